@@ -17,7 +17,18 @@
 // Package main load the Components CLI
 package main
 
-func main() {
+import (
+	"fmt"
+	"os"
+
+	"scanoss.com/components/pkg/cmd"
+)
 
-	// TODO Add CLI entry point...
+// main runs the Components CLI.
+func main() {
+	code, err := cmd.RunCli(os.Args[1:])
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+	}
+	os.Exit(code)
 }
@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/encoding/protojson"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// The ComponentsExtension service extends the componentsv2 API with the inputs and results that do not fit in its
+// (fixed) messages. Its requests and responses are the JSON encoded DTOs, so it is called using the JSONCodecName
+// content subtype, and over REST each method is a POST of the JSON request to ExtensionRESTPath/<method>.
+const (
+	ExtensionServiceName = "scanoss.components.v2.ComponentsExtension"
+	ExtensionRESTPath    = "/v2/components/ext"
+)
+
+// ComponentsExtension service methods.
+const (
+	ExtensionSearchComponents  = "SearchComponents"
+	ExtensionSuggestComponents = "SuggestComponents"
+	ExtensionLookupURL         = "LookupURL"
+	ExtensionComponentVersions = "GetComponentVersions"
+	ExtensionResolveVersion    = "ResolveVersion"
+	ExtensionComponentsStatus  = "GetComponentsStatus"
+)
+
+// JSONCodecName is the gRPC content subtype of the ComponentsExtension messages. It is specific to the service, so
+// the codec is not picked for the messages of any other service.
+const JSONCodecName = "scanoss-components-extension-json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec encodes the ComponentsExtension messages as JSON.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return JSONCodecName
+}
+
+// statusMarshalOptions and statusUnmarshalOptions encode the response status the same way as the REST gateway
+// encodes the componentsv2 responses.
+var (
+	statusMarshalOptions   = protojson.MarshalOptions{EmitUnpopulated: true}
+	statusUnmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// ExtensionStatus is the status of a ComponentsExtension response. It is JSON encoded with protojson, like the status
+// of the componentsv2 responses (i.e. "status": "SUCCESS" rather than the number of the enum value).
+type ExtensionStatus struct {
+	*common.StatusResponse
+}
+
+// MarshalJSON encodes the status with protojson.
+func (s ExtensionStatus) MarshalJSON() ([]byte, error) {
+	if s.StatusResponse == nil {
+		return []byte("null"), nil
+	}
+	return statusMarshalOptions.Marshal(s.StatusResponse)
+}
+
+// UnmarshalJSON decodes a protojson encoded status.
+func (s *ExtensionStatus) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		s.StatusResponse = nil
+		return nil
+	}
+	s.StatusResponse = &common.StatusResponse{}
+	return statusUnmarshalOptions.Unmarshal(data, s.StatusResponse)
+}
+
+// ExtensionMethod returns the full gRPC method name of a ComponentsExtension method.
+func ExtensionMethod(name string) string {
+	return "/" + ExtensionServiceName + "/" + name
+}
+
+// IsExtensionMethod reports whether the name is a ComponentsExtension method.
+func IsExtensionMethod(name string) bool {
+	for _, m := range componentsExtensionServiceDesc.Methods {
+		if m.MethodName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchComponentsResponse is the response of a ComponentsExtension component search.
+type SearchComponentsResponse struct {
+	Status ExtensionStatus `json:"status"`
+	dtos.ComponentsSearchOutput
+}
+
+// GetStatus returns the status of the response (nil if there is no response).
+func (r *SearchComponentsResponse) GetStatus() *common.StatusResponse {
+	if r == nil {
+		return nil
+	}
+	return r.Status.StatusResponse
+}
+
+// SuggestComponentsResponse is the response of a ComponentsExtension component suggestion (autocomplete) request.
+type SuggestComponentsResponse struct {
+	Status ExtensionStatus `json:"status"`
+	dtos.ComponentsSearchOutput
+}
+
+// GetStatus returns the status of the response (nil if there is no response).
+func (r *SuggestComponentsResponse) GetStatus() *common.StatusResponse {
+	if r == nil {
+		return nil
+	}
+	return r.Status.StatusResponse
+}
+
+// LookupURLResponse is the response of a ComponentsExtension URL lookup.
+type LookupURLResponse struct {
+	Status ExtensionStatus `json:"status"`
+	dtos.ComponentsSearchOutput
+}
+
+// GetStatus returns the status of the response (nil if there is no response).
+func (r *LookupURLResponse) GetStatus() *common.StatusResponse {
+	if r == nil {
+		return nil
+	}
+	return r.Status.StatusResponse
+}
+
+// ComponentVersionsResponse is the response of a ComponentsExtension component versions request.
+type ComponentVersionsResponse struct {
+	Status ExtensionStatus `json:"status"`
+	dtos.ComponentVersionsOutput
+}
+
+// GetStatus returns the status of the response (nil if there is no response).
+func (r *ComponentVersionsResponse) GetStatus() *common.StatusResponse {
+	if r == nil {
+		return nil
+	}
+	return r.Status.StatusResponse
+}
+
+// ResolveVersionResponse is the response of a ComponentsExtension version resolution.
+type ResolveVersionResponse struct {
+	Status ExtensionStatus `json:"status"`
+	dtos.ComponentResolutionOutput
+}
+
+// GetStatus returns the status of the response (nil if there is no response).
+func (r *ResolveVersionResponse) GetStatus() *common.StatusResponse {
+	if r == nil {
+		return nil
+	}
+	return r.Status.StatusResponse
+}
+
+// ComponentsStatusResponse is the response of a ComponentsExtension components status request.
+type ComponentsStatusResponse struct {
+	Status ExtensionStatus `json:"status"`
+	dtos.ComponentsStatusOutput
+	Policy  *dtos.ComponentsPolicyOutput  `json:"policy,omitempty"`  // Verdicts of the configured policy (if any)
+	Waivers *dtos.ComponentsWaiversOutput `json:"waivers,omitempty"` // Waivers applied to the components (if any)
+}
+
+// GetStatus returns the status of the response (nil if there is no response).
+func (r *ComponentsStatusResponse) GetStatus() *common.StatusResponse {
+	if r == nil {
+		return nil
+	}
+	return r.Status.StatusResponse
+}
+
+// ComponentsExtensionServer is the server API of the ComponentsExtension service.
+type ComponentsExtensionServer interface {
+	// SearchComponents searches for components using every search input (filters, sort, cursor, etc.)
+	SearchComponents(ctx context.Context, request *dtos.ComponentSearchInput) (*SearchComponentsResponse, error)
+	// SuggestComponents returns the most popular components starting with a prefix (autocomplete)
+	SuggestComponents(ctx context.Context, request *dtos.ComponentSuggestInput) (*SuggestComponentsResponse, error)
+	// LookupURL returns the purls (and versions) of a repository, registry or download URL
+	LookupURL(ctx context.Context, request *dtos.ComponentURLInput) (*LookupURLResponse, error)
+	// GetComponentVersions lists the versions of a component, with their license expressions, statuses and the
+	// highest version matching the requirement
+	GetComponentVersions(ctx context.Context, request *dtos.ComponentVersionsInput) (*ComponentVersionsResponse, error)
+	// ResolveVersion explains how the version requirement of a component resolves to a concrete version
+	ResolveVersion(ctx context.Context, request *dtos.ComponentStatusInput) (*ResolveVersionResponse, error)
+	// GetComponentsStatus returns the status of the components, with the verdicts of the configured policy and waivers
+	GetComponentsStatus(ctx context.Context, request *dtos.ComponentsStatusInput) (*ComponentsStatusResponse, error)
+}
+
+// RegisterComponentsExtensionServer registers the ComponentsExtension service with a gRPC server.
+func RegisterComponentsExtensionServer(s grpc.ServiceRegistrar, srv ComponentsExtensionServer) {
+	s.RegisterService(&componentsExtensionServiceDesc, srv)
+}
+
+var componentsExtensionServiceDesc = grpc.ServiceDesc{
+	ServiceName: ExtensionServiceName,
+	HandlerType: (*ComponentsExtensionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: ExtensionSearchComponents, Handler: unaryHandler(ExtensionSearchComponents, ComponentsExtensionServer.SearchComponents)},
+		{MethodName: ExtensionSuggestComponents, Handler: unaryHandler(ExtensionSuggestComponents, ComponentsExtensionServer.SuggestComponents)},
+		{MethodName: ExtensionLookupURL, Handler: unaryHandler(ExtensionLookupURL, ComponentsExtensionServer.LookupURL)},
+		{MethodName: ExtensionComponentVersions, Handler: unaryHandler(ExtensionComponentVersions, ComponentsExtensionServer.GetComponentVersions)},
+		{MethodName: ExtensionResolveVersion, Handler: unaryHandler(ExtensionResolveVersion, ComponentsExtensionServer.ResolveVersion)},
+		{MethodName: ExtensionComponentsStatus, Handler: unaryHandler(ExtensionComponentsStatus, ComponentsExtensionServer.GetComponentsStatus)},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "scanoss/api/components/v2/scanoss-components-extension",
+}
+
+// unaryHandler returns the gRPC handler of a ComponentsExtension method, decoding its request and running it
+// through the server interceptors.
+func unaryHandler[Req, Resp any](name string, method func(ComponentsExtensionServer, context.Context, *Req) (*Resp, error)) grpc.MethodHandler {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := new(Req)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return method(srv.(ComponentsExtensionServer), ctx, req.(*Req))
+		}
+		if interceptor == nil {
+			return handler(ctx, in)
+		}
+		return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: ExtensionMethod(name)}, handler)
+	}
+}
+
+// ComponentsExtensionClient is the client API of the ComponentsExtension service.
+type ComponentsExtensionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewComponentsExtensionClient creates a ComponentsExtension client using the supplied connection.
+func NewComponentsExtensionClient(cc grpc.ClientConnInterface) *ComponentsExtensionClient {
+	return &ComponentsExtensionClient{cc: cc}
+}
+
+// SearchComponents searches for components using every search input.
+func (c *ComponentsExtensionClient) SearchComponents(ctx context.Context, in *dtos.ComponentSearchInput, opts ...grpc.CallOption) (*SearchComponentsResponse, error) {
+	out := new(SearchComponentsResponse)
+	if err := c.invoke(ctx, ExtensionSearchComponents, in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SuggestComponents returns the most popular components starting with a prefix.
+func (c *ComponentsExtensionClient) SuggestComponents(ctx context.Context, in *dtos.ComponentSuggestInput, opts ...grpc.CallOption) (*SuggestComponentsResponse, error) {
+	out := new(SuggestComponentsResponse)
+	if err := c.invoke(ctx, ExtensionSuggestComponents, in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LookupURL returns the purls (and versions) of a repository, registry or download URL.
+func (c *ComponentsExtensionClient) LookupURL(ctx context.Context, in *dtos.ComponentURLInput, opts ...grpc.CallOption) (*LookupURLResponse, error) {
+	out := new(LookupURLResponse)
+	if err := c.invoke(ctx, ExtensionLookupURL, in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetComponentVersions lists the versions of a component.
+func (c *ComponentsExtensionClient) GetComponentVersions(ctx context.Context, in *dtos.ComponentVersionsInput, opts ...grpc.CallOption) (*ComponentVersionsResponse, error) {
+	out := new(ComponentVersionsResponse)
+	if err := c.invoke(ctx, ExtensionComponentVersions, in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResolveVersion explains how the version requirement of a component resolves to a concrete version.
+func (c *ComponentsExtensionClient) ResolveVersion(ctx context.Context, in *dtos.ComponentStatusInput, opts ...grpc.CallOption) (*ResolveVersionResponse, error) {
+	out := new(ResolveVersionResponse)
+	if err := c.invoke(ctx, ExtensionResolveVersion, in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetComponentsStatus returns the status of the components, with the verdicts of the configured policy and waivers.
+func (c *ComponentsExtensionClient) GetComponentsStatus(ctx context.Context, in *dtos.ComponentsStatusInput, opts ...grpc.CallOption) (*ComponentsStatusResponse, error) {
+	out := new(ComponentsStatusResponse)
+	if err := c.invoke(ctx, ExtensionComponentsStatus, in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// invoke calls a ComponentsExtension method, JSON encoding its messages.
+func (c *ComponentsExtensionClient) invoke(ctx context.Context, name string, in, out any, opts []grpc.CallOption) error {
+	return c.cc.Invoke(ctx, ExtensionMethod(name), in, out, append(opts, grpc.CallContentSubtype(JSONCodecName))...)
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"strings"
+	"testing"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"google.golang.org/grpc/encoding"
+	"scanoss.com/components/pkg/dtos"
+)
+
+func TestJSONCodec(t *testing.T) {
+	codec := encoding.GetCodec(JSONCodecName)
+	if codec == nil {
+		t.Fatalf("expected the %v codec to be registered", JSONCodecName)
+	}
+	if c := encoding.GetCodec("json"); c != nil && c == codec {
+		t.Errorf("did not expect the codec to be registered under the generic json name")
+	}
+	resp := &ComponentVersionsResponse{
+		Status:                  ExtensionStatus{StatusResponse: &common.StatusResponse{Status: common.StatusCode_SUCCESS, Message: "Success"}},
+		ComponentVersionsOutput: dtos.ComponentVersionsOutput{Component: dtos.ComponentOutput{Purl: "pkg:npm/react"}},
+	}
+	data, err := codec.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	// The status is encoded the same way as in the componentsv2 responses
+	if !strings.Contains(string(data), `"status":"SUCCESS"`) || !strings.Contains(string(data), `"purl":"pkg:npm/react"`) {
+		t.Errorf("Marshal() unexpected JSON: %s", data)
+	}
+	var decoded ComponentVersionsResponse
+	if err = codec.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.GetStatus().GetStatus() != common.StatusCode_SUCCESS || decoded.GetStatus().GetMessage() != "Success" ||
+		decoded.Component.Purl != "pkg:npm/react" {
+		t.Errorf("Unmarshal() unexpected response: %+v", decoded)
+	}
+	// A missing status is decoded as nil
+	decoded = ComponentVersionsResponse{}
+	if err = codec.Unmarshal([]byte(`{"status": null}`), &decoded); err != nil || decoded.GetStatus() != nil {
+		t.Errorf("Unmarshal() expected a nil status, got %v (%v)", decoded.GetStatus(), err)
+	}
+	if (*ComponentVersionsResponse)(nil).GetStatus() != nil {
+		t.Errorf("expected a nil response to have a nil status")
+	}
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package api contains the parts of the Component Service API shared by the service and its clients:
+// the request and response metadata keys that are not part of the componentsv2 messages, and the
+// ComponentsExtension service.
+// Over REST, the metadata is forwarded as HTTP headers with the RESTMetadataPrefix.
+package api
+
+// RESTMetadataPrefix is the prefix the REST gateway uses to forward gRPC metadata as HTTP headers.
+const RESTMetadataPrefix = "Grpc-Metadata-"
+
+// HTTPCodeTrailer is the response trailer holding the HTTP status code of a failed request.
+const HTTPCodeTrailer = "x-http-code"
+
+// Component search request metadata.
+const (
+	SearchCursorHeader    = "x-search-cursor"    // Cursor (from a previous page) to continue the search from
+	SearchFuzzyHeader     = "x-search-fuzzy"     // Typo tolerant search (true/false)
+	SearchAllTypesHeader  = "x-search-all-types" // Search across every purl type when no package type is supplied (true/false)
+	SearchSortHeader      = "x-search-sort"      // Sort key
+	SearchOrderHeader     = "x-search-order"     // Sort direction (asc/desc)
+	SearchNamespaceHeader = "x-search-namespace" // Namespace to list the components of
+)
+
+// Component search response metadata.
+const (
+	SearchDidYouMeanHeader = "x-did-you-mean"
+	SearchNextCursorHeader = "x-next-cursor"
+	SearchTotalHeader      = "x-total-count"
+	SearchHasMoreHeader    = "x-has-more"
+)
+
+// Component versions request and response metadata.
+const (
+	VersionsOrderHeader       = "x-versions-order"       // Order of the versions: date (default) or version
+	VersionsRequirementHeader = "x-versions-requirement" // Only list the versions satisfying this requirement
+	// Exclude the pre-releases and/or the yanked (removed or deleted) versions (true/false)
+	VersionsExcludePreReleasesHeader = "x-versions-exclude-pre-releases"
+	VersionsExcludeYankedHeader      = "x-versions-exclude-yanked"
+	// Classified statuses are returned as a multi-valued header of URL escaped "version=status" pairs
+	VersionsStatusHeader = "x-version-status"
+)
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package client contains the gRPC and REST clients used to talk to a running Component Service.
+// Both clients accept and return the same DTOs as the use case layer, so callers can switch between
+// local database access and a remote service transparently.
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"scanoss.com/components/pkg/dtos"
+	se "scanoss.com/components/pkg/errors"
+)
+
+// Supported client protocols.
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolREST = "rest"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Config holds the connection settings for a remote Component Service.
+type Config struct {
+	Address    string        // host:port for gRPC, base URL for REST
+	Protocol   string        // grpc or rest
+	TLS        bool          // Use TLS to connect to the service
+	CAFile     string        // Optional CA/certificate file to trust (PEM)
+	ServerName string        // Optional server name (CN) to verify the certificate against
+	Timeout    time.Duration // Request timeout
+}
+
+// tlsConfig builds the TLS client configuration from the supplied settings.
+func (c Config) tlsConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: c.ServerName}
+	if len(c.CAFile) > 0 {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file %v: %v", c.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates found in CA file: %v", c.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	return tlsCfg, nil
+}
+
+// timeout returns the configured request timeout, or the default if not set.
+func (c Config) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return defaultTimeout
+	}
+	return c.Timeout
+}
+
+// newRemoteError creates a ServiceError describing a failure reported by the remote service.
+func newRemoteError(message string, httpCode int, err error) *se.ServiceError {
+	return &se.ServiceError{
+		Message:      message,
+		HTTPCode:     httpCode,
+		InternalCode: "REMOTE_" + strconv.Itoa(httpCode),
+		Err:          err,
+	}
+}
+
+// parseHTTPCode converts the value of an x-http-code trailer into an HTTP status (falling back to 500).
+func parseHTTPCode(value string) int {
+	code, err := strconv.Atoi(value)
+	if err != nil || code < 100 || code > 599 {
+		return http.StatusInternalServerError
+	}
+	return code
+}
+
+// ErrUnsupportedProtocol is returned when an unknown client protocol is requested.
+var ErrUnsupportedProtocol = errors.New("unsupported protocol")
+
+// Client is implemented by both the gRPC and REST Component Service clients.
+type Client interface {
+	SearchComponents(request dtos.ComponentSearchInput) (dtos.ComponentsSearchOutput, error)
+	SuggestComponents(request dtos.ComponentSuggestInput) (dtos.ComponentsSearchOutput, error)
+	LookupURL(request dtos.ComponentURLInput) (dtos.ComponentsSearchOutput, error)
+	GetComponentVersions(request dtos.ComponentVersionsInput) (dtos.ComponentVersionsOutput, error)
+	GetComponentStatus(request dtos.ComponentStatusInput) (dtos.ComponentStatusOutput, error)
+	GetComponentsStatus(request dtos.ComponentsStatusInput) (dtos.ComponentsStatusOutput, error)
+	ResolveVersion(request dtos.ComponentStatusInput) (dtos.ComponentResolutionOutput, error)
+	Close() error
+}
+
+// NewClient creates a gRPC or REST client depending on the configured protocol (defaults to gRPC).
+func NewClient(s *zap.SugaredLogger, cfg Config) (Client, error) {
+	switch strings.ToLower(cfg.Protocol) {
+	case "", ProtocolGRPC:
+		return NewGrpcClient(s, cfg)
+	case ProtocolREST:
+		return NewRestClient(s, cfg)
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedProtocol, cfg.Protocol)
+	}
+}
@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/componentsv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"scanoss.com/components/pkg/api"
+	"scanoss.com/components/pkg/dtos"
+	se "scanoss.com/components/pkg/errors"
+)
+
+func TestCheckGrpcResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		status   *common.StatusResponse
+		trailer  metadata.MD
+		wantCode int
+	}{
+		{
+			name:     "success",
+			status:   &common.StatusResponse{Status: common.StatusCode_SUCCESS},
+			wantCode: 0,
+		},
+		{
+			name:     "failed status with trailer",
+			status:   &common.StatusResponse{Status: common.StatusCode_FAILED, Message: "No components found"},
+			trailer:  metadata.Pairs("x-http-code", "404"),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "failed status without trailer",
+			status:   &common.StatusResponse{Status: common.StatusCode_FAILED},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "grpc error without trailer",
+			err:      status.Error(codes.InvalidArgument, "bad purl"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "grpc error with bad trailer",
+			err:      status.Error(codes.Unknown, "boom"),
+			trailer:  metadata.Pairs("x-http-code", "abc"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkGrpcResponse(tt.err, tt.status, tt.trailer)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("checkGrpcResponse() unexpected error = %v", err)
+				}
+				return
+			}
+			serviceErr, ok := se.GetServiceError(err)
+			if !ok {
+				t.Fatalf("checkGrpcResponse() expected a ServiceError, got %v", err)
+			}
+			if serviceErr.GetHTTPCode() != tt.wantCode {
+				t.Errorf("checkGrpcResponse() http code = %v, want %v", serviceErr.GetHTTPCode(), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestConvertStatusResponse(t *testing.T) {
+	msg := "Component not found"
+	code := string(domain.ComponentNotFound)
+	resp := &pb.ComponentsStatusResponse{Components: []*pb.ComponentStatusResponse{
+		{
+			Purl: "pkg:npm/react",
+			Name: "react",
+			ComponentStatus: &pb.ComponentStatusResponse_ComponentStatus{
+				Status:           "active",
+				RepositoryStatus: "active",
+				FirstIndexedDate: "2015-01-01",
+			},
+			VersionStatus: &pb.ComponentStatusResponse_VersionStatus{Version: "18.0.0", Status: "active"},
+		},
+		{
+			Purl:            "pkg:npm/not-there",
+			ComponentStatus: &pb.ComponentStatusResponse_ComponentStatus{InfoMessage: &msg, InfoCode: &code},
+		},
+	}}
+	output := convertStatusesResponse(resp)
+	if len(output.Components) != 2 {
+		t.Fatalf("convertStatusesResponse() returned %v components, want 2", len(output.Components))
+	}
+	first := output.Components[0]
+	if first.ComponentStatus.Status != "active" || first.VersionStatus.Version != "18.0.0" || first.ComponentStatus.ErrorCode != nil {
+		t.Errorf("convertStatusesResponse() unexpected first component: %+v", first)
+	}
+	second := output.Components[1]
+	if second.ComponentStatus.ErrorCode == nil || *second.ComponentStatus.ErrorCode != domain.ComponentNotFound {
+		t.Errorf("convertStatusesResponse() expected error code %v, got %+v", domain.ComponentNotFound, second.ComponentStatus)
+	}
+	if second.VersionStatus != nil {
+		t.Errorf("convertStatusesResponse() expected no version status, got %+v", second.VersionStatus)
+	}
+	if empty := convertStatusesResponse(nil); empty.Components == nil {
+		t.Errorf("convertStatusesResponse() expected an empty component list for nil input")
+	}
+}
+
+func TestRestClientErrorStatus(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	s := ctxzap.Extract(ctxzap.ToContext(context.Background(), zlog.L)).Sugar()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != api.ExtensionRESTPath+"/"+api.ExtensionSearchComponents {
+			t.Errorf("unexpected request: %v %v", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status": {"status": "FAILED", "message": "No components found matching the search criteria"}}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(s, Config{Address: srv.URL, Protocol: ProtocolREST})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	_, err = c.SearchComponents(dtos.ComponentSearchInput{Search: "angular"})
+	serviceErr, ok := se.GetServiceError(err)
+	if !ok {
+		t.Fatalf("SearchComponents() expected a ServiceError, got %v", err)
+	}
+	if serviceErr.GetHTTPCode() != http.StatusNotFound || serviceErr.Message != "No components found matching the search criteria" {
+		t.Errorf("SearchComponents() unexpected error: %v (%v)", serviceErr, serviceErr.GetHTTPCode())
+	}
+
+	_, err = NewClient(s, Config{Address: srv.URL, Protocol: "smtp"})
+	if !errors.Is(err, ErrUnsupportedProtocol) {
+		t.Errorf("NewClient() expected ErrUnsupportedProtocol, got %v", err)
+	}
+}
+
+func TestRestClientSearchComponents(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	s := ctxzap.Extract(ctxzap.ToContext(context.Background(), zlog.L)).Sugar()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request dtos.ComponentSearchInput
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode the search request: %v", err)
+		}
+		if request.Search != "react" || request.Cursor != "abc" || request.MinStars != 100 || len(request.ExcludeStatuses) != 1 {
+			t.Errorf("unexpected search request: %+v", request)
+		}
+		_, _ = w.Write([]byte(`{"status": {"status": "SUCCESS", "message": "Success"}, "total": 42, "has_more": true, "next_cursor": "def",
+			"components": [{"purl": "pkg:npm/react", "purl_type": "npm", "details": {"latest_version": "18.0.0", "stars": 42}}],
+			"facets": {"purl_type": [{"value": "npm", "count": 12}]}}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewRestClient(s, Config{Address: srv.URL})
+	if err != nil {
+		t.Fatalf("NewRestClient() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	request := dtos.ComponentSearchInput{Search: "react", Cursor: "abc"}
+	request.MinStars = 100
+	request.ExcludeStatuses = []string{"deleted"}
+	output, err := c.SearchComponents(request)
+	if err != nil {
+		t.Fatalf("SearchComponents() error = %v", err)
+	}
+	if output.Total != 42 || !output.HasMore || output.NextCursor != "def" || len(output.Components) != 1 {
+		t.Errorf("SearchComponents() unexpected pagination: %+v", output)
+	}
+	if d := output.Components[0].Details; d == nil || d.LatestVersion != "18.0.0" || d.Stars != 42 || output.Components[0].PurlType != "npm" {
+		t.Errorf("SearchComponents() unexpected results: %+v", output.Components)
+	}
+	if output.Facets == nil || len(output.Facets.PurlType) != 1 || output.Facets.PurlType[0] != (dtos.FacetCount{Value: "npm", Count: 12}) {
+		t.Errorf("SearchComponents() unexpected facets: %+v", output.Facets)
+	}
+}
+
+func TestRestClientSuggestComponents(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	s := ctxzap.Extract(ctxzap.ToContext(context.Background(), zlog.L)).Sugar()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != api.ExtensionRESTPath+"/"+api.ExtensionSuggestComponents {
+			t.Errorf("unexpected request: %v %v", r.Method, r.URL.Path)
+		}
+		var request dtos.ComponentSuggestInput
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Prefix != "reac" || request.Limit != 2 {
+			t.Errorf("unexpected suggest request: %+v (%v)", request, err)
+		}
+		_, _ = w.Write([]byte(`{"status": {"status": "SUCCESS", "message": "Success"}, "total": 1,
+			"components": [{"purl": "pkg:npm/react", "purl_type": "npm"}]}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewRestClient(s, Config{Address: srv.URL})
+	if err != nil {
+		t.Fatalf("NewRestClient() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	output, err := c.SuggestComponents(dtos.ComponentSuggestInput{Prefix: "reac", Package: "npm", Limit: 2})
+	if err != nil {
+		t.Fatalf("SuggestComponents() error = %v", err)
+	}
+	if output.Total != 1 || len(output.Components) != 1 || output.Components[0].Purl != "pkg:npm/react" {
+		t.Errorf("SuggestComponents() unexpected suggestions: %+v", output)
+	}
+}
+
+func TestRestClientLookupURL(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	s := ctxzap.Extract(ctxzap.ToContext(context.Background(), zlog.L)).Sugar()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != api.ExtensionRESTPath+"/"+api.ExtensionLookupURL {
+			t.Errorf("unexpected request: %v %v", r.Method, r.URL.Path)
+		}
+		var request dtos.ComponentURLInput
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.URL != "https://github.com/angular/angular" {
+			t.Errorf("unexpected lookup request: %+v (%v)", request, err)
+		}
+		_, _ = w.Write([]byte(`{"status": {"status": "SUCCESS", "message": "Success"}, "total": 1,
+			"components": [{"purl": "pkg:github/angular/angular", "purl_type": "github"}]}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewRestClient(s, Config{Address: srv.URL})
+	if err != nil {
+		t.Fatalf("NewRestClient() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	output, err := c.LookupURL(dtos.ComponentURLInput{URL: "https://github.com/angular/angular"})
+	if err != nil {
+		t.Fatalf("LookupURL() error = %v", err)
+	}
+	if output.Total != 1 || len(output.Components) != 1 || output.Components[0].Purl != "pkg:github/angular/angular" {
+		t.Errorf("LookupURL() unexpected purls: %+v", output)
+	}
+}
+
+func TestRestClientGetComponentVersions(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	s := ctxzap.Extract(ctxzap.ToContext(context.Background(), zlog.L)).Sugar()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != api.ExtensionRESTPath+"/"+api.ExtensionComponentVersions {
+			t.Errorf("unexpected request: %v %v", r.Method, r.URL.Path)
+		}
+		var request dtos.ComponentVersionsInput
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Purl != "pkg:npm/react" || request.Order != "version" {
+			t.Errorf("unexpected versions request: %+v (%v)", request, err)
+		}
+		_, _ = w.Write([]byte(`{"status": {"status": "SUCCESS", "message": "Success"}, "component": {"purl": "pkg:npm/react",
+			"versions": [{"version": "18.0.0", "license_expression": "Apache-2.0 AND MIT", "status": "removed"}]}}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewRestClient(s, Config{Address: srv.URL})
+	if err != nil {
+		t.Fatalf("NewRestClient() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	output, err := c.GetComponentVersions(dtos.ComponentVersionsInput{Purl: "pkg:npm/react", Order: "version"})
+	if err != nil {
+		t.Fatalf("GetComponentVersions() error = %v", err)
+	}
+	versions := output.Component.Versions
+	if len(versions) != 1 || versions[0].LicenseExpression != "Apache-2.0 AND MIT" || versions[0].Status != "removed" {
+		t.Errorf("GetComponentVersions() unexpected versions: %+v", output)
+	}
+}
+
+func TestRestClientResolveVersion(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	s := ctxzap.Extract(ctxzap.ToContext(context.Background(), zlog.L)).Sugar()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != api.ExtensionRESTPath+"/"+api.ExtensionResolveVersion {
+			t.Errorf("unexpected request: %v %v", r.Method, r.URL.Path)
+		}
+		var request dtos.ComponentStatusInput
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Purl != "pkg:npm/react" || request.Requirement != "^16.8" {
+			t.Errorf("unexpected resolution request: %+v (%v)", request, err)
+		}
+		_, _ = w.Write([]byte(`{"status": {"status": "SUCCESS", "message": "Success"}, "purl": "pkg:npm/react", "requirement": "^16.8",
+			"version": "16.13.1", "rule": "highest version", "candidates": [{"version": "16.14.0", "status": "removed",
+			"rejected": "yanked"}, {"version": "16.13.1", "selected": true}]}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewRestClient(s, Config{Address: srv.URL})
+	if err != nil {
+		t.Fatalf("NewRestClient() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	output, err := c.ResolveVersion(dtos.ComponentStatusInput{Purl: "pkg:npm/react", Requirement: "^16.8"})
+	if err != nil {
+		t.Fatalf("ResolveVersion() error = %v", err)
+	}
+	if output.Version != "16.13.1" || len(output.Candidates) != 2 || output.Candidates[0].Rejected != dtos.RejectedYanked ||
+		!output.Candidates[1].Selected {
+		t.Errorf("ResolveVersion() unexpected resolution: %+v", output)
+	}
+}
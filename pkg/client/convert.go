@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/componentsv2"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// convertStatusInput converts a ComponentStatusInput DTO into a gRPC ComponentRequest.
+func convertStatusInput(request dtos.ComponentStatusInput) *common.ComponentRequest {
+	return &common.ComponentRequest{Purl: request.Purl, Requirement: request.Requirement}
+}
+
+// convertStatusesInput converts a ComponentsStatusInput DTO into a gRPC ComponentsRequest.
+func convertStatusesInput(request dtos.ComponentsStatusInput) *common.ComponentsRequest {
+	components := make([]*common.ComponentRequest, 0, len(request.Components))
+	for _, c := range request.Components {
+		components = append(components, convertStatusInput(c))
+	}
+	return &common.ComponentsRequest{Components: components}
+}
+
+// convertStatusResponse converts a gRPC ComponentStatusResponse into a ComponentStatusOutput DTO.
+// It is the inverse of the service side conversion, mapping InfoMessage/InfoCode back onto the error fields.
+func convertStatusResponse(resp *pb.ComponentStatusResponse) dtos.ComponentStatusOutput {
+	if resp == nil {
+		return dtos.ComponentStatusOutput{}
+	}
+	output := dtos.ComponentStatusOutput{
+		Purl:        resp.Purl,
+		Name:        resp.Name,
+		Requirement: resp.Requirement,
+	}
+	if cs := resp.ComponentStatus; cs != nil {
+		output.ComponentStatus = &dtos.ComponentStatusInfo{
+			Status:           cs.Status,
+			RepositoryStatus: cs.RepositoryStatus,
+			FirstIndexedDate: cs.FirstIndexedDate,
+			LastIndexedDate:  cs.LastIndexedDate,
+			StatusChangeDate: cs.StatusChangeDate,
+			ErrorMessage:     cs.InfoMessage,
+			ErrorCode:        toStatusCode(cs.InfoCode),
+		}
+	}
+	if vs := resp.VersionStatus; vs != nil {
+		output.VersionStatus = &dtos.VersionStatusOutput{
+			Version:          vs.Version,
+			Status:           vs.Status,
+			RepositoryStatus: vs.RepositoryStatus,
+			IndexedDate:      vs.IndexedDate,
+			StatusChangeDate: vs.StatusChangeDate,
+			ErrorMessage:     vs.InfoMessage,
+			ErrorCode:        toStatusCode(vs.InfoCode),
+		}
+	}
+	return output
+}
+
+// convertStatusesResponse converts a gRPC ComponentsStatusResponse into a ComponentsStatusOutput DTO.
+func convertStatusesResponse(resp *pb.ComponentsStatusResponse) dtos.ComponentsStatusOutput {
+	output := dtos.ComponentsStatusOutput{Components: []dtos.ComponentStatusOutput{}}
+	if resp == nil {
+		return output
+	}
+	for _, c := range resp.Components {
+		output.Components = append(output.Components, convertStatusResponse(c))
+	}
+	return output
+}
+
+// toStatusCode converts an optional info code string into a domain status code.
+func toStatusCode(code *string) *domain.StatusCode {
+	if code == nil || len(*code) == 0 {
+		return nil
+	}
+	sc := domain.StatusCode(*code)
+	return &sc
+}
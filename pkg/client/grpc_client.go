@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/componentsv2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"scanoss.com/components/pkg/api"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// GrpcClient talks to a Component Service over gRPC using the componentsv2 stubs (and the ComponentsExtension service).
+type GrpcClient struct {
+	s         *zap.SugaredLogger
+	cfg       Config
+	conn      *grpc.ClientConn
+	client    pb.ComponentsClient
+	extension *api.ComponentsExtensionClient
+}
+
+// NewGrpcClient creates a gRPC client connection for the supplied config.
+func NewGrpcClient(s *zap.SugaredLogger, cfg Config) (*GrpcClient, error) {
+	creds := insecure.NewCredentials()
+	if cfg.TLS {
+		tlsCfg, err := cfg.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsCfg)
+	}
+	conn, err := grpc.NewClient(cfg.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %v: %v", cfg.Address, err)
+	}
+	return &GrpcClient{s: s, cfg: cfg, conn: conn, client: pb.NewComponentsClient(conn),
+		extension: api.NewComponentsExtensionClient(conn)}, nil
+}
+
+// Close closes the underlying gRPC connection.
+func (c *GrpcClient) Close() error {
+	return c.conn.Close()
+}
+
+// SearchComponents searches the remote service for components, using the ComponentsExtension service
+// (which accepts every search input and returns the pagination, facets and details in its response).
+func (c *GrpcClient) SearchComponents(request dtos.ComponentSearchInput) (dtos.ComponentsSearchOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.timeout())
+	defer cancel()
+	var trailer metadata.MD
+	resp, err := c.extension.SearchComponents(ctx, &request, grpc.Trailer(&trailer))
+	if err = checkGrpcResponse(err, resp.GetStatus(), trailer); err != nil {
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	return resp.ComponentsSearchOutput, nil
+}
+
+// SuggestComponents retrieves the most popular components starting with a prefix from the remote service,
+// using the ComponentsExtension service.
+func (c *GrpcClient) SuggestComponents(request dtos.ComponentSuggestInput) (dtos.ComponentsSearchOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.timeout())
+	defer cancel()
+	var trailer metadata.MD
+	resp, err := c.extension.SuggestComponents(ctx, &request, grpc.Trailer(&trailer))
+	if err = checkGrpcResponse(err, resp.GetStatus(), trailer); err != nil {
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	return resp.ComponentsSearchOutput, nil
+}
+
+// LookupURL retrieves the purls (and versions) of a repository, registry or download URL from the remote service,
+// using the ComponentsExtension service.
+func (c *GrpcClient) LookupURL(request dtos.ComponentURLInput) (dtos.ComponentsSearchOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.timeout())
+	defer cancel()
+	var trailer metadata.MD
+	resp, err := c.extension.LookupURL(ctx, &request, grpc.Trailer(&trailer))
+	if err = checkGrpcResponse(err, resp.GetStatus(), trailer); err != nil {
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	return resp.ComponentsSearchOutput, nil
+}
+
+// GetComponentVersions retrieves the versions of a component from the remote service, using the
+// ComponentsExtension service (which returns the license expression, status and requirement match of each version).
+func (c *GrpcClient) GetComponentVersions(request dtos.ComponentVersionsInput) (dtos.ComponentVersionsOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.timeout())
+	defer cancel()
+	var trailer metadata.MD
+	resp, err := c.extension.GetComponentVersions(ctx, &request, grpc.Trailer(&trailer))
+	if err = checkGrpcResponse(err, resp.GetStatus(), trailer); err != nil {
+		return dtos.ComponentVersionsOutput{}, err
+	}
+	return resp.ComponentVersionsOutput, nil
+}
+
+// GetComponentStatus retrieves the status of a single component from the remote service.
+func (c *GrpcClient) GetComponentStatus(request dtos.ComponentStatusInput) (dtos.ComponentStatusOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.timeout())
+	defer cancel()
+	var trailer metadata.MD
+	resp, err := c.client.GetComponentStatus(ctx, convertStatusInput(request), grpc.Trailer(&trailer))
+	if err = checkGrpcResponse(err, nil, trailer); err != nil {
+		return dtos.ComponentStatusOutput{}, err
+	}
+	return convertStatusResponse(resp), nil
+}
+
+// ResolveVersion explains how the version requirement of a component resolves to a concrete version, using the
+// ComponentsExtension service.
+func (c *GrpcClient) ResolveVersion(request dtos.ComponentStatusInput) (dtos.ComponentResolutionOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.timeout())
+	defer cancel()
+	var trailer metadata.MD
+	resp, err := c.extension.ResolveVersion(ctx, &request, grpc.Trailer(&trailer))
+	if err = checkGrpcResponse(err, resp.GetStatus(), trailer); err != nil {
+		return dtos.ComponentResolutionOutput{}, err
+	}
+	return resp.ComponentResolutionOutput, nil
+}
+
+// GetComponentsStatus retrieves the status of multiple components from the remote service.
+func (c *GrpcClient) GetComponentsStatus(request dtos.ComponentsStatusInput) (dtos.ComponentsStatusOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.timeout())
+	defer cancel()
+	var trailer metadata.MD
+	resp, err := c.client.GetComponentsStatus(ctx, convertStatusesInput(request), grpc.Trailer(&trailer))
+	if err = checkGrpcResponse(err, resp.GetStatus(), trailer); err != nil {
+		return dtos.ComponentsStatusOutput{}, err
+	}
+	return convertStatusesResponse(resp), nil
+}
+
+// checkGrpcResponse converts a failed gRPC call or a failure status response into a ServiceError.
+// The HTTP code is taken from the x-http-code trailer when present, otherwise it is derived from the gRPC code.
+func checkGrpcResponse(err error, statusResp *common.StatusResponse, trailer metadata.MD) error {
+	httpCode := 0
+	if vals := trailer.Get(api.HTTPCodeTrailer); len(vals) > 0 {
+		httpCode = parseHTTPCode(vals[0])
+	}
+	if err != nil {
+		if httpCode == 0 {
+			httpCode = runtime.HTTPStatusFromCode(grpcStatus(err).Code())
+		}
+		return newRemoteError(grpcStatus(err).Message(), httpCode, err)
+	}
+	if statusResp != nil && statusResp.GetStatus() != common.StatusCode_SUCCESS {
+		if httpCode == 0 {
+			httpCode = http.StatusInternalServerError
+		}
+		return newRemoteError(statusResp.GetMessage(), httpCode, nil)
+	}
+	if httpCode >= http.StatusBadRequest {
+		return newRemoteError(http.StatusText(httpCode), httpCode, nil)
+	}
+	return nil
+}
+
+// grpcStatus extracts the gRPC status from an error.
+func grpcStatus(err error) *status.Status {
+	st, _ := status.FromError(err)
+	return st
+}
@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/componentsv2"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"scanoss.com/components/pkg/api"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// REST gateway routes for the componentsv2 service.
+const (
+	restComponentStatusPath = "/v2/components/status/component"
+	restComponentsStatusURL = "/v2/components/status/components"
+)
+
+// RestClient talks to a Component Service through its REST (grpc-gateway) interface.
+type RestClient struct {
+	s          *zap.SugaredLogger
+	cfg        Config
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewRestClient creates a REST client for the supplied config.
+func NewRestClient(s *zap.SugaredLogger, cfg Config) (*RestClient, error) {
+	baseURL := strings.TrimRight(cfg.Address, "/")
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		if cfg.TLS {
+			baseURL = "https://" + baseURL
+		} else {
+			baseURL = "http://" + baseURL
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.TLS || strings.HasPrefix(baseURL, "https://") {
+		tlsCfg, err := cfg.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsCfg
+	}
+	return &RestClient{
+		s:          s,
+		cfg:        cfg,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Transport: transport, Timeout: cfg.timeout()},
+	}, nil
+}
+
+// Close releases any idle connections held by the client.
+func (c *RestClient) Close() error {
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// SearchComponents searches the remote service for components, using the ComponentsExtension service
+// (which accepts every search input and returns the pagination, facets and details in its response).
+func (c *RestClient) SearchComponents(request dtos.ComponentSearchInput) (dtos.ComponentsSearchOutput, error) {
+	var resp api.SearchComponentsResponse
+	if err := c.doExtension(api.ExtensionSearchComponents, request, &resp); err != nil {
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	if err := checkStatus(resp.GetStatus()); err != nil {
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	return resp.ComponentsSearchOutput, nil
+}
+
+// SuggestComponents retrieves the most popular components starting with a prefix from the remote service,
+// using the ComponentsExtension service.
+func (c *RestClient) SuggestComponents(request dtos.ComponentSuggestInput) (dtos.ComponentsSearchOutput, error) {
+	var resp api.SuggestComponentsResponse
+	if err := c.doExtension(api.ExtensionSuggestComponents, request, &resp); err != nil {
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	if err := checkStatus(resp.GetStatus()); err != nil {
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	return resp.ComponentsSearchOutput, nil
+}
+
+// LookupURL retrieves the purls (and versions) of a repository, registry or download URL from the remote service,
+// using the ComponentsExtension service.
+func (c *RestClient) LookupURL(request dtos.ComponentURLInput) (dtos.ComponentsSearchOutput, error) {
+	var resp api.LookupURLResponse
+	if err := c.doExtension(api.ExtensionLookupURL, request, &resp); err != nil {
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	if err := checkStatus(resp.GetStatus()); err != nil {
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	return resp.ComponentsSearchOutput, nil
+}
+
+// GetComponentVersions retrieves the versions of a component from the remote service, using the
+// ComponentsExtension service (which returns the license expression, status and requirement match of each version).
+func (c *RestClient) GetComponentVersions(request dtos.ComponentVersionsInput) (dtos.ComponentVersionsOutput, error) {
+	var resp api.ComponentVersionsResponse
+	if err := c.doExtension(api.ExtensionComponentVersions, request, &resp); err != nil {
+		return dtos.ComponentVersionsOutput{}, err
+	}
+	if err := checkStatus(resp.GetStatus()); err != nil {
+		return dtos.ComponentVersionsOutput{}, err
+	}
+	return resp.ComponentVersionsOutput, nil
+}
+
+// GetComponentStatus retrieves the status of a single component from the remote service.
+func (c *RestClient) GetComponentStatus(request dtos.ComponentStatusInput) (dtos.ComponentStatusOutput, error) {
+	params := url.Values{}
+	addParam(params, "purl", request.Purl)
+	addParam(params, "requirement", request.Requirement)
+	var resp pb.ComponentStatusResponse
+	if err := c.do(http.MethodGet, restComponentStatusPath, params, nil, &resp); err != nil {
+		return dtos.ComponentStatusOutput{}, err
+	}
+	return convertStatusResponse(&resp), nil
+}
+
+// ResolveVersion explains how the version requirement of a component resolves to a concrete version, using the
+// ComponentsExtension service.
+func (c *RestClient) ResolveVersion(request dtos.ComponentStatusInput) (dtos.ComponentResolutionOutput, error) {
+	var resp api.ResolveVersionResponse
+	if err := c.doExtension(api.ExtensionResolveVersion, request, &resp); err != nil {
+		return dtos.ComponentResolutionOutput{}, err
+	}
+	if err := checkStatus(resp.GetStatus()); err != nil {
+		return dtos.ComponentResolutionOutput{}, err
+	}
+	return resp.ComponentResolutionOutput, nil
+}
+
+// GetComponentsStatus retrieves the status of multiple components from the remote service.
+func (c *RestClient) GetComponentsStatus(request dtos.ComponentsStatusInput) (dtos.ComponentsStatusOutput, error) {
+	var resp pb.ComponentsStatusResponse
+	if err := c.do(http.MethodPost, restComponentsStatusURL, nil, convertStatusesInput(request), &resp); err != nil {
+		return dtos.ComponentsStatusOutput{}, err
+	}
+	if err := checkStatus(resp.GetStatus()); err != nil {
+		return dtos.ComponentsStatusOutput{}, err
+	}
+	return convertStatusesResponse(&resp), nil
+}
+
+// do sends the request to the given path and decodes the JSON response into the supplied message.
+// Any non-2xx HTTP status (set by the gateway from the x-http-code trailer) is returned as a ServiceError.
+func (c *RestClient) do(method, path string, params url.Values, body, result proto.Message) error {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		if reqBody, err = protojson.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %v", err)
+		}
+	}
+	data, err := c.send(method, path, params, reqBody)
+	if err != nil {
+		return err
+	}
+	if err = (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	return nil
+}
+
+// doExtension posts the JSON encoded request to a ComponentsExtension method and decodes its JSON response.
+func (c *RestClient) doExtension(name string, request, result any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %v", err)
+	}
+	data, err := c.send(http.MethodPost, api.ExtensionRESTPath+"/"+name, nil, body)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	return nil
+}
+
+// send sends the request (with the JSON body, if any) to the given path and returns the response body. Any non-2xx HTTP status (set by the gateway from the x-http-code trailer) is returned
+// as a ServiceError.
+func (c *RestClient) send(method, path string, params url.Values, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.timeout())
+	defer cancel()
+	endpoint := c.baseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.s.Debugf("Sending %v request to %v", method, endpoint)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %v: %v", c.baseURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, newRemoteError(errorMessage(data, resp.StatusCode), resp.StatusCode, nil)
+	}
+	return data, nil
+}
+
+// errorMessage extracts the most relevant error message from a failed REST response body.
+func errorMessage(data []byte, httpCode int) string {
+	var body struct {
+		Status struct {
+			Message string `json:"message"`
+		} `json:"status"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &body); err == nil {
+		if len(body.Status.Message) > 0 {
+			return body.Status.Message
+		}
+		if len(body.Message) > 0 {
+			return body.Message
+		}
+	}
+	return http.StatusText(httpCode)
+}
+
+// checkStatus converts a failure status in a successful HTTP response into a ServiceError.
+func checkStatus(status *common.StatusResponse) error {
+	if status != nil && status.GetStatus() != common.StatusCode_SUCCESS {
+		return newRemoteError(status.GetMessage(), http.StatusInternalServerError, nil)
+	}
+	return nil
+}
+
+// addParam adds a query parameter if it has a value.
+func addParam(params url.Values, key, value string) {
+	if len(value) > 0 {
+		params.Set(key, value)
+	}
+}
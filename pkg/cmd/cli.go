@@ -16,4 +16,711 @@
 
 package cmd
 
-// TODO Add CLI code processing here
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/components/pkg/client"
+	myconfig "scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	se "scanoss.com/components/pkg/errors"
+	"scanoss.com/components/pkg/usecase"
+)
+
+// CLI exit codes. Failures reported by the service (x-http-code) are mapped onto exitNotFound/exitClientError/exitServerError.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitNotFound    = 3
+	exitClientError = 4
+	exitServerError = 5
+	exitFindings    = 6 // The audit/policy reported findings at or above the -fail-on level
+)
+
+// cliOptions holds the options shared by every CLI sub-command.
+type cliOptions struct {
+	jsonConfig string
+	envConfig  string
+	debug      bool
+	format     string
+	server     string        // Remote service address (enables client mode)
+	protocol   string        // Remote service protocol (grpc or rest)
+	tls        bool          // Connect to the remote service using TLS
+	caCert     string        // CA certificate to trust for the remote service
+	serverName string        // Server name (CN) to verify the remote certificate against
+	timeout    time.Duration // Remote request timeout
+}
+
+// componentsAPI is the set of component operations the CLI needs. It is satisfied by both the
+// local use case (direct DB access) and the remote service clients.
+type componentsAPI interface {
+	SearchComponents(request dtos.ComponentSearchInput) (dtos.ComponentsSearchOutput, error)
+	SuggestComponents(request dtos.ComponentSuggestInput) (dtos.ComponentsSearchOutput, error)
+	LookupURL(request dtos.ComponentURLInput) (dtos.ComponentsSearchOutput, error)
+	GetComponentVersions(request dtos.ComponentVersionsInput) (dtos.ComponentVersionsOutput, error)
+	GetComponentStatus(request dtos.ComponentStatusInput) (dtos.ComponentStatusOutput, error)
+	GetComponentsStatus(request dtos.ComponentsStatusInput) (dtos.ComponentsStatusOutput, error)
+	ResolveVersion(request dtos.ComponentStatusInput) (dtos.ComponentResolutionOutput, error)
+}
+
+// cliCommand describes a single CLI sub-command.
+type cliCommand struct {
+	name        string
+	description string
+	run         func(args []string, out io.Writer) error
+}
+
+// errUsage flags a problem with the supplied command line (rather than with the request itself).
+var errUsage = errors.New("usage error")
+
+// cliCommands returns the list of supported CLI sub-commands.
+func cliCommands() []cliCommand {
+	return []cliCommand{
+		{name: "search", description: "Search for components by name, vendor or free text", run: runSearchCommand},
+		{name: "suggest", description: "Suggest (autocomplete) component names and purls starting with a prefix", run: runSuggestCommand},
+		{name: "lookup", description: "Look up the purls (and versions) of a repository, registry or download URL", run: runLookupCommand},
+		{name: "versions", description: "List the known versions of a component (purl)", run: runVersionsCommand},
+		{name: "status", description: "Get the status of one or more components (purls)", run: runStatusCommand},
+		{name: "resolve", description: "Explain how a version requirement of a component (purl) resolves to a version", run: runResolveCommand},
+		{name: "audit", description: "Report removed, deprecated and unknown components in an SBOM or lockfile", run: runAuditCommand},
+		{name: "policy", description: "Evaluate a status policy against the components in an SBOM or lockfile", run: runPolicyCommand},
+	}
+}
+
+// RunCli runs the Components CLI with the supplied arguments (excluding the program name).
+// It returns the exit code the process should terminate with.
+func RunCli(args []string) (int, error) {
+	if len(args) == 0 {
+		printCliUsage(os.Stderr)
+		return exitUsage, errors.New("no command supplied")
+	}
+	switch args[0] {
+	case "-h", "-help", "--help", "help":
+		printCliUsage(os.Stdout)
+		return exitOK, nil
+	case "-version", "--version", "version":
+		fmt.Printf("Version: %v\n", strings.TrimSpace(version))
+		return exitOK, nil
+	}
+	for _, c := range cliCommands() {
+		if c.name != args[0] {
+			continue
+		}
+		err := c.run(args[1:], os.Stdout)
+		switch {
+		case err == nil:
+			return exitOK, nil
+		case errors.Is(err, flag.ErrHelp):
+			return exitOK, nil
+		case errors.Is(err, errUsage):
+			return exitUsage, err
+		case errors.Is(err, errFindings):
+			return exitFindings, err
+		default:
+			return exitCodeForError(err), err
+		}
+	}
+	printCliUsage(os.Stderr)
+	return exitUsage, fmt.Errorf("unknown command: %v", args[0])
+}
+
+// exitCodeForError maps an error onto a process exit code, using the HTTP code of service errors when available.
+func exitCodeForError(err error) int {
+	serviceErr, ok := se.GetServiceError(err)
+	if !ok {
+		return exitError
+	}
+	code := serviceErr.GetHTTPCode()
+	switch {
+	case code == http.StatusNotFound:
+		return exitNotFound
+	case code >= http.StatusBadRequest && code < http.StatusInternalServerError:
+		return exitClientError
+	case code >= http.StatusInternalServerError:
+		return exitServerError
+	default:
+		return exitError
+	}
+}
+
+// printCliUsage writes the top level CLI help to the given writer.
+func printCliUsage(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "Usage: components <command> [options] [arguments]\n\nCommands:\n")
+	for _, c := range cliCommands() {
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", c.name, c.description)
+	}
+	_, _ = fmt.Fprintf(w, "\nRun 'components <command> -h' for the options of each command.\n")
+	_, _ = fmt.Fprintf(w, "Use -server to query a running Component Service instead of the database.\n")
+	_, _ = fmt.Fprintf(w, "\nExit codes: %d success, %d error, %d usage, %d not found, %d client (4xx) error, %d server (5xx) error, %d audit findings\n",
+		exitOK, exitError, exitUsage, exitNotFound, exitClientError, exitServerError, exitFindings)
+}
+
+// newCliFlagSet creates a flag set for the named sub-command, pre-loaded with the shared options.
+func newCliFlagSet(name string, opts *cliOptions) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&opts.jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&opts.envConfig, "env-config", "", "Application dot-ENV config")
+	fs.BoolVar(&opts.debug, "debug", false, "Enable debug")
+	fs.StringVar(&opts.format, "format", outputFormatTable, "Output format: table or json")
+	fs.StringVar(&opts.server, "server", "", "Remote Component Service address (gRPC host:port or REST URL)")
+	fs.StringVar(&opts.protocol, "protocol", client.ProtocolGRPC, "Remote Component Service protocol: grpc or rest")
+	fs.BoolVar(&opts.tls, "tls", false, "Use TLS to connect to the remote service (implied by COMP_TLS_CERT or -ca-cert)")
+	fs.StringVar(&opts.caCert, "ca-cert", "", "CA certificate (PEM) to trust for the remote service (defaults to COMP_TLS_CERT)")
+	fs.StringVar(&opts.serverName, "server-name", "", "Server name to verify the remote certificate against (defaults to COMP_TLS_CN)")
+	fs.DurationVar(&opts.timeout, "timeout", 0, "Remote request timeout (default 30s)")
+	return fs
+}
+
+// parseCliFlags parses the sub-command arguments and validates the shared options.
+// Any extra output formats supported by the sub-command can be supplied.
+func parseCliFlags(fs *flag.FlagSet, opts *cliOptions, args []string, extraFormats ...string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if !isValidOutputFormat(opts.format) && !slices.Contains(extraFormats, opts.format) {
+		return fmt.Errorf("%w: unsupported output format: %v", errUsage, opts.format)
+	}
+	return nil
+}
+
+// cliConfig is the part of the config the CLI needs. It is satisfied by both the server config (local mode)
+// and the client config (client mode).
+type cliConfig interface {
+	GetStatusMapper() *myconfig.StatusMapper
+	GetPolicy() *myconfig.Policy
+	GetWaivers() *myconfig.Waivers
+}
+
+// newCliAPI loads the config and returns either a remote service client (if -server was supplied) or a use case
+// backed by the configured database, together with a function to release its resources.
+func newCliAPI(opts *cliOptions) (componentsAPI, func(), error) {
+	api, _, cleanup, err := newCliAPIWithConfig(opts)
+	return api, cleanup, err
+}
+
+// newCliAPIWithConfig is the same as newCliAPI, but also returns the loaded config.
+// In client mode (-server), only the client config is loaded, so no server settings are required.
+func newCliAPIWithConfig(opts *cliOptions) (componentsAPI, cliConfig, func(), error) {
+	if len(opts.server) > 0 {
+		cfg, err := loadClientConfig(opts.jsonConfig, opts.envConfig, opts.debug)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to load config: %v", err)
+		}
+		api, cleanup, err := newCliClient(opts, cfg)
+		return api, cfg, cleanup, err
+	}
+	cfg, err := loadConfig(opts.jsonConfig, opts.envConfig, opts.debug)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load config: %v", err)
+	}
+	if !cfg.App.Debug {
+		zlog.SetLevel("warn") // Keep the console clean for command output
+	}
+	db, err := openDB(cfg)
+	if err != nil {
+		zlog.SyncZap()
+		return nil, nil, nil, fmt.Errorf("failed to open database: %v", err)
+	}
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	compUc := usecase.NewComponents(ctx, s, db, gd.NewDBSelectContext(s, db, nil, cfg.Database.Trace), cfg.GetStatusMapper())
+	compUc.SetSearchOrder(cfg.GetSearchOrder())
+	cleanup := func() {
+		gd.CloseDBConnection(db)
+		zlog.SyncZap()
+	}
+	return compUc, cfg, cleanup, nil
+}
+
+// loadClientConfig builds the client config from the supplied JSON/dot-ENV files (plus the environment),
+// sets up the application logger and initialises the status mapper, policy and waivers.
+func loadClientConfig(jsonConfig, envConfig string, debug bool) (*myconfig.ClientConfig, error) {
+	feeders, err := configFeeders(jsonConfig, envConfig, debug)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := myconfig.NewClientConfig(feeders)
+	if err != nil {
+		return nil, err
+	}
+	if err = zlog.SetupAppLogger(cfg.App.Mode, cfg.Logging.ConfigFile, cfg.App.Debug); err != nil {
+		return nil, err
+	}
+	if !cfg.App.Debug {
+		zlog.SetLevel("warn") // Keep the console clean for command output
+	}
+	cfg.InitStatusMapperConfig(zlog.S)
+	if err = cfg.InitPolicyConfig(zlog.S); err != nil {
+		return nil, err
+	}
+	if err = cfg.InitWaiversConfig(zlog.S); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// newCliClient creates a remote service client, honouring the same COMP_TLS_* settings as the server.
+func newCliClient(opts *cliOptions, cfg *myconfig.ClientConfig) (componentsAPI, func(), error) {
+	clientCfg := client.Config{
+		Address:    opts.server,
+		Protocol:   opts.protocol,
+		TLS:        opts.tls,
+		CAFile:     opts.caCert,
+		ServerName: opts.serverName,
+		Timeout:    opts.timeout,
+	}
+	if len(clientCfg.CAFile) == 0 {
+		clientCfg.CAFile = cfg.TLS.CertFile
+	}
+	if len(clientCfg.ServerName) == 0 {
+		clientCfg.ServerName = cfg.TLS.CN
+	}
+	if len(clientCfg.CAFile) > 0 || strings.HasPrefix(clientCfg.Address, "https://") {
+		clientCfg.TLS = true
+	}
+	c, err := client.NewClient(zlog.S, clientCfg)
+	if err != nil {
+		zlog.SyncZap()
+		if errors.Is(err, client.ErrUnsupportedProtocol) {
+			return nil, nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		return nil, nil, err
+	}
+	cleanup := func() {
+		if closeErr := c.Close(); closeErr != nil {
+			zlog.S.Warnf("Problem closing client connection: %v", closeErr)
+		}
+		zlog.SyncZap()
+	}
+	return c, cleanup, nil
+}
+
+// runSearchCommand searches the knowledge base for components.
+func runSearchCommand(args []string, out io.Writer) error {
+	var opts cliOptions
+	var request dtos.ComponentSearchInput
+	fs := newCliFlagSet("search", &opts)
+	fs.StringVar(&request.Search, "search", "", "Free text to search for (defaults to the command arguments)")
+	fs.StringVar(&request.Component, "component", "", "Component name to search for")
+	fs.StringVar(&request.Vendor, "vendor", "", "Vendor name to search for")
+	fs.StringVar(&request.Package, "package", "", "Package (purl) type to search (default github)")
+	fs.BoolVar(&request.AllTypes, "all-types", false, "Search across every package type (when no -package is supplied)")
+	fs.StringVar(&request.Namespace, "namespace", "", "List the components in a Maven groupId, npm @scope, Go module path prefix or owner")
+	fs.IntVar(&request.PerTypeLimit, "per-type", 0, "Maximum number of results per package type when searching all types")
+	fs.IntVar(&request.Limit, "limit", 0, "Maximum number of results to return")
+	fs.IntVar(&request.Offset, "offset", 0, "Number of results to skip")
+	fs.StringVar(&request.Cursor, "cursor", "", "Cursor (from a previous search) to fetch the next page of results")
+	fs.BoolVar(&request.Fuzzy, "fuzzy", false, "Typo tolerant search, returning the components with the most similar names")
+	fs.BoolVar(&request.Facets, "facets", false, "Also return the match counts by purl type, license, status and vendor")
+	fs.BoolVar(&request.Enrich, "enrich", false, "Include the latest version, license, status and popularity of each result")
+	fs.StringVar(&request.Sort, "sort", "", "Sort by relevance (default), name, stars, forks, latest_release, first_release or versions")
+	fs.StringVar(&request.Order, "order", "", "Sort direction: asc or desc (defaults to the natural order of the sort key)")
+	var licenses, statuses, excludeStatuses string
+	fs.StringVar(&licenses, "license", "", "Only include components with one of these (comma separated) licenses")
+	fs.StringVar(&statuses, "status", "", "Only include components with one of these (comma separated) statuses")
+	fs.StringVar(&excludeStatuses, "exclude-status", "", "Exclude components with any of these (comma separated) statuses")
+	fs.BoolVar(&request.Verified, "verified", false, "Only include verified components")
+	fs.Int64Var(&request.MinStars, "min-stars", 0, "Minimum number of git stars")
+	fs.StringVar(&request.LatestVersionFrom, "latest-from", "", "Latest version released on or after this date (YYYY-MM-DD)")
+	fs.StringVar(&request.LatestVersionTo, "latest-to", "", "Latest version released on or before this date (YYYY-MM-DD)")
+	fs.StringVar(&request.PushedFrom, "pushed-from", "", "Last git push on or after this date (YYYY-MM-DD)")
+	fs.StringVar(&request.PushedTo, "pushed-to", "", "Last git push on or before this date (YYYY-MM-DD)")
+	if err := parseCliFlags(fs, &opts, args); err != nil {
+		return err
+	}
+	request.Licenses = splitList(licenses)
+	request.Statuses = splitList(statuses)
+	request.ExcludeStatuses = splitList(excludeStatuses)
+	if len(request.Search) == 0 && fs.NArg() > 0 {
+		request.Search = strings.Join(fs.Args(), " ")
+	}
+	if len(request.Search) == 0 && len(request.Component) == 0 && len(request.Vendor) == 0 && len(request.Namespace) == 0 {
+		return fmt.Errorf("%w: please specify a search term, component, vendor or namespace", errUsage)
+	}
+	api, cleanup, err := newCliAPI(&opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	results, err := api.SearchComponents(request)
+	if err != nil {
+		return err
+	}
+	return writeSearchOutput(out, opts.format, results)
+}
+
+// runSuggestCommand lists the most popular components starting with the supplied prefix.
+func runSuggestCommand(args []string, out io.Writer) error {
+	var opts cliOptions
+	var request dtos.ComponentSuggestInput
+	fs := newCliFlagSet("suggest", &opts)
+	fs.StringVar(&request.Package, "package", "", "Package (purl) type to suggest (default github)")
+	fs.BoolVar(&request.AllTypes, "all-types", false, "Suggest across every package type (when no -package is supplied)")
+	fs.IntVar(&request.Limit, "limit", 0, "Maximum number of suggestions to return")
+	fs.BoolVar(&request.Enrich, "enrich", false, "Include the latest version, license, status and popularity of each suggestion")
+	if err := parseCliFlags(fs, &opts, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: please specify a single prefix", errUsage)
+	}
+	request.Prefix = fs.Arg(0)
+	api, cleanup, err := newCliAPI(&opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	results, err := api.SuggestComponents(request)
+	if err != nil {
+		return err
+	}
+	return writeSearchOutput(out, opts.format, results)
+}
+
+// runLookupCommand lists the purls (and versions) matching the supplied URL.
+func runLookupCommand(args []string, out io.Writer) error {
+	var opts cliOptions
+	fs := newCliFlagSet("lookup", &opts)
+	if err := parseCliFlags(fs, &opts, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: please specify a single URL", errUsage)
+	}
+	api, cleanup, err := newCliAPI(&opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	results, err := api.LookupURL(dtos.ComponentURLInput{URL: fs.Arg(0)})
+	if err != nil {
+		return err
+	}
+	return writeLookupOutput(out, opts.format, results)
+}
+
+// runVersionsCommand lists the versions of the requested component.
+func runVersionsCommand(args []string, out io.Writer) error {
+	var opts cliOptions
+	var request dtos.ComponentVersionsInput
+	fs := newCliFlagSet("versions", &opts)
+	fs.IntVar(&request.Limit, "limit", 0, "Maximum number of versions to return")
+	fs.StringVar(&request.Order, "order", "", "Order by date (default, newest release first) or version (highest version first)")
+	fs.StringVar(&request.Requirement, "requirement", "", "Only list the versions satisfying this requirement (i.e. ^1.2, ~=3.4 or [1.0,2.0))")
+	fs.BoolVar(&request.ExcludePreReleases, "exclude-pre-releases", false, "Exclude pre-releases (alpha, beta, rc, experimental or commit hash versions)")
+	fs.BoolVar(&request.ExcludeYanked, "exclude-yanked", false, "Exclude versions whose status maps to removed or deleted (i.e. yanked)")
+	if err := parseCliFlags(fs, &opts, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: please specify a single purl", errUsage)
+	}
+	request.Purl = fs.Arg(0)
+	api, cleanup, err := newCliAPI(&opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	versions, err := api.GetComponentVersions(request)
+	if err != nil {
+		return err
+	}
+	return writeVersionsOutput(out, opts.format, versions)
+}
+
+// runResolveCommand explains how the version requirement (or version) of the requested component is resolved.
+func runResolveCommand(args []string, out io.Writer) error {
+	var opts cliOptions
+	var request dtos.ComponentStatusInput
+	fs := newCliFlagSet("resolve", &opts)
+	fs.StringVar(&request.Requirement, "requirement", "", "Version requirement to resolve (defaults to the purl version, or the latest version)")
+	if err := parseCliFlags(fs, &opts, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: please specify a single purl", errUsage)
+	}
+	request.Purl = fs.Arg(0)
+	api, cleanup, err := newCliAPI(&opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	resolution, err := api.ResolveVersion(request)
+	if err != nil {
+		return err
+	}
+	return writeResolutionOutput(out, opts.format, resolution)
+}
+
+// runStatusCommand reports the status of the requested components.
+func runStatusCommand(args []string, out io.Writer) error {
+	var opts cliOptions
+	var input statusInputOptions
+	fs := newCliFlagSet("status", &opts)
+	fs.StringVar(&input.requirement, "requirement", "", "Version requirement to check (single purl only)")
+	fs.StringVar(&input.inputFile, "input", "", "JSON file containing a list of components to check")
+	fs.StringVar(&input.lockfile, "lockfile", "", "Lockfile to check the dependencies of ("+lockfileNames+")")
+	fs.StringVar(&input.lockfileType, "lockfile-type", "", "Lockfile type, if it cannot be detected from the file name ("+
+		strings.Join(usecase.LockfileTypes, ", ")+")")
+	if err := parseCliFlags(fs, &opts, args); err != nil {
+		return err
+	}
+	input.purls = fs.Args()
+	if len(input.purls) == 0 && len(input.inputFile) == 0 && len(input.lockfile) == 0 {
+		return fmt.Errorf("%w: please specify at least one purl, an input file or a lockfile", errUsage)
+	}
+	if len(input.requirement) > 0 && len(input.purls) != 1 {
+		return fmt.Errorf("%w: -requirement can only be used with a single purl", errUsage)
+	}
+	api, cleanup, err := newCliAPI(&opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	request, err := buildStatusRequest(input)
+	if err != nil {
+		return err
+	}
+	if len(request.Components) == 1 && len(input.inputFile) == 0 && len(input.lockfile) == 0 {
+		status, err := api.GetComponentStatus(request.Components[0])
+		if err != nil {
+			return err
+		}
+		return writeStatusOutput(out, opts.format, status)
+	}
+	statuses, err := api.GetComponentsStatus(request)
+	if err != nil {
+		return err
+	}
+	return writeStatusesOutput(out, opts.format, statuses)
+}
+
+// lockfileNames lists the lockfile names that are recognised automatically.
+const lockfileNames = "package-lock.json, yarn.lock, requirements.txt, poetry.lock, Gemfile.lock, go.sum or pom.xml"
+
+// statusInputOptions holds the different sources of components for a status request.
+type statusInputOptions struct {
+	purls        []string
+	requirement  string
+	inputFile    string
+	lockfile     string
+	lockfileType string
+}
+
+// buildStatusRequest assembles the status request from the command line purls, an input file and/or a lockfile.
+func buildStatusRequest(input statusInputOptions) (dtos.ComponentsStatusInput, error) {
+	var request dtos.ComponentsStatusInput
+	if len(input.inputFile) > 0 {
+		data, err := os.ReadFile(input.inputFile)
+		if err != nil {
+			return request, fmt.Errorf("failed to read input file: %v", err)
+		}
+		request, err = dtos.ParseComponentsStatusInput(zlog.S, data)
+		if err != nil {
+			return request, err
+		}
+	}
+	if len(input.lockfile) > 0 {
+		lockRequest, err := readLockfile(input.lockfile, input.lockfileType)
+		if err != nil {
+			return request, err
+		}
+		request.Components = append(request.Components, lockRequest.Components...)
+	}
+	for _, purl := range input.purls {
+		request.Components = append(request.Components, dtos.ComponentStatusInput{Purl: purl, Requirement: input.requirement})
+	}
+	if len(request.Components) == 0 {
+		return request, errors.New("no components found in the input file")
+	}
+	return request, nil
+}
+
+// readLockfile reads and parses the given lockfile, detecting its type from the file name if not specified.
+func readLockfile(filename, lockfileType string) (dtos.ComponentsStatusInput, error) {
+	if len(lockfileType) == 0 {
+		lockfileType = usecase.DetectLockfileType(filename)
+		if len(lockfileType) == 0 {
+			return dtos.ComponentsStatusInput{}, fmt.Errorf("%w: unable to detect the lockfile type of %v, please use -lockfile-type", errUsage, filename)
+		}
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return dtos.ComponentsStatusInput{}, fmt.Errorf("failed to read lockfile: %v", err)
+	}
+	return usecase.ParseLockfile(zlog.S, lockfileType, data)
+}
+
+// runAuditCommand reads an SBOM or lockfile and reports any removed, deprecated or unknown components it contains.
+// Findings can also be written as SARIF, JUnit XML or GitHub annotations, optionally failing on a given severity.
+func runAuditCommand(args []string, out io.Writer) error {
+	var opts cliOptions
+	var lockfileType, severity, failOn, waiversFile string
+	fs := newCliFlagSet("audit", &opts)
+	fs.StringVar(&waiversFile, "waivers", "", "Waivers file (YAML or JSON) exempting removed/deprecated components. Defaults to COMP_WAIVERS_FILE")
+	fs.StringVar(&lockfileType, "lockfile-type", "", "Treat the input as a lockfile of this type ("+strings.Join(usecase.LockfileTypes, ", ")+")")
+	fs.StringVar(&severity, "severity", defaultSeverities, "Severity (error, warning, note or none) of each classification")
+	fs.StringVar(&failOn, "fail-on", "", "Exit with an error if there are findings at or above this severity (error, warning or note)")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(fs.Output(), "Usage: components audit [options] <sbom-or-lockfile>\n\n"+
+			"Supported SBOM formats: CycloneDX JSON, SPDX JSON and SPDX tag-value.\n"+
+			"Supported lockfiles: "+lockfileNames+".\n"+
+			"Output formats: table, json, sarif, junit and github.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	if err := parseCliFlags(fs, &opts, args, outputFormatSARIF, outputFormatJUnit, outputFormatGitHub); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: please specify a single SBOM or lockfile", errUsage)
+	}
+	severities, err := parseSeverities(severity)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	failOn = strings.ToLower(failOn)
+	if len(failOn) > 0 && !isValidSeverity(failOn) {
+		return fmt.Errorf("%w: unsupported -fail-on severity: %v", errUsage, failOn)
+	}
+	filename := fs.Arg(0)
+	lockfileType, data, err := readInputFile(filename, lockfileType)
+	if err != nil {
+		return err
+	}
+	api, cfg, cleanup, err := newCliAPIWithConfig(&opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	waivers := cfg.GetWaivers()
+	if len(waiversFile) > 0 {
+		if waivers, err = myconfig.LoadWaiversFile(waiversFile); err != nil {
+			return err
+		}
+	}
+	statuses, err := getInputStatuses(api, lockfileType, data)
+	if err != nil {
+		return err
+	}
+	output := usecase.AuditComponentsStatus(cfg.GetStatusMapper(), statuses)
+	usecase.ApplyWaivers(waivers, &output, time.Now())
+	failures := applySeverities(&output, severities, failOn)
+	if err = writeAuditReport(out, opts.format, filename, output, severities); err != nil {
+		return err
+	}
+	if failures > 0 {
+		return fmt.Errorf("%w: %d finding(s) at or above severity %v", errFindings, failures, failOn)
+	}
+	return nil
+}
+
+// runPolicyCommand evaluates a status policy against the components listed in an SBOM or lockfile.
+func runPolicyCommand(args []string, out io.Writer) error {
+	var opts cliOptions
+	var lockfileType, policyFile, failOn string
+	fs := newCliFlagSet("policy", &opts)
+	fs.StringVar(&policyFile, "policy", "", "Policy file (YAML or JSON). Defaults to COMP_POLICY_FILE")
+	fs.StringVar(&lockfileType, "lockfile-type", "", "Treat the input as a lockfile of this type ("+strings.Join(usecase.LockfileTypes, ", ")+")")
+	fs.StringVar(&failOn, "fail-on", myconfig.PolicyDeny, "Exit with an error if any component verdict is at or above this level (warn or deny)")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(fs.Output(), "Usage: components policy [options] <sbom-or-lockfile>\n\n"+
+			"Supported SBOM formats: CycloneDX JSON, SPDX JSON and SPDX tag-value.\n"+
+			"Supported lockfiles: "+lockfileNames+".\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	if err := parseCliFlags(fs, &opts, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: please specify a single SBOM or lockfile", errUsage)
+	}
+	failOn = strings.ToLower(failOn)
+	if failOn != myconfig.PolicyWarn && failOn != myconfig.PolicyDeny {
+		return fmt.Errorf("%w: unsupported -fail-on level: %v", errUsage, failOn)
+	}
+	lockfileType, data, err := readInputFile(fs.Arg(0), lockfileType)
+	if err != nil {
+		return err
+	}
+	api, cfg, cleanup, err := newCliAPIWithConfig(&opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	policy := cfg.GetPolicy()
+	if len(policyFile) > 0 {
+		if policy, err = myconfig.LoadPolicyFile(policyFile); err != nil {
+			return err
+		}
+	}
+	if policy == nil {
+		return fmt.Errorf("%w: please specify a policy file (-policy or COMP_POLICY_FILE)", errUsage)
+	}
+	statuses, err := getInputStatuses(api, lockfileType, data)
+	if err != nil {
+		return err
+	}
+	output := usecase.EvaluatePolicy(policy, cfg.GetStatusMapper(), statuses, time.Now())
+	if err = writePolicyOutput(out, opts.format, output); err != nil {
+		return err
+	}
+	if myconfig.PolicyActionRank(output.Verdict) >= myconfig.PolicyActionRank(failOn) {
+		return fmt.Errorf("%w: policy verdict is %v", errFindings, output.Verdict)
+	}
+	return nil
+}
+
+// readInputFile reads an SBOM or lockfile, detecting the lockfile type from the filename if not supplied.
+// An empty lockfile type means the input should be treated as an SBOM.
+func readInputFile(filename, lockfileType string) (string, []byte, error) {
+	if len(lockfileType) == 0 {
+		lockfileType = usecase.DetectLockfileType(filename)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read input file: %v", err)
+	}
+	return lockfileType, data, nil
+}
+
+// getInputStatuses parses the components from an SBOM or lockfile and retrieves their statuses.
+func getInputStatuses(api componentsAPI, lockfileType string, data []byte) (dtos.ComponentsStatusOutput, error) {
+	var request dtos.ComponentsStatusInput
+	var err error
+	if len(lockfileType) > 0 {
+		request, err = usecase.ParseLockfile(zlog.S, lockfileType, data)
+	} else {
+		request, err = usecase.ParseSBOM(zlog.S, data)
+	}
+	if err != nil {
+		return dtos.ComponentsStatusOutput{}, err
+	}
+	return api.GetComponentsStatus(request)
+}
+
+// splitList splits a comma separated list, dropping empty values.
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			values = append(values, v)
+		}
+	}
+	return values
+}
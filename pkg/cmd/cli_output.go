@@ -0,0 +1,295 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"scanoss.com/components/pkg/dtos"
+)
+
+// Supported CLI output formats.
+const (
+	outputFormatTable = "table"
+	outputFormatJSON  = "json"
+)
+
+// isValidOutputFormat checks if the requested output format is supported.
+func isValidOutputFormat(format string) bool {
+	switch format {
+	case outputFormatTable, outputFormatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// writeJSON writes the supplied DTO to the writer as indented JSON.
+func writeJSON(out io.Writer, data any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("failed to produce JSON output: %v", err)
+	}
+	return nil
+}
+
+// newTableWriter returns a tab writer configured for the CLI table output.
+func newTableWriter(out io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+}
+
+// writeSearchOutput writes the component search results in the requested format.
+func writeSearchOutput(out io.Writer, format string, output dtos.ComponentsSearchOutput) error {
+	if format == outputFormatJSON {
+		return writeJSON(out, output)
+	}
+	tw := newTableWriter(out)
+	if slices.ContainsFunc(output.Components, func(c dtos.ComponentSearchOutput) bool { return c.Details != nil }) {
+		_, _ = fmt.Fprintln(tw, "NAME\tTYPE\tPURL\tLATEST\tRELEASED\tLICENSE\tSTATUS\tSTARS\tURL")
+		for _, c := range output.Components {
+			d := c.Details
+			if d == nil {
+				d = &dtos.ComponentSearchDetails{}
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n", c.Name, c.PurlType, c.Purl,
+				d.LatestVersion, d.LatestVersionDate, firstNonEmpty(d.SpdxID, d.License), d.Status, d.Stars, c.URL)
+		}
+	} else {
+		_, _ = fmt.Fprintln(tw, "NAME\tTYPE\tPURL\tSCORE\tURL")
+		for _, c := range output.Components {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f\t%s\n", c.Name, c.PurlType, c.Purl, c.Score, c.URL)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if output.Total > 0 {
+		_, _ = fmt.Fprintf(out, "\nShowing %d of %d components\n", len(output.Components), output.Total)
+	}
+	if output.HasMore {
+		_, _ = fmt.Fprintf(out, "Next page: -cursor %s\n", output.NextCursor)
+	}
+	if len(output.DidYouMean) > 0 {
+		_, _ = fmt.Fprintf(out, "Did you mean: %s\n", strings.Join(output.DidYouMean, ", "))
+	}
+	if output.Facets != nil {
+		_, _ = fmt.Fprintln(out, "\nFacets:")
+		writeFacet(out, "purl_type", output.Facets.PurlType)
+		writeFacet(out, "license", output.Facets.License)
+		writeFacet(out, "status", output.Facets.Status)
+		writeFacet(out, "vendor", output.Facets.Vendor)
+	}
+	return nil
+}
+
+// writeLookupOutput writes the purls (and versions) matching a URL in the requested format.
+func writeLookupOutput(out io.Writer, format string, output dtos.ComponentsSearchOutput) error {
+	if format == outputFormatJSON {
+		return writeJSON(out, output)
+	}
+	tw := newTableWriter(out)
+	_, _ = fmt.Fprintln(tw, "NAME\tTYPE\tPURL\tVERSION\tURL")
+	for _, c := range output.Components {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.Name, c.PurlType, c.Purl, c.Version, c.URL)
+	}
+	return tw.Flush()
+}
+
+// writeFacet writes the counts of a single facet on one line (i.e. "purl_type: npm (42), github (7)").
+func writeFacet(out io.Writer, name string, counts []dtos.FacetCount) {
+	values := make([]string, 0, len(counts))
+	for _, fc := range counts {
+		values = append(values, fmt.Sprintf("%s (%d)", fc.Value, fc.Count))
+	}
+	_, _ = fmt.Fprintf(out, "  %s: %s\n", name, strings.Join(values, ", "))
+}
+
+// writeVersionsOutput writes the component versions in the requested format.
+func writeVersionsOutput(out io.Writer, format string, output dtos.ComponentVersionsOutput) error {
+	if format == outputFormatJSON {
+		return writeJSON(out, output)
+	}
+	comp := output.Component
+	_, _ = fmt.Fprintf(out, "Component: %s\nPurl:      %s\nURL:       %s\n", comp.Name, comp.Purl, comp.URL)
+	for _, v := range comp.Versions {
+		if v.HighestMatch {
+			_, _ = fmt.Fprintf(out, "Highest:   %s\n", v.Version)
+		}
+	}
+	_, _ = fmt.Fprintln(out)
+	tw := newTableWriter(out)
+	_, _ = fmt.Fprintln(tw, "VERSION\tDATE\tSTATUS\tLICENSES")
+	for _, v := range comp.Versions {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", v.Version, v.Date, v.Status, licenseNames(v.Licenses))
+	}
+	return tw.Flush()
+}
+
+// writeResolutionOutput writes the resolution of a version requirement in the requested format.
+func writeResolutionOutput(out io.Writer, format string, output dtos.ComponentResolutionOutput) error {
+	if format == outputFormatJSON {
+		return writeJSON(out, output)
+	}
+	resolved := output.Version
+	if len(resolved) == 0 {
+		resolved = "(none)"
+	}
+	_, _ = fmt.Fprintf(out, "Purl:        %s\nRequirement: %s\nResolved:    %s\nRule:        %s\n\n",
+		output.Purl, output.Requirement, resolved, output.Rule)
+	tw := newTableWriter(out)
+	_, _ = fmt.Fprintln(tw, "VERSION\tDATE\tSTATUS\tRESULT")
+	for _, c := range output.Candidates {
+		result := "rejected: " + c.Rejected
+		if c.Selected {
+			result = "selected"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Version, c.Date, c.Status, result)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if output.OmittedCandidates > 0 {
+		_, _ = fmt.Fprintf(out, "(%d other versions not listed)\n", output.OmittedCandidates)
+	}
+	return nil
+}
+
+// writeStatusOutput writes a single component status in the requested format.
+func writeStatusOutput(out io.Writer, format string, output dtos.ComponentStatusOutput) error {
+	if format == outputFormatJSON {
+		return writeJSON(out, output)
+	}
+	return writeStatusTable(out, []dtos.ComponentStatusOutput{output})
+}
+
+// writeStatusesOutput writes a list of component statuses in the requested format.
+func writeStatusesOutput(out io.Writer, format string, output dtos.ComponentsStatusOutput) error {
+	if format == outputFormatJSON {
+		return writeJSON(out, output)
+	}
+	return writeStatusTable(out, output.Components)
+}
+
+// writeStatusTable writes the component statuses as a table.
+func writeStatusTable(out io.Writer, statuses []dtos.ComponentStatusOutput) error {
+	tw := newTableWriter(out)
+	_, _ = fmt.Fprintln(tw, "PURL\tNAME\tVERSION\tVERSION STATUS\tCOMPONENT STATUS\tINFO")
+	for _, cs := range statuses {
+		var version, versionStatus, componentStatus string
+		var info []string
+		if vs := cs.VersionStatus; vs != nil {
+			version = vs.Version
+			versionStatus = describeStatus(vs.Status, vs.RepositoryStatus)
+			if vs.ErrorMessage != nil {
+				info = append(info, *vs.ErrorMessage)
+			}
+		}
+		if comp := cs.ComponentStatus; comp != nil {
+			componentStatus = describeStatus(comp.Status, comp.RepositoryStatus)
+			if comp.ErrorMessage != nil {
+				info = append(info, *comp.ErrorMessage)
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", cs.Purl, cs.Name, version, versionStatus, componentStatus,
+			strings.Join(info, "; "))
+	}
+	return tw.Flush()
+}
+
+// describeStatus combines the mapped status with the raw repository status (if it differs).
+func describeStatus(status, repositoryStatus string) string {
+	if len(repositoryStatus) > 0 && !strings.EqualFold(status, repositoryStatus) {
+		return fmt.Sprintf("%s (%s)", status, repositoryStatus)
+	}
+	return status
+}
+
+// licenseNames returns a comma separated list of license identifiers (preferring SPDX IDs).
+func licenseNames(licenses []dtos.ComponentLicense) string {
+	names := make([]string, 0, len(licenses))
+	for _, l := range licenses {
+		if len(l.SpdxID) > 0 {
+			names = append(names, l.SpdxID)
+		} else {
+			names = append(names, l.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// writeAuditOutput writes the component audit report in the requested format.
+func writeAuditOutput(out io.Writer, format string, output dtos.ComponentsAuditOutput) error {
+	if format == outputFormatJSON {
+		return writeJSON(out, output)
+	}
+	sum := output.Summary
+	_, _ = fmt.Fprintf(out, "Audited %d components: %d removed, %d deprecated, %d unknown",
+		sum.Total, sum.Removed, sum.Deprecated, sum.Unknown)
+	if sum.Waived > 0 || sum.ExpiredWaivers > 0 {
+		_, _ = fmt.Fprintf(out, " (%d waived, %d expired waivers)", sum.Waived, sum.ExpiredWaivers)
+	}
+	_, _ = fmt.Fprintln(out)
+	if len(output.Findings) == 0 {
+		return nil
+	}
+	_, _ = fmt.Fprintln(out)
+	tw := newTableWriter(out)
+	_, _ = fmt.Fprintln(tw, "PURL\tVERSION\tCLASSIFICATION\tSEVERITY\tSCOPE\tSTATUS\tINFO")
+	for _, f := range output.Findings {
+		version := f.Version
+		if len(version) == 0 {
+			version = f.Requirement
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", f.Purl, version, f.Classification, f.Severity, f.Scope,
+			describeStatus(f.Status, f.RepositoryStatus), f.Message)
+	}
+	return tw.Flush()
+}
+
+// writePolicyOutput writes the policy verdicts in the requested format.
+func writePolicyOutput(out io.Writer, format string, output dtos.ComponentsPolicyOutput) error {
+	if format == outputFormatJSON {
+		return writeJSON(out, output)
+	}
+	sum := output.Summary
+	name := output.Policy
+	if len(name) == 0 {
+		name = "policy"
+	}
+	_, _ = fmt.Fprintf(out, "Evaluated %d components against %v: %v (%d allow, %d warn, %d deny)\n",
+		len(output.Components), name, output.Verdict, sum.Allow, sum.Warn, sum.Deny)
+	if len(output.Components) == 0 {
+		return nil
+	}
+	_, _ = fmt.Fprintln(out)
+	tw := newTableWriter(out)
+	_, _ = fmt.Fprintln(tw, "PURL\tVERSION\tVERDICT\tREASONS")
+	for _, c := range output.Components {
+		version := c.Version
+		if len(version) == 0 {
+			version = c.Requirement
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Purl, version, c.Verdict, strings.Join(c.Reasons, "; "))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,401 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"scanoss.com/components/pkg/dtos"
+)
+
+// CI report output formats (audit command only).
+const (
+	outputFormatSARIF  = "sarif"
+	outputFormatJUnit  = "junit"
+	outputFormatGitHub = "github"
+)
+
+// Finding severities, from least to most severe. Findings with severity none are not reported.
+const (
+	severityNone    = "none"
+	severityNote    = "note"
+	severityWarning = "warning"
+	severityError   = "error"
+)
+
+// defaultSeverities is the default severity of each audit classification.
+const defaultSeverities = "removed=error,deprecated=warning,unknown=note"
+
+var severityRank = map[string]int{
+	severityNone:    0,
+	severityNote:    1,
+	severityWarning: 2,
+	severityError:   3,
+}
+
+// auditRules describes the audit classifications as report rules.
+var auditRules = []struct {
+	classification string
+	id             string
+	name           string
+	description    string
+}{
+	{dtos.AuditRemoved, "component-removed", "RemovedComponent", "The component or version has been removed, unpublished or yanked from its registry"},
+	{dtos.AuditDeprecated, "component-deprecated", "DeprecatedComponent", "The component or version has been deprecated or archived"},
+	{dtos.AuditUnknown, "component-unknown", "UnknownComponent", "The component or version is not known to the SCANOSS knowledge base"},
+}
+
+// errFindings is returned when the audit reports findings at or above the -fail-on severity.
+var errFindings = errors.New("audit findings")
+
+// isValidSeverity checks if the given severity is supported.
+func isValidSeverity(severity string) bool {
+	_, ok := severityRank[severity]
+	return ok
+}
+
+// parseSeverities parses a list of classification=severity pairs (i.e. removed=error,deprecated=warning).
+// Classifications not mentioned keep their default severity.
+func parseSeverities(value string) (map[string]string, error) {
+	severities := make(map[string]string)
+	for _, list := range []string{defaultSeverities, value} {
+		for _, pair := range strings.Split(list, ",") {
+			pair = strings.TrimSpace(pair)
+			if len(pair) == 0 {
+				continue
+			}
+			classification, severity, found := strings.Cut(pair, "=")
+			classification = strings.ToLower(strings.TrimSpace(classification))
+			severity = strings.ToLower(strings.TrimSpace(severity))
+			if !found || len(auditRuleID(classification)) == 0 || !isValidSeverity(severity) {
+				return nil, fmt.Errorf("invalid severity setting %q (expected <removed|deprecated|unknown>=<error|warning|note|none>)", pair)
+			}
+			severities[classification] = severity
+		}
+	}
+	return severities, nil
+}
+
+// applySeverities sets the severity of each finding, dropping those with a severity of none.
+// It returns the number of findings at or above the failOn severity (if set).
+func applySeverities(output *dtos.ComponentsAuditOutput, severities map[string]string, failOn string) int {
+	failures := 0
+	findings := make([]dtos.ComponentAuditFinding, 0, len(output.Findings))
+	for _, f := range output.Findings {
+		f.Severity = severities[f.Classification]
+		if len(f.Severity) == 0 || f.Severity == severityNone {
+			continue
+		}
+		if len(failOn) > 0 && failOn != severityNone && severityRank[f.Severity] >= severityRank[failOn] {
+			failures++
+		}
+		findings = append(findings, f)
+	}
+	output.Findings = findings
+	return failures
+}
+
+// auditRuleID returns the report rule ID for the given classification (empty if unknown).
+func auditRuleID(classification string) string {
+	for _, r := range auditRules {
+		if r.classification == classification {
+			return r.id
+		}
+	}
+	return ""
+}
+
+// findingTitle returns a short title for the given finding (i.e. Removed component).
+func findingTitle(f dtos.ComponentAuditFinding) string {
+	title := strings.ToUpper(f.Classification[:1]) + f.Classification[1:]
+	if f.Scope == "version" {
+		return title + " version"
+	}
+	return title + " component"
+}
+
+// findingPurl returns the purl of the given finding, with its version (or requirement) if known.
+func findingPurl(f dtos.ComponentAuditFinding) string {
+	if version := firstNonEmpty(f.Version, f.Requirement); len(version) > 0 {
+		return f.Purl + "@" + version
+	}
+	return f.Purl
+}
+
+// findingMessage returns a human-readable description of the given finding.
+func findingMessage(f dtos.ComponentAuditFinding) string {
+	purl := findingPurl(f)
+	var msg string
+	switch f.Classification {
+	case dtos.AuditUnknown:
+		msg = fmt.Sprintf("%s is not known to the knowledge base", purl)
+	default:
+		msg = fmt.Sprintf("%s is %s", purl, f.Classification)
+		if status := describeStatus(f.Status, f.RepositoryStatus); len(status) > 0 && status != f.Classification {
+			msg += fmt.Sprintf(" (status: %s)", status)
+		}
+		if len(f.StatusChangeDate) > 0 {
+			msg += fmt.Sprintf(" since %s", f.StatusChangeDate)
+		}
+	}
+	if len(f.Message) > 0 {
+		msg += ": " + f.Message
+	}
+	return msg
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if len(v) > 0 {
+			return v
+		}
+	}
+	return ""
+}
+
+// writeAuditReport writes the audit findings in one of the CI report formats.
+// The source is the name of the audited SBOM/lockfile, used as the location of each finding.
+func writeAuditReport(out io.Writer, format, source string, output dtos.ComponentsAuditOutput, severities map[string]string) error {
+	switch format {
+	case outputFormatSARIF:
+		return writeJSON(out, newSarifReport(source, output, severities))
+	case outputFormatJUnit:
+		return writeJUnitReport(out, source, output)
+	case outputFormatGitHub:
+		return writeGitHubAnnotations(out, source, output)
+	default:
+		return writeAuditOutput(out, format, output)
+	}
+}
+
+// SARIF 2.1.0 report structure (only the parts we produce).
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints"`
+	Properties          map[string]string  `json:"properties,omitempty"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// newSarifReport converts the audit findings into a SARIF 2.1.0 report, using the configured severities as the rule levels.
+func newSarifReport(source string, output dtos.ComponentsAuditOutput, severities map[string]string) sarifReport {
+	rules := make([]sarifRule, 0, len(auditRules))
+	for _, r := range auditRules {
+		rules = append(rules, sarifRule{
+			ID:                   r.id,
+			Name:                 r.name,
+			ShortDescription:     sarifMessage{Text: r.description},
+			DefaultConfiguration: sarifConfiguration{Level: severities[r.classification]},
+		})
+	}
+	results := make([]sarifResult, 0, len(output.Findings)+len(output.Waived))
+	for _, f := range output.Findings {
+		results = append(results, newSarifResult(source, f, f.Severity))
+	}
+	// Waived findings are reported as suppressed results, so the exemption remains visible
+	for _, f := range output.Waived {
+		level := severities[f.Classification]
+		if len(level) == 0 || level == severityNone || f.Waiver == nil {
+			continue
+		}
+		result := newSarifResult(source, f, level)
+		result.Suppressions = []sarifSuppression{{
+			Kind:          "external",
+			Status:        "accepted",
+			Justification: fmt.Sprintf("%s (owner: %s, expires: %s)", f.Waiver.Justification, f.Waiver.Owner, f.Waiver.Expires),
+		}}
+		results = append(results, result)
+	}
+	return sarifReport{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "scanoss-components",
+				Version:        strings.TrimSpace(version),
+				InformationURI: "https://github.com/scanoss/components",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// newSarifResult converts a single audit finding into a SARIF result with the given level.
+func newSarifResult(source string, f dtos.ComponentAuditFinding, level string) sarifResult {
+	return sarifResult{
+		RuleID:  auditRuleID(f.Classification),
+		Level:   level,
+		Message: sarifMessage{Text: findingMessage(f)},
+		Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: (&url.URL{Path: filepath.ToSlash(source)}).String()},
+			Region:           sarifRegion{StartLine: 1},
+		}}},
+		PartialFingerprints: map[string]string{"componentPurl/v1": findingPurl(f)},
+		Properties:          map[string]string{"purl": f.Purl, "classification": f.Classification, "scope": f.Scope},
+	}
+}
+
+// JUnit XML report structure.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes the audit findings as a JUnit XML report. Each finding becomes a test case,
+// which fails for error and warning severities.
+func writeJUnitReport(out io.Writer, source string, output dtos.ComponentsAuditOutput) error {
+	suite := junitTestSuite{Name: "components-audit: " + source, TestCases: []junitTestCase{}}
+	for _, f := range output.Findings {
+		tc := junitTestCase{Name: findingPurl(f), ClassName: source}
+		if severityRank[f.Severity] >= severityRank[severityWarning] {
+			tc.Failure = &junitFailure{
+				Message: findingTitle(f),
+				Type:    f.Severity,
+				Text:    findingMessage(f),
+			}
+			suite.Failures++
+		} else {
+			tc.SystemOut = findingMessage(f)
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	suite.Tests = len(suite.TestCases)
+	report := junitTestSuites{Tests: suite.Tests, Failures: suite.Failures, Suites: []junitTestSuite{suite}}
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return fmt.Errorf("failed to produce JUnit output: %v", err)
+	}
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to produce JUnit output: %v", err)
+	}
+	_, _ = fmt.Fprintln(out)
+	return nil
+}
+
+// gitHubEscaper escapes GitHub workflow command data, gitHubPropertyEscaper also escapes property values.
+var (
+	gitHubEscaper         = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	gitHubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// writeGitHubAnnotations writes the audit findings as GitHub Actions workflow commands (annotations).
+func writeGitHubAnnotations(out io.Writer, source string, output dtos.ComponentsAuditOutput) error {
+	for _, f := range output.Findings {
+		command := f.Severity
+		if command == severityNote {
+			command = "notice"
+		}
+		if _, err := fmt.Fprintf(out, "::%s file=%s,title=%s::%s\n", command, gitHubPropertyEscaper.Replace(source),
+			gitHubPropertyEscaper.Replace(findingTitle(f)), gitHubEscaper.Replace(findingMessage(f))); err != nil {
+			return fmt.Errorf("failed to produce GitHub annotations: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// auditReportSource is the audited file name, with characters that need escaping in each report format.
+const auditReportSource = "sbom, \"final\".json"
+
+// newAuditReportOutput returns audit findings (with their default severities) whose purls and messages need
+// escaping in each report format.
+func newAuditReportOutput(t *testing.T) (dtos.ComponentsAuditOutput, map[string]string) {
+	t.Helper()
+	output := dtos.ComponentsAuditOutput{
+		Findings: []dtos.ComponentAuditFinding{
+			{Purl: "pkg:npm/%40acme/left-pad", Version: "1.0.0", Classification: dtos.AuditRemoved, Scope: "version",
+				Status: "removed", RepositoryStatus: "unpublished", StatusChangeDate: "2024-01-02",
+				Message: "gone: 100%\nsee <https://example.com/?a=1&b=2>"},
+			{Purl: "pkg:pypi/requests", Requirement: ">=2,<3", Classification: dtos.AuditDeprecated, Scope: "component", Status: "deprecated"},
+			{Purl: "pkg:gem/unknown", Classification: dtos.AuditUnknown, Scope: "component"},
+		},
+		Waived: []dtos.ComponentAuditFinding{
+			{Purl: "pkg:npm/old", Version: "0.1.0", Classification: dtos.AuditDeprecated, Scope: "version",
+				Waiver: &dtos.ComponentWaiver{Expires: "2099-01-01", Justification: "vendor fork", Owner: "team-a"}},
+		},
+	}
+	severities, err := parseSeverities("")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing the default severities", err)
+	}
+	applySeverities(&output, severities, "")
+	return output, severities
+}
+
+func TestWriteAuditReportSARIF(t *testing.T) {
+	defer func(v string) { version = v }(version)
+	version = "1.2.3\n"
+	output, severities := newAuditReportOutput(t)
+	output.Findings = output.Findings[:1]
+	var out bytes.Buffer
+	if err := writeAuditReport(&out, outputFormatSARIF, auditReportSource, output, severities); err != nil {
+		t.Fatalf("an error '%s' was not expected when writing the SARIF report", err)
+	}
+	want := `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "scanoss-components",
+          "version": "1.2.3",
+          "informationUri": "https://github.com/scanoss/components",
+          "rules": [
+            {
+              "id": "component-removed",
+              "name": "RemovedComponent",
+              "shortDescription": {
+                "text": "The component or version has been removed, unpublished or yanked from its registry"
+              },
+              "defaultConfiguration": {
+                "level": "error"
+              }
+            },
+            {
+              "id": "component-deprecated",
+              "name": "DeprecatedComponent",
+              "shortDescription": {
+                "text": "The component or version has been deprecated or archived"
+              },
+              "defaultConfiguration": {
+                "level": "warning"
+              }
+            },
+            {
+              "id": "component-unknown",
+              "name": "UnknownComponent",
+              "shortDescription": {
+                "text": "The component or version is not known to the SCANOSS knowledge base"
+              },
+              "defaultConfiguration": {
+                "level": "note"
+              }
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "component-removed",
+          "level": "error",
+          "message": {
+            "text": "pkg:npm/%40acme/left-pad@1.0.0 is removed (status: removed (unpublished)) since 2024-01-02: gone: 100%\nsee \u003chttps://example.com/?a=1\u0026b=2\u003e"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "sbom,%20%22final%22.json"
+                },
+                "region": {
+                  "startLine": 1
+                }
+              }
+            }
+          ],
+          "partialFingerprints": {
+            "componentPurl/v1": "pkg:npm/%40acme/left-pad@1.0.0"
+          },
+          "properties": {
+            "classification": "removed",
+            "purl": "pkg:npm/%40acme/left-pad",
+            "scope": "version"
+          }
+        },
+        {
+          "ruleId": "component-deprecated",
+          "level": "warning",
+          "message": {
+            "text": "pkg:npm/old@0.1.0 is deprecated"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "sbom,%20%22final%22.json"
+                },
+                "region": {
+                  "startLine": 1
+                }
+              }
+            }
+          ],
+          "partialFingerprints": {
+            "componentPurl/v1": "pkg:npm/old@0.1.0"
+          },
+          "properties": {
+            "classification": "deprecated",
+            "purl": "pkg:npm/old",
+            "scope": "version"
+          },
+          "suppressions": [
+            {
+              "kind": "external",
+              "status": "accepted",
+              "justification": "vendor fork (owner: team-a, expires: 2099-01-01)"
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}
+`
+	if out.String() != want {
+		t.Errorf("unexpected SARIF report:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestWriteAuditReportJUnit(t *testing.T) {
+	output, severities := newAuditReportOutput(t)
+	var out bytes.Buffer
+	if err := writeAuditReport(&out, outputFormatJUnit, auditReportSource, output, severities); err != nil {
+		t.Fatalf("an error '%s' was not expected when writing the JUnit report", err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="2">
+  <testsuite name="components-audit: sbom, &#34;final&#34;.json" tests="3" failures="2">
+    <testcase name="pkg:npm/%40acme/left-pad@1.0.0" classname="sbom, &#34;final&#34;.json">
+      <failure message="Removed version" type="error">pkg:npm/%40acme/left-pad@1.0.0 is removed (status: removed (unpublished)) since 2024-01-02: gone: 100%&#xA;see &lt;https://example.com/?a=1&amp;b=2&gt;</failure>
+    </testcase>
+    <testcase name="pkg:pypi/requests@&gt;=2,&lt;3" classname="sbom, &#34;final&#34;.json">
+      <failure message="Deprecated component" type="warning">pkg:pypi/requests@&gt;=2,&lt;3 is deprecated</failure>
+    </testcase>
+    <testcase name="pkg:gem/unknown" classname="sbom, &#34;final&#34;.json">
+      <system-out>pkg:gem/unknown is not known to the knowledge base</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if out.String() != want {
+		t.Errorf("unexpected JUnit report:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestWriteAuditReportGitHub(t *testing.T) {
+	output, severities := newAuditReportOutput(t)
+	var out bytes.Buffer
+	if err := writeAuditReport(&out, outputFormatGitHub, auditReportSource, output, severities); err != nil {
+		t.Fatalf("an error '%s' was not expected when writing the GitHub annotations", err)
+	}
+	want := `::error file=sbom%2C "final".json,title=Removed version::pkg:npm/%2540acme/left-pad@1.0.0 is removed (status: removed (unpublished)) since 2024-01-02: gone: 100%25%0Asee <https://example.com/?a=1&b=2>
+::warning file=sbom%2C "final".json,title=Deprecated component::pkg:pypi/requests@>=2,<3 is deprecated
+::notice file=sbom%2C "final".json,title=Unknown component::pkg:gem/unknown is not known to the knowledge base
+`
+	if out.String() != want {
+		t.Errorf("unexpected GitHub annotations:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestParseSeverities(t *testing.T) {
+	severities, err := parseSeverities(" Deprecated=ERROR, unknown=none ")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing severities", err)
+	}
+	want := map[string]string{dtos.AuditRemoved: severityError, dtos.AuditDeprecated: severityError, dtos.AuditUnknown: severityNone}
+	for classification, severity := range want {
+		if severities[classification] != severity {
+			t.Errorf("parseSeverities() %v = %q, want %q", classification, severities[classification], severity)
+		}
+	}
+	for _, value := range []string{"removed", "removed=fatal", "outdated=error", "=error"} {
+		if _, err = parseSeverities(value); err == nil {
+			t.Errorf("parseSeverities(%q) expected an error", value)
+		}
+	}
+}
+
+func TestApplySeverities(t *testing.T) {
+	tests := []struct {
+		name         string
+		severity     string
+		failOn       string
+		wantFailures int
+		wantFindings int
+	}{
+		{name: "no threshold", failOn: "", wantFailures: 0, wantFindings: 3},
+		{name: "fail on error", failOn: severityError, wantFailures: 1, wantFindings: 3},
+		{name: "fail on warning", failOn: severityWarning, wantFailures: 2, wantFindings: 3},
+		{name: "fail on note", failOn: severityNote, wantFailures: 3, wantFindings: 3},
+		{name: "fail on none", failOn: severityNone, wantFailures: 0, wantFindings: 3},
+		{name: "unreported classification", severity: "unknown=none", failOn: severityNote, wantFailures: 2, wantFindings: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, _ := newAuditReportOutput(t)
+			severities, err := parseSeverities(tt.severity)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when parsing severities", err)
+			}
+			if failures := applySeverities(&output, severities, tt.failOn); failures != tt.wantFailures {
+				t.Errorf("applySeverities() failures = %d, want %d", failures, tt.wantFailures)
+			}
+			if len(output.Findings) != tt.wantFindings {
+				t.Errorf("applySeverities() kept %d findings, want %d", len(output.Findings), tt.wantFindings)
+			}
+		})
+	}
+}
+
+func TestRunAuditCommandExitCodes(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	setupCliDB(t)
+	// An unknown component, reported with the note severity by default
+	lockfile := filepath.Join(t.TempDir(), "requirements.txt")
+	if err = os.WriteFile(lockfile, []byte("no-such-package-xyz-123==1.0.0\n"), 0o600); err != nil {
+		t.Fatalf("an error '%s' was not expected when writing the lockfile", err)
+	}
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "below the threshold", args: []string{"-fail-on", "warning"}, want: exitOK},
+		{name: "at the threshold", args: []string{"-fail-on", "note"}, want: exitFindings},
+		{name: "raised severity", args: []string{"-severity", "unknown=error", "-fail-on", "error"}, want: exitFindings},
+		{name: "unreported classification", args: []string{"-severity", "unknown=none", "-fail-on", "note"}, want: exitOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append(append([]string{"audit", "-format", outputFormatGitHub}, tt.args...), lockfile)
+			code, err := RunCli(args)
+			if code != tt.want {
+				t.Errorf("RunCli(%v) exit code = %d, want %d (%v)", args, code, tt.want, err)
+			}
+			if tt.want == exitFindings && !errors.Is(err, errFindings) {
+				t.Errorf("RunCli(%v) error = %v, want %v", args, err, errFindings)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	se "scanoss.com/components/pkg/errors"
+	"scanoss.com/components/pkg/models"
+)
+
+// setupCliDB creates an SQLite database file loaded with the test data and points the CLI config at it.
+func setupCliDB(t *testing.T) {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "components.db")
+	db, err := sqlx.Connect("sqlite", dsn+"?_pragma=synchronous(off)") // Speed up loading the test data
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a test database", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("DB_DSN", dsn)
+}
+
+func TestRunCliUsage(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "no command", args: nil, want: exitUsage},
+		{name: "help", args: []string{"help"}, want: exitOK},
+		{name: "unknown command", args: []string{"unknown"}, want: exitUsage},
+		{name: "command help", args: []string{"search", "-h"}, want: exitOK},
+		{name: "unknown flag", args: []string{"search", "-unknown"}, want: exitUsage},
+		{name: "search without terms", args: []string{"search"}, want: exitUsage},
+		{name: "unsupported format", args: []string{"search", "-format", "xml", "angular"}, want: exitUsage},
+		{name: "suggest without prefix", args: []string{"suggest"}, want: exitUsage},
+		{name: "lookup without url", args: []string{"lookup"}, want: exitUsage},
+		{name: "versions without purl", args: []string{"versions"}, want: exitUsage},
+		{name: "resolve with two purls", args: []string{"resolve", "pkg:gem/a", "pkg:gem/b"}, want: exitUsage},
+		{name: "status without purls", args: []string{"status"}, want: exitUsage},
+		{name: "status requirement with two purls", args: []string{"status", "-requirement", "1.0", "pkg:gem/a", "pkg:gem/b"}, want: exitUsage},
+		{name: "audit without input", args: []string{"audit"}, want: exitUsage},
+		{name: "audit unsupported fail-on", args: []string{"audit", "-fail-on", "fatal", "sbom.json"}, want: exitUsage},
+		{name: "policy unsupported fail-on", args: []string{"policy", "-fail-on", "allow", "sbom.json"}, want: exitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := RunCli(tt.args)
+			if code != tt.want {
+				t.Errorf("RunCli(%v) exit code = %d, want %d", tt.args, code, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "generic error", err: errors.New("failed"), want: exitError},
+		{name: "not found", err: se.NewNotFoundError("not found"), want: exitNotFound},
+		{name: "bad request", err: se.NewBadRequestError("bad request", nil), want: exitClientError},
+		{name: "server error", err: &se.ServiceError{Message: "failed", HTTPCode: 503}, want: exitServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCodeForError(tt.err); got != tt.want {
+				t.Errorf("exitCodeForError() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunSearchCommand(t *testing.T) {
+	setupCliDB(t)
+	var out bytes.Buffer
+	if err := runSearchCommand([]string{"-package", "gem", "tablestyle"}, &out); err != nil {
+		t.Fatalf("an error '%s' was not expected when searching", err)
+	}
+	if !strings.Contains(out.String(), "pkg:gem/tablestyle") || !strings.Contains(out.String(), "Showing 1 of 1 components") {
+		t.Errorf("unexpected search output:\n%s", out.String())
+	}
+	out.Reset()
+	if err := runSearchCommand([]string{"-format", "json", "-package", "gem", "tablestyle"}, &out); err != nil {
+		t.Fatalf("an error '%s' was not expected when searching", err)
+	}
+	var output dtos.ComponentsSearchOutput
+	if err := json.Unmarshal(out.Bytes(), &output); err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing the JSON output", err)
+	}
+	if len(output.Components) != 1 || output.Components[0].Purl != "pkg:gem/tablestyle" {
+		t.Errorf("unexpected search results: %+v", output.Components)
+	}
+	code, err := RunCli([]string{"search", "-package", "gem", "no-such-component-exists"})
+	if code != exitNotFound {
+		t.Errorf("RunCli() exit code = %d, want %d (%v)", code, exitNotFound, err)
+	}
+}
+
+func TestRunVersionsCommand(t *testing.T) {
+	setupCliDB(t)
+	var out bytes.Buffer
+	if err := runVersionsCommand([]string{"-format", "json", "-limit", "2", "pkg:gem/tablestyle"}, &out); err != nil {
+		t.Fatalf("an error '%s' was not expected when listing versions", err)
+	}
+	var output dtos.ComponentVersionsOutput
+	if err := json.Unmarshal(out.Bytes(), &output); err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing the JSON output", err)
+	}
+	if output.Component.Name != "tablestyle" || len(output.Component.Versions) != 2 {
+		t.Errorf("unexpected versions output: %+v", output.Component)
+	}
+	out.Reset()
+	if err := runVersionsCommand([]string{"pkg:gem/tablestyle"}, &out); err != nil {
+		t.Fatalf("an error '%s' was not expected when listing versions", err)
+	}
+	if !strings.Contains(out.String(), "Component: tablestyle") || !strings.Contains(out.String(), "VERSION") {
+		t.Errorf("unexpected versions output:\n%s", out.String())
+	}
+}
+
+func TestRunResolveCommand(t *testing.T) {
+	setupCliDB(t)
+	var out bytes.Buffer
+	if err := runResolveCommand([]string{"-format", "json", "-requirement", "~>0.0", "pkg:gem/tablestyle"}, &out); err != nil {
+		t.Fatalf("an error '%s' was not expected when resolving a version", err)
+	}
+	var output dtos.ComponentResolutionOutput
+	if err := json.Unmarshal(out.Bytes(), &output); err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing the JSON output", err)
+	}
+	if output.Version != "0.99.0" {
+		t.Errorf("resolved version = %q, want 0.99.0", output.Version)
+	}
+}
+
+func TestRunLookupCommand(t *testing.T) {
+	setupCliDB(t)
+	var out bytes.Buffer
+	if err := runLookupCommand([]string{"https://rubygems.org/gems/tablestyle"}, &out); err != nil {
+		t.Fatalf("an error '%s' was not expected when looking up a URL", err)
+	}
+	if !strings.Contains(out.String(), "pkg:gem/tablestyle") {
+		t.Errorf("unexpected lookup output:\n%s", out.String())
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	got := splitList(" MIT, ,Apache-2.0,")
+	if len(got) != 2 || got[0] != "MIT" || got[1] != "Apache-2.0" {
+		t.Errorf("splitList() = %v, want [MIT Apache-2.0]", got)
+	}
+	if got = splitList(""); len(got) != 0 {
+		t.Errorf("splitList() = %v, want none", got)
+	}
+}
+
+func TestNewCliAPIClientMode(t *testing.T) {
+	t.Setenv("COMP_SEARCH_ORDER", "not json") // Invalid server only setting, which must not be loaded in client mode
+	opts := cliOptions{server: "localhost:50053", protocol: "grpc", timeout: time.Second}
+	api, cfg, cleanup, err := newCliAPIWithConfig(&opts)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating a client", err)
+	}
+	defer cleanup()
+	if api == nil {
+		t.Errorf("expected a remote service client")
+	}
+	if _, ok := cfg.(*myconfig.ClientConfig); !ok {
+		t.Errorf("expected a client config, got %T", cfg)
+	}
+}
@@ -62,6 +62,11 @@ func getConfig() (*myconfig.ServerConfig, error) {
 		fmt.Printf("Version: %v", version)
 		os.Exit(1)
 	}
+	return loadConfig(jsonConfig, envConfig, *debug)
+}
+
+// configFeeders returns the feeders for the supplied JSON/dot-ENV config files, enabling debug (APP_DEBUG) if requested.
+func configFeeders(jsonConfig, envConfig string, debug bool) ([]config.Feeder, error) {
 	var feeders []config.Feeder
 	if len(jsonConfig) > 0 {
 		feeders = append(feeders, feeder.Json{Path: jsonConfig})
@@ -69,13 +74,23 @@ func getConfig() (*myconfig.ServerConfig, error) {
 	if len(envConfig) > 0 {
 		feeders = append(feeders, feeder.DotEnv{Path: envConfig})
 	}
-	if *debug {
+	if debug {
 		err := os.Setenv("APP_DEBUG", "1")
 		if err != nil {
 			fmt.Printf("Warning: Failed to set env APP_DEBUG to 1: %v", err)
 			return nil, err
 		}
 	}
+	return feeders, nil
+}
+
+// loadConfig builds the server config from the supplied JSON/dot-ENV files (plus the environment),
+// sets up the application logger and initialises the status mapper, policy, waivers and search ordering.
+func loadConfig(jsonConfig, envConfig string, debug bool) (*myconfig.ServerConfig, error) {
+	feeders, err := configFeeders(jsonConfig, envConfig, debug)
+	if err != nil {
+		return nil, err
+	}
 	myConfig, err := myconfig.NewServerConfig(feeders)
 	if err != nil {
 		return nil, err
@@ -87,7 +102,33 @@ func getConfig() (*myconfig.ServerConfig, error) {
 	}
 	// Initialise the status mapping config
 	myConfig.InitStatusMapperConfig(zlog.S)
-	return myConfig, err
+	// Load the status policy (if configured)
+	if err = myConfig.InitPolicyConfig(zlog.S); err != nil {
+		return nil, err
+	}
+	// Load the status waivers (if configured)
+	if err = myConfig.InitWaiversConfig(zlog.S); err != nil {
+		return nil, err
+	}
+	// Apply any search result ordering overrides
+	if err = myConfig.InitSearchOrderConfig(zlog.S); err != nil {
+		return nil, err
+	}
+	return myConfig, nil
+}
+
+// openDB opens the configured database connection pool and checks that it is reachable.
+func openDB(cfg *myconfig.ServerConfig) (*sqlx.DB, error) {
+	db, err := gd.OpenDBConnection(cfg.Database.Dsn, cfg.Database.Driver, cfg.Database.User, cfg.Database.Passwd,
+		cfg.Database.Host, cfg.Database.Schema, cfg.Database.SslMode)
+	if err != nil {
+		return nil, err
+	}
+	if err = gd.SetDBOptionsAndPing(db); err != nil {
+		gd.CloseDBConnection(db)
+		return nil, err
+	}
+	return db, nil
 }
 
 // RunServer runs the gRPC Component Server.
@@ -114,14 +155,10 @@ func RunServer() error {
 	}
 	zlog.S.Infof("Starting SCANOSS Component Service: %v", cfg.App.Version)
 	// Set up the database connection pool
-	db, err := gd.OpenDBConnection(cfg.Database.Dsn, cfg.Database.Driver, cfg.Database.User, cfg.Database.Passwd,
-		cfg.Database.Host, cfg.Database.Schema, cfg.Database.SslMode)
+	db, err := openDB(cfg)
 	if err != nil {
 		return err
 	}
-	if err = gd.SetDBOptionsAndPing(db); err != nil {
-		return err
-	}
 	defer gd.CloseDBConnection(db)
 	// Log database version info
 	logDBVersion(db)
@@ -129,6 +166,7 @@ func RunServer() error {
 	zlog.SetupAppDynamicLogging(cfg.Logging.DynamicPort, cfg.Logging.DynamicLogging)
 	// Register the component service
 	v2API := service.NewComponentServer(db, cfg)
+	extAPI := service.NewComponentExtensionServer(db, cfg)
 	ctx := context.Background()
 	// Start the REST grpc-gateway if requested
 	var srv *http.Server
@@ -138,7 +176,7 @@ func RunServer() error {
 		}
 	}
 	// Start the gRPC service
-	server, err := grpc.RunServer(cfg, v2API, cfg.App.GRPCPort, allowedIPs, deniedIPs, startTLS)
+	server, err := grpc.RunServer(cfg, v2API, extAPI, cfg.App.GRPCPort, allowedIPs, deniedIPs, startTLS)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"github.com/golobby/config/v3"
+	"github.com/golobby/config/v3/pkg/feeder"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ClientConfig is a configuration for the CLI when talking to a remote Component Service (client mode).
+// It only holds the settings a client needs, so no server (database, ports, etc.) settings are required.
+type ClientConfig struct {
+	App struct {
+		Debug bool   `env:"APP_DEBUG"` // true/false
+		Mode  string `env:"APP_MODE"`  // dev or prod
+	}
+	Logging struct {
+		ConfigFile string `env:"LOG_JSON_CONFIG"`
+	}
+	TLS struct {
+		CertFile string `env:"COMP_TLS_CERT"` // CA certificate to trust for the remote service
+		CN       string `env:"COMP_TLS_CN"`   // Common Name to verify the remote certificate against
+	}
+	StatusMapping struct {
+		Mapping string `env:"STATUS_MAPPING"` // JSON string mapping DB statuses to classified statuses (from env or file)
+	}
+	Policy struct {
+		File string `env:"COMP_POLICY_FILE"` // Optional policy file (YAML/JSON) used to evaluate component status verdicts
+	}
+	Waivers struct {
+		File string `env:"COMP_WAIVERS_FILE"` // Optional waivers file (YAML/JSON) exempting components from removed/deprecated findings
+	}
+	// statusMapper is the compiled status mapper (initialised once at startup)
+	statusMapper *StatusMapper
+	// policy is the compiled status policy (initialised once at startup, if configured)
+	policy *Policy
+	// waivers is the list of time-boxed status exemptions (initialised once at startup, if configured)
+	waivers *Waivers
+}
+
+// NewClientConfig loads all client config options and return a struct for use.
+func NewClientConfig(feeders []config.Feeder) (*ClientConfig, error) {
+	cfg := ClientConfig{}
+	cfg.App.Mode = "dev"
+	c := config.New()
+	for _, f := range feeders {
+		c.AddFeeder(f)
+	}
+	c.AddFeeder(feeder.Env{})
+	c.AddStruct(&cfg)
+	err := c.Feed()
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// InitStatusMapperConfig initialise the status mapper for mapping component statuses.
+func (cfg *ClientConfig) InitStatusMapperConfig(s *zap.SugaredLogger) {
+	cfg.statusMapper = NewStatusMapper(s, parseStatusMappingString(cfg.StatusMapping.Mapping))
+}
+
+// GetStatusMapper returns the status mapper for mapping database statuses to classified statuses.
+func (cfg *ClientConfig) GetStatusMapper() *StatusMapper {
+	// Initialise the mapper if it wasn't done previously
+	if cfg.statusMapper == nil {
+		cfg.InitStatusMapperConfig(zlog.S)
+	}
+	return cfg.statusMapper
+}
+
+// InitPolicyConfig loads and validates the status policy file (if configured).
+func (cfg *ClientConfig) InitPolicyConfig(s *zap.SugaredLogger) error {
+	policy, err := loadPolicyConfig(s, cfg.Policy.File)
+	cfg.policy = policy
+	return err
+}
+
+// GetPolicy returns the status policy, or nil if no policy has been configured.
+func (cfg *ClientConfig) GetPolicy() *Policy {
+	return cfg.policy
+}
+
+// InitWaiversConfig loads and validates the status waivers file (if configured).
+func (cfg *ClientConfig) InitWaiversConfig(s *zap.SugaredLogger) error {
+	waivers, err := loadWaiversConfig(s, cfg.Waivers.File)
+	cfg.waivers = waivers
+	return err
+}
+
+// GetWaivers returns the status waivers, or nil if no waivers file has been configured.
+func (cfg *ClientConfig) GetWaivers() *Waivers {
+	return cfg.waivers
+}
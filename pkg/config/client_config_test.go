@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/golobby/config/v3"
+	"github.com/golobby/config/v3/pkg/feeder"
+)
+
+// TestClientConfig verifies that NewClientConfig loads the client settings from the environment,
+// and that no server settings (i.e. database) are needed to do so.
+func TestClientConfig(t *testing.T) {
+	t.Setenv("COMP_TLS_CN", "components.example.com")
+	t.Setenv("COMP_POLICY_FILE", "tests/policy.yaml")
+	t.Setenv("COMP_WAIVERS_FILE", "tests/waivers.yaml")
+	var feeders []config.Feeder
+	feeders = append(feeders, feeder.Json{Path: "tests/env.json"})
+	cfg, err := NewClientConfig(feeders)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating new client config instance", err)
+	}
+	if cfg.TLS.CN != "components.example.com" {
+		t.Errorf("TLS CN '%v' doesn't match expected: components.example.com", cfg.TLS.CN)
+	}
+	if cfg.App.Mode != "dev" {
+		t.Errorf("App mode '%v' doesn't match expected: dev", cfg.App.Mode)
+	}
+	if err = cfg.InitPolicyConfig(nil); err != nil || cfg.GetPolicy() == nil {
+		t.Errorf("expected the policy to be loaded: %v", err)
+	}
+	if err = cfg.InitWaiversConfig(nil); err != nil || cfg.GetWaivers() == nil {
+		t.Errorf("expected the waivers to be loaded: %v", err)
+	}
+	if cfg.GetStatusMapper() == nil {
+		t.Errorf("expected a status mapper")
+	}
+}
@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy verdicts (actions), from least to most severe.
+const (
+	PolicyAllow = "allow"
+	PolicyWarn  = "warn"
+	PolicyDeny  = "deny"
+)
+
+// Policy condition scopes.
+const (
+	PolicyScopeAny       = "any"
+	PolicyScopeComponent = "component"
+	PolicyScopeVersion   = "version"
+)
+
+// policyAgeRegex matches relative ages such as 2y, 18m, 2w, 90d or 1y6m.
+var policyAgeRegex = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)m)?(?:(\d+)w)?(?:(\d+)d)?$`)
+
+// Policy is a set of rules used to produce allow/warn/deny verdicts from component statuses.
+// It can be written in YAML or JSON.
+type Policy struct {
+	Name    string       `yaml:"name"`
+	Default string       `yaml:"default"` // Verdict when no rule matches (defaults to allow)
+	Rules   []PolicyRule `yaml:"rules"`
+}
+
+// PolicyRule produces its action as the verdict for any component matching all of its conditions.
+// When several rules match, the most severe action wins.
+type PolicyRule struct {
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description"`
+	Action      string          `yaml:"action"` // allow, warn or deny
+	When        PolicyCondition `yaml:"when"`
+}
+
+// PolicyCondition describes the components a rule applies to. Every condition set must match (a rule with no
+// conditions matches every component). Statuses are compared case-insensitively.
+type PolicyCondition struct {
+	Purls                  []string `yaml:"purl"`                      // purl patterns (* and ? wildcards, no version)
+	Scope                  string   `yaml:"scope"`                     // component, version or any (default)
+	Status                 []string `yaml:"status"`                    // Mapped status (see StatusMapper)
+	RepositoryStatus       []string `yaml:"repository_status"`         // Raw registry status
+	Unknown                *bool    `yaml:"unknown"`                   // Component/version is (or is not) known to the KB
+	LastIndexedOlderThan   string   `yaml:"last_indexed_older_than"`   // i.e. 2y, 6m, 90d
+	StatusChangedOlderThan string   `yaml:"status_changed_older_than"` // i.e. 2y, 6m, 90d
+	purlRegexes            []*regexp.Regexp
+	lastIndexedAge         PolicyAge
+	statusChangedAge       PolicyAge
+}
+
+// PolicyAge is a relative age expressed in years, months and days.
+type PolicyAge struct {
+	Years, Months, Days int
+}
+
+// IsSet reports whether an age has been specified.
+func (a PolicyAge) IsSet() bool {
+	return a.Years > 0 || a.Months > 0 || a.Days > 0
+}
+
+// Cutoff returns the point in time the given age refers to, relative to now.
+func (a PolicyAge) Cutoff(now time.Time) time.Time {
+	return now.AddDate(-a.Years, -a.Months, -a.Days)
+}
+
+// ParsePolicyAge parses a relative age such as 2y, 18m, 2w, 90d or 1y6m.
+func ParsePolicyAge(value string) (PolicyAge, error) {
+	m := policyAgeRegex.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if m == nil || len(m[0]) == 0 {
+		return PolicyAge{}, fmt.Errorf("invalid age %q (expected a combination of <n>y, <n>m, <n>w and <n>d)", value)
+	}
+	n := func(s string) int {
+		v, _ := strconv.Atoi(s)
+		return v
+	}
+	return PolicyAge{Years: n(m[1]), Months: n(m[2]), Days: n(m[3])*7 + n(m[4])}, nil
+}
+
+// PolicyActionRank returns the severity rank of a policy action (allow < warn < deny).
+func PolicyActionRank(action string) int {
+	switch action {
+	case PolicyWarn:
+		return 1
+	case PolicyDeny:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// LoadPolicyFile reads and validates a YAML or JSON policy file.
+func LoadPolicyFile(filename string) (*Policy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file %v: %v", filename, err)
+	}
+	policy, err := ParsePolicy(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %v: %v", filename, err)
+	}
+	return policy, nil
+}
+
+// ParsePolicy parses and validates a YAML or JSON policy. Unknown fields are rejected to catch typos.
+func ParsePolicy(data []byte) (*Policy, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, errors.New("no policy data supplied")
+	}
+	var policy Policy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&policy); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse policy: %v", err)
+	}
+	if err := policy.compile(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// compile validates the policy and prepares its conditions for evaluation.
+func (p *Policy) compile() error {
+	p.Default = strings.ToLower(strings.TrimSpace(p.Default))
+	if len(p.Default) == 0 {
+		p.Default = PolicyAllow
+	}
+	if !isPolicyAction(p.Default) {
+		return fmt.Errorf("invalid default verdict %q (expected allow, warn or deny)", p.Default)
+	}
+	if len(p.Rules) == 0 {
+		return errors.New("policy does not contain any rules")
+	}
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if len(r.Name) == 0 {
+			r.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+		if !isPolicyAction(r.Action) {
+			return fmt.Errorf("rule %v: invalid action %q (expected allow, warn or deny)", r.Name, r.Action)
+		}
+		if err := r.When.compile(); err != nil {
+			return fmt.Errorf("rule %v: %v", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// compile validates the condition and pre-computes its patterns and ages.
+func (c *PolicyCondition) compile() error {
+	c.Scope = strings.ToLower(strings.TrimSpace(c.Scope))
+	switch c.Scope {
+	case "":
+		c.Scope = PolicyScopeAny
+	case PolicyScopeAny, PolicyScopeComponent, PolicyScopeVersion:
+	default:
+		return fmt.Errorf("invalid scope %q (expected component, version or any)", c.Scope)
+	}
+	var err error
+	c.purlRegexes = make([]*regexp.Regexp, 0, len(c.Purls))
+	for _, p := range c.Purls {
+		re, err := compilePurlPattern(p)
+		if err != nil {
+			return err
+		}
+		c.purlRegexes = append(c.purlRegexes, re)
+	}
+	if len(c.LastIndexedOlderThan) > 0 {
+		if c.lastIndexedAge, err = ParsePolicyAge(c.LastIndexedOlderThan); err != nil {
+			return fmt.Errorf("last_indexed_older_than: %v", err)
+		}
+	}
+	if len(c.StatusChangedOlderThan) > 0 {
+		if c.statusChangedAge, err = ParsePolicyAge(c.StatusChangedOlderThan); err != nil {
+			return fmt.Errorf("status_changed_older_than: %v", err)
+		}
+	}
+	return nil
+}
+
+// MatchesPurl reports whether the purl matches the condition patterns (or no patterns were specified).
+func (c *PolicyCondition) MatchesPurl(purl string) bool {
+	if len(c.purlRegexes) == 0 {
+		return true
+	}
+	for _, re := range c.purlRegexes {
+		if re.MatchString(purl) {
+			return true
+		}
+	}
+	return false
+}
+
+// LastIndexedAge returns the parsed last_indexed_older_than age.
+func (c *PolicyCondition) LastIndexedAge() PolicyAge {
+	return c.lastIndexedAge
+}
+
+// StatusChangedAge returns the parsed status_changed_older_than age.
+func (c *PolicyCondition) StatusChangedAge() PolicyAge {
+	return c.statusChangedAge
+}
+
+// compilePurlPattern compiles a purl pattern with * and ? wildcards into a case-insensitive regular expression.
+func compilePurlPattern(pattern string) (*regexp.Regexp, error) {
+	expr := regexp.QuoteMeta(strings.TrimSpace(pattern))
+	expr = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(expr)
+	re, err := regexp.Compile("(?i)^" + expr + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid purl pattern %q: %v", pattern, err)
+	}
+	return re, nil
+}
+
+// isPolicyAction checks if the given value is a supported policy action.
+func isPolicyAction(action string) bool {
+	return action == PolicyAllow || action == PolicyWarn || action == PolicyDeny
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadPolicyFile(t *testing.T) {
+	policy, err := LoadPolicyFile("./tests/policy.yaml")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when loading the policy", err)
+	}
+	if policy.Name != "ci-policy" || policy.Default != PolicyAllow || len(policy.Rules) != 5 {
+		t.Errorf("unexpected policy: %+v", policy)
+	}
+	if r := policy.Rules[0]; r.Action != PolicyDeny || r.When.Scope != PolicyScopeAny || len(r.When.Status) != 2 {
+		t.Errorf("unexpected removed rule: %+v", r)
+	}
+	if age := policy.Rules[2].When.LastIndexedAge(); age.Years != 2 {
+		t.Errorf("unexpected stale age: %+v", age)
+	}
+	if u := policy.Rules[3].When.Unknown; u == nil || !*u {
+		t.Errorf("expected the unknown condition to be set")
+	}
+	cond := policy.Rules[4].When
+	if !cond.MatchesPurl("pkg:npm/@acme/widgets") || !cond.MatchesPurl("PKG:NPM/@ACME/x") {
+		t.Errorf("expected the internal pattern to match")
+	}
+	if cond.MatchesPurl("pkg:npm/lodash") {
+		t.Errorf("did not expect the internal pattern to match lodash")
+	}
+	if _, err = LoadPolicyFile("./tests/does-not-exist.yaml"); err == nil {
+		t.Errorf("expected an error loading a missing policy file")
+	}
+}
+
+func TestParsePolicyJSON(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`{"default": "WARN", "rules": [{"action": "Deny", "when": {"status": ["removed"]}}]}`))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing the policy", err)
+	}
+	if policy.Default != PolicyWarn || policy.Rules[0].Action != PolicyDeny || policy.Rules[0].Name != "rule-1" {
+		t.Errorf("unexpected policy: %+v", policy)
+	}
+	if !policy.Rules[0].When.MatchesPurl("pkg:github/scanoss/engine") {
+		t.Errorf("expected a condition without patterns to match every purl")
+	}
+}
+
+func TestParsePolicyInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: "  "},
+		{name: "no rules", input: "name: empty\n"},
+		{name: "bad default", input: "default: block\nrules:\n  - action: deny\n"},
+		{name: "bad action", input: "rules:\n  - action: block\n"},
+		{name: "bad scope", input: "rules:\n  - action: deny\n    when:\n      scope: vendor\n"},
+		{name: "bad age", input: "rules:\n  - action: deny\n    when:\n      last_indexed_older_than: soon\n"},
+		{name: "unknown field", input: "rules:\n  - action: deny\n    when:\n      stauts: [removed]\n"},
+		{name: "bad yaml", input: "rules: [\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePolicy([]byte(tt.input)); err == nil {
+				t.Errorf("expected an error parsing %q", tt.input)
+			}
+		})
+	}
+}
+
+func TestParsePolicyAge(t *testing.T) {
+	now := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{input: "2y", want: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "18m", want: time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "1y6m", want: time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "2w", want: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "90D", want: time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		age, err := ParsePolicyAge(tt.input)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", tt.input, err)
+			continue
+		}
+		if got := age.Cutoff(now); !got.Equal(tt.want) {
+			t.Errorf("ParsePolicyAge(%q).Cutoff() = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+	for _, input := range []string{"", "2", "y2", "2h", "-1y"} {
+		if _, err := ParsePolicyAge(input); err == nil {
+			t.Errorf("expected an error parsing %q", input)
+		}
+	}
+}
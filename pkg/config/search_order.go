@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// searchOrderColumns are the projects columns that search results can be ordered by.
+var searchOrderColumns = map[string]bool{
+	"component":           true,
+	"vendor":              true,
+	"purl_name":           true,
+	"versions":            true,
+	"first_version_date":  true,
+	"latest_version_date": true,
+	"git_created_at":      true,
+	"git_updated_at":      true,
+	"git_pushed_at":       true,
+	"git_stars":           true,
+	"git_forks":           true,
+	"git_issues":          true,
+	"first_indexed_date":  true,
+	"last_indexed_date":   true,
+}
+
+// ParseSearchOrder parses a JSON object mapping purl types to their search result ordering, a comma separated list
+// of "column [ASC|DESC] [NULLS FIRST|NULLS LAST]" (i.e. {"maven": "versions DESC NULLS LAST, first_version_date"}).
+// It returns the ORDER BY clause of each purl type, or an error if any column or direction is not allowed.
+func ParseSearchOrder(value string) (map[string]string, error) {
+	if len(strings.TrimSpace(value)) == 0 {
+		return nil, nil
+	}
+	var orders map[string]string
+	if err := json.Unmarshal([]byte(value), &orders); err != nil {
+		return nil, fmt.Errorf("invalid search order (expected a JSON object of purl types): %w", err)
+	}
+	clauses := make(map[string]string, len(orders))
+	for purlType, order := range orders {
+		purlType = strings.ToLower(strings.TrimSpace(purlType))
+		if len(purlType) == 0 {
+			return nil, fmt.Errorf("invalid search order: empty purl type")
+		}
+		clause, err := parseOrderBy(order)
+		if err != nil {
+			return nil, fmt.Errorf("invalid search order for %s: %w", purlType, err)
+		}
+		clauses[purlType] = clause
+	}
+	return clauses, nil
+}
+
+// parseOrderBy validates a comma separated list of order terms and returns them as an ORDER BY clause.
+func parseOrderBy(order string) (string, error) {
+	var terms []string
+	for _, term := range strings.Split(order, ",") {
+		fields := strings.Fields(strings.ToLower(term))
+		if len(fields) == 0 {
+			return "", fmt.Errorf("empty order term in %q", order)
+		}
+		if !searchOrderColumns[fields[0]] {
+			return "", fmt.Errorf("unsupported order column %q", fields[0])
+		}
+		rest := fields[1:]
+		if len(rest) > 0 && (rest[0] == "asc" || rest[0] == "desc") {
+			rest = rest[1:]
+		}
+		if len(rest) > 0 && (len(rest) != 2 || rest[0] != "nulls" || (rest[1] != "first" && rest[1] != "last")) {
+			return "", fmt.Errorf("unsupported order term %q (expected column [ASC|DESC] [NULLS FIRST|NULLS LAST])", strings.TrimSpace(term))
+		}
+		normalised := fields[0]
+		if len(fields) > 1 {
+			normalised += " " + strings.ToUpper(strings.Join(fields[1:], " "))
+		}
+		terms = append(terms, normalised)
+	}
+	return "ORDER BY " + strings.Join(terms, ", "), nil
+}
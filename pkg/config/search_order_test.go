@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"testing"
+)
+
+// TestParseSearchOrder verifies that per purl type orderings are normalised into ORDER BY clauses
+// and that only the allowed columns and directions are accepted.
+func TestParseSearchOrder(t *testing.T) {
+	clauses, err := ParseSearchOrder(`{"Maven": "versions desc nulls last, first_version_date", "nuget": " git_stars DESC "}`)
+	if err != nil {
+		t.Fatalf("ParseSearchOrder() error = %v", err)
+	}
+	if clauses["maven"] != "ORDER BY versions DESC NULLS LAST, first_version_date" || clauses["nuget"] != "ORDER BY git_stars DESC" {
+		t.Errorf("ParseSearchOrder() unexpected clauses: %v", clauses)
+	}
+	if clauses, err = ParseSearchOrder(" "); err != nil || clauses != nil {
+		t.Errorf("ParseSearchOrder() expected no clauses for an empty value, got %v (%v)", clauses, err)
+	}
+	invalid := []string{
+		`["maven"]`,
+		`{"maven": ""}`,
+		`{"maven": "versions,"}`,
+		`{"maven": "password"}`,
+		`{"maven": "versions; DROP TABLE projects"}`,
+		`{"maven": "versions DESC NULLS"}`,
+		`{"maven": "versions sideways"}`,
+		`{"": "versions"}`,
+	}
+	for _, value := range invalid {
+		if _, err = ParseSearchOrder(value); err == nil {
+			t.Errorf("ParseSearchOrder(%v) expected an error", value)
+		}
+	}
+}
@@ -90,8 +90,23 @@ type ServerConfig struct {
 	StatusMapping struct {
 		Mapping string `env:"STATUS_MAPPING"` // JSON string mapping DB statuses to classified statuses (from env or file)
 	}
+	Policy struct {
+		File string `env:"COMP_POLICY_FILE"` // Optional policy file (YAML/JSON) used to evaluate component status verdicts
+	}
+	Waivers struct {
+		File string `env:"COMP_WAIVERS_FILE"` // Optional waivers file (YAML/JSON) exempting components from removed/deprecated findings
+	}
+	Search struct {
+		Order string `env:"COMP_SEARCH_ORDER"` // JSON object overriding the search result ordering of purl types (i.e. {"maven":"versions DESC"})
+	}
 	// StatusMapper is the compiled status mapper (initialised once at startup)
 	statusMapper *StatusMapper
+	// policy is the compiled status policy (initialised once at startup, if configured)
+	policy *Policy
+	// waivers is the list of time-boxed status exemptions (initialised once at startup, if configured)
+	waivers *Waivers
+	// searchOrder is the validated ORDER BY clause of each overridden purl type (initialised once at startup)
+	searchOrder map[string]string
 }
 
 // NewServerConfig loads all config options and return a struct for use.
@@ -144,3 +159,75 @@ func (cfg *ServerConfig) GetStatusMapper() *StatusMapper {
 	}
 	return cfg.statusMapper
 }
+
+// InitPolicyConfig loads and validates the status policy file (if configured).
+func (cfg *ServerConfig) InitPolicyConfig(s *zap.SugaredLogger) error {
+	policy, err := loadPolicyConfig(s, cfg.Policy.File)
+	cfg.policy = policy
+	return err
+}
+
+// GetPolicy returns the status policy, or nil if no policy has been configured.
+func (cfg *ServerConfig) GetPolicy() *Policy {
+	return cfg.policy
+}
+
+// InitWaiversConfig loads and validates the status waivers file (if configured).
+func (cfg *ServerConfig) InitWaiversConfig(s *zap.SugaredLogger) error {
+	waivers, err := loadWaiversConfig(s, cfg.Waivers.File)
+	cfg.waivers = waivers
+	return err
+}
+
+// GetWaivers returns the status waivers, or nil if no waivers file has been configured.
+func (cfg *ServerConfig) GetWaivers() *Waivers {
+	return cfg.waivers
+}
+
+// InitSearchOrderConfig validates the search result ordering overrides (if configured).
+func (cfg *ServerConfig) InitSearchOrderConfig(s *zap.SugaredLogger) error {
+	order, err := ParseSearchOrder(cfg.Search.Order)
+	if err != nil {
+		return err
+	}
+	if s != nil && len(order) > 0 {
+		s.Infof("Loaded search result ordering for %d purl types", len(order))
+	}
+	cfg.searchOrder = order
+	return nil
+}
+
+// GetSearchOrder returns the ORDER BY clause of each purl type with a configured search result ordering.
+func (cfg *ServerConfig) GetSearchOrder() map[string]string {
+	return cfg.searchOrder
+}
+
+// loadPolicyConfig loads and validates the status policy file, returning nil if no file has been configured.
+func loadPolicyConfig(s *zap.SugaredLogger, filename string) (*Policy, error) {
+	if len(filename) == 0 {
+		return nil, nil
+	}
+	policy, err := LoadPolicyFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if s != nil {
+		s.Infof("Loaded status policy %q with %d rules", policy.Name, len(policy.Rules))
+	}
+	return policy, nil
+}
+
+// loadWaiversConfig loads and validates the status waivers file, returning nil if no file has been configured.
+func loadWaiversConfig(s *zap.SugaredLogger, filename string) (*Waivers, error) {
+	if len(filename) == 0 {
+		return nil, nil
+	}
+	waivers, err := LoadWaiversFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if s != nil {
+		s.Infof("Loaded %d status waivers", len(waivers.Waivers))
+	}
+	return waivers, nil
+}
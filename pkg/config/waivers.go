@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// versionConstraintRegex matches a single version constraint such as >=1.2.0, <2, !=1.4.1 or 1.3.0.
+var versionConstraintRegex = regexp.MustCompile(`^(>=|<=|!=|==|=|>|<)?\s*(v?[0-9A-Za-z][0-9A-Za-z.+\-_]*)$`)
+
+// versionOperatorRegex matches an operator separated from its version by whitespace (i.e. ">= 1.0").
+var versionOperatorRegex = regexp.MustCompile(`(>=|<=|!=|==|=|>|<)\s+`)
+
+// Waivers is a list of time-boxed exemptions for removed and deprecated components.
+// It can be written in YAML or JSON.
+type Waivers struct {
+	Waivers []Waiver `yaml:"waivers"`
+}
+
+// Waiver exempts the matching components from removed/deprecated findings until it expires.
+// At least one of purl or vendor must be supplied; all supplied fields must match.
+type Waiver struct {
+	Purl          string   `yaml:"purl"`          // purl pattern (* and ? wildcards, no version)
+	Vendor        string   `yaml:"vendor"`        // purl namespace (i.e. GitHub organisation, Maven group or npm scope)
+	Versions      string   `yaml:"versions"`      // Version constraints (i.e. ">=1.2.0, <2.0.0"). Empty matches all versions
+	Status        []string `yaml:"status"`        // Classifications to waive (removed and/or deprecated). Empty waives both
+	Expires       string   `yaml:"expires"`       // Last day the waiver applies (YYYY-MM-DD)
+	Justification string   `yaml:"justification"` // Why the exemption was granted
+	Owner         string   `yaml:"owner"`         // Who is accountable for the exemption
+	purlRegex     *regexp.Regexp
+	constraints   []VersionConstraint
+	expiresAt     time.Time
+}
+
+// VersionConstraint is a single comparison against a version, such as >=1.2.0.
+type VersionConstraint struct {
+	Op      string // One of =, !=, <, <=, > or >=
+	Version string
+}
+
+// LoadWaiversFile reads and validates a YAML or JSON waivers file.
+func LoadWaiversFile(filename string) (*Waivers, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read waivers file %v: %v", filename, err)
+	}
+	waivers, err := ParseWaivers(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid waivers file %v: %v", filename, err)
+	}
+	return waivers, nil
+}
+
+// ParseWaivers parses and validates a YAML or JSON waivers list. Unknown fields are rejected to catch typos.
+func ParseWaivers(data []byte) (*Waivers, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, errors.New("no waivers data supplied")
+	}
+	var waivers Waivers
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&waivers); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse waivers: %v", err)
+	}
+	for i := range waivers.Waivers {
+		if err := waivers.Waivers[i].compile(); err != nil {
+			return nil, fmt.Errorf("waiver %d: %v", i+1, err)
+		}
+	}
+	return &waivers, nil
+}
+
+// ParseVersionConstraints parses a comma (or space) separated list of version constraints.
+// A version without an operator is an exact match.
+func ParseVersionConstraints(value string) ([]VersionConstraint, error) {
+	value = versionOperatorRegex.ReplaceAllString(strings.TrimSpace(value), "$1")
+	fields := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	constraints := make([]VersionConstraint, 0, len(fields))
+	for _, f := range fields {
+		m := versionConstraintRegex.FindStringSubmatch(f)
+		if m == nil {
+			return nil, fmt.Errorf("invalid version constraint %q", f)
+		}
+		op := m[1]
+		if len(op) == 0 || op == "==" {
+			op = "="
+		}
+		constraints = append(constraints, VersionConstraint{Op: op, Version: m[2]})
+	}
+	if len(constraints) == 0 {
+		return nil, fmt.Errorf("invalid version constraints %q", value)
+	}
+	return constraints, nil
+}
+
+// compile validates the waiver and prepares it for matching.
+func (w *Waiver) compile() error {
+	if len(strings.TrimSpace(w.Purl)) == 0 && len(strings.TrimSpace(w.Vendor)) == 0 {
+		return errors.New("a purl or vendor is required")
+	}
+	if len(strings.TrimSpace(w.Justification)) == 0 {
+		return errors.New("a justification is required")
+	}
+	if len(strings.TrimSpace(w.Owner)) == 0 {
+		return errors.New("an owner is required")
+	}
+	expires, err := time.Parse(time.DateOnly, strings.TrimSpace(w.Expires))
+	if err != nil {
+		return fmt.Errorf("invalid expires date %q (expected YYYY-MM-DD)", w.Expires)
+	}
+	w.expiresAt = expires.AddDate(0, 0, 1) // The waiver is valid for the whole of its last day
+	if len(strings.TrimSpace(w.Purl)) > 0 {
+		if w.purlRegex, err = compilePurlPattern(w.Purl); err != nil {
+			return err
+		}
+	}
+	w.Vendor = strings.TrimSpace(w.Vendor)
+	if len(strings.TrimSpace(w.Versions)) > 0 {
+		if w.constraints, err = ParseVersionConstraints(w.Versions); err != nil {
+			return err
+		}
+	}
+	for i, status := range w.Status {
+		w.Status[i] = strings.ToLower(strings.TrimSpace(status))
+		if w.Status[i] != "removed" && w.Status[i] != "deprecated" {
+			return fmt.Errorf("invalid status %q (expected removed or deprecated)", status)
+		}
+	}
+	return nil
+}
+
+// MatchesPurl reports whether the purl matches the waiver purl pattern and vendor (if supplied).
+func (w *Waiver) MatchesPurl(purl string) bool {
+	if w.purlRegex != nil && !w.purlRegex.MatchString(purl) {
+		return false
+	}
+	if len(w.Vendor) > 0 && !strings.EqualFold(w.Vendor, purlNamespace(purl)) {
+		return false
+	}
+	return true
+}
+
+// Constraints returns the parsed version constraints (empty if the waiver applies to every version).
+func (w *Waiver) Constraints() []VersionConstraint {
+	return w.constraints
+}
+
+// IsExpired reports whether the waiver has expired at the given point in time.
+func (w *Waiver) IsExpired(now time.Time) bool {
+	return !now.Before(w.expiresAt)
+}
+
+// purlNamespace returns the namespace (vendor) of a purl, i.e. scanoss for pkg:github/scanoss/engine.
+func purlNamespace(purl string) string {
+	purl = strings.TrimPrefix(purl, "pkg:")
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		purl = purl[:i]
+	}
+	parts := strings.Split(purl, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.Join(parts[1:len(parts)-1], "/")
+}
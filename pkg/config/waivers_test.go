@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadWaiversFile(t *testing.T) {
+	waivers, err := LoadWaiversFile("./tests/waivers.yaml")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when loading the waivers", err)
+	}
+	if len(waivers.Waivers) != 3 {
+		t.Fatalf("expected 3 waivers, got %d", len(waivers.Waivers))
+	}
+	w := waivers.Waivers[0]
+	want := []VersionConstraint{{Op: ">=", Version: "1.0.0"}, {Op: "<", Version: "2.0.0"}}
+	if !reflect.DeepEqual(w.Constraints(), want) {
+		t.Errorf("constraints = %v, want %v", w.Constraints(), want)
+	}
+	if !w.MatchesPurl("pkg:npm/left-pad") || w.MatchesPurl("pkg:npm/right-pad") {
+		t.Errorf("unexpected purl matching for %v", w.Purl)
+	}
+	if w.IsExpired(time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC)) {
+		t.Errorf("expected the waiver to be valid on its last day")
+	}
+	if !w.IsExpired(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the waiver to expire after its last day")
+	}
+	vendor := waivers.Waivers[1]
+	if !vendor.MatchesPurl("pkg:github/scanoss/engine") || !vendor.MatchesPurl("pkg:github/SCANOSS/engine@1.0.0") {
+		t.Errorf("expected the vendor waiver to match scanoss components")
+	}
+	if vendor.MatchesPurl("pkg:github/other/scanoss") || vendor.MatchesPurl("pkg:npm/scanoss") {
+		t.Errorf("did not expect the vendor waiver to match other vendors")
+	}
+}
+
+func TestParseWaiversInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "no purl or vendor", input: "waivers:\n  - expires: 2026-01-01\n    justification: x\n    owner: y\n"},
+		{name: "no justification", input: "waivers:\n  - purl: pkg:npm/x\n    expires: 2026-01-01\n    owner: y\n"},
+		{name: "no owner", input: "waivers:\n  - purl: pkg:npm/x\n    expires: 2026-01-01\n    justification: x\n"},
+		{name: "no expiry", input: "waivers:\n  - purl: pkg:npm/x\n    justification: x\n    owner: y\n"},
+		{name: "bad expiry", input: "waivers:\n  - purl: pkg:npm/x\n    expires: next year\n    justification: x\n    owner: y\n"},
+		{name: "bad versions", input: "waivers:\n  - purl: pkg:npm/x\n    versions: \"~>1.0\"\n    expires: 2026-01-01\n    justification: x\n    owner: y\n"},
+		{name: "bad status", input: "waivers:\n  - purl: pkg:npm/x\n    status: [unknown]\n    expires: 2026-01-01\n    justification: x\n    owner: y\n"},
+		{name: "unknown field", input: "waivers:\n  - purl: pkg:npm/x\n    expiry: 2026-01-01\n    justification: x\n    owner: y\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseWaivers([]byte(tt.input)); err == nil {
+				t.Errorf("expected an error parsing %q", tt.input)
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraints(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []VersionConstraint
+	}{
+		{input: "1.2.3", want: []VersionConstraint{{Op: "=", Version: "1.2.3"}}},
+		{input: "==v2.0.0", want: []VersionConstraint{{Op: "=", Version: "v2.0.0"}}},
+		{input: ">=1.0 <2.0", want: []VersionConstraint{{Op: ">=", Version: "1.0"}, {Op: "<", Version: "2.0"}}},
+		{input: "> 1.0.0-beta.1,!= 1.4.0", want: []VersionConstraint{{Op: ">", Version: "1.0.0-beta.1"}, {Op: "!=", Version: "1.4.0"}}},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersionConstraints(tt.input)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseVersionConstraints(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+	for _, input := range []string{"", " , ", "~1.0", ">=", "^2"} {
+		if _, err := ParseVersionConstraints(input); err == nil {
+			t.Errorf("expected an error parsing %q", input)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package dtos
+
+// Audit classifications for components that need attention.
+const (
+	AuditRemoved    = "removed"    // The component or version has been removed/unpublished/yanked from its registry
+	AuditDeprecated = "deprecated" // The component or version has been deprecated/archived
+	AuditUnknown    = "unknown"    // The component or version is not known to the knowledge base
+)
+
+// ComponentAuditFinding represents a single component that needs attention.
+type ComponentAuditFinding struct {
+	Purl             string           `json:"purl"`
+	Name             string           `json:"name,omitempty"`
+	Requirement      string           `json:"requirement,omitempty"`
+	Version          string           `json:"version,omitempty"`
+	Classification   string           `json:"classification"`
+	Scope            string           `json:"scope"` // component or version
+	Status           string           `json:"status,omitempty"`
+	RepositoryStatus string           `json:"repository_status,omitempty"`
+	StatusChangeDate string           `json:"status_change_date,omitempty"`
+	Message          string           `json:"message,omitempty"`
+	Severity         string           `json:"severity,omitempty"` // Reporting severity (error, warning or note)
+	Waiver           *ComponentWaiver `json:"waiver,omitempty"`   // Waiver covering this finding (active or expired)
+}
+
+// ComponentWaiver describes the time-boxed exemption applied to an audit finding.
+type ComponentWaiver struct {
+	Purl          string `json:"purl,omitempty"`
+	Vendor        string `json:"vendor,omitempty"`
+	Versions      string `json:"versions,omitempty"`
+	Expires       string `json:"expires"`
+	Justification string `json:"justification"`
+	Owner         string `json:"owner"`
+	Expired       bool   `json:"expired"`
+}
+
+// ComponentsAuditSummary contains the number of components in each audit classification.
+type ComponentsAuditSummary struct {
+	Total          int `json:"total"`
+	Removed        int `json:"removed"`
+	Deprecated     int `json:"deprecated"`
+	Unknown        int `json:"unknown"`
+	Waived         int `json:"waived,omitempty"`          // Findings suppressed by an active waiver
+	ExpiredWaivers int `json:"expired_waivers,omitempty"` // Findings whose waiver has expired
+}
+
+// ComponentsAuditOutput represents the result of auditing the status of a list of components.
+type ComponentsAuditOutput struct {
+	Summary  ComponentsAuditSummary  `json:"summary"`
+	Findings []ComponentAuditFinding `json:"findings"`
+	Waived   []ComponentAuditFinding `json:"waived,omitempty"` // Findings suppressed by an active waiver
+}
+
+// ComponentsWaiversOutput lists the components of a status request covered by a waiver.
+type ComponentsWaiversOutput struct {
+	Waived  []ComponentAuditFinding `json:"waived,omitempty"`  // Findings suppressed by an active waiver
+	Expired []ComponentAuditFinding `json:"expired,omitempty"` // Findings whose waiver has expired
+}
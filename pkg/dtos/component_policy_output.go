@@ -0,0 +1,26 @@
+package dtos
+
+// ComponentPolicyResult represents the policy verdict for a single component.
+type ComponentPolicyResult struct {
+	Purl        string   `json:"purl"`
+	Requirement string   `json:"requirement,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Verdict     string   `json:"verdict"`         // allow, warn or deny
+	Rules       []string `json:"rules,omitempty"` // Names of the rules that matched
+	Reasons     []string `json:"reasons,omitempty"`
+}
+
+// ComponentsPolicySummary contains the number of components with each verdict.
+type ComponentsPolicySummary struct {
+	Allow int `json:"allow"`
+	Warn  int `json:"warn"`
+	Deny  int `json:"deny"`
+}
+
+// ComponentsPolicyOutput represents the result of evaluating a policy against a list of component statuses.
+type ComponentsPolicyOutput struct {
+	Policy     string                  `json:"policy,omitempty"`
+	Verdict    string                  `json:"verdict"` // Overall verdict (the most severe component verdict)
+	Summary    ComponentsPolicySummary `json:"summary"`
+	Components []ComponentPolicyResult `json:"components"`
+}
@@ -0,0 +1,28 @@
+package dtos
+
+// Reasons a candidate version was not chosen when resolving a version requirement.
+const (
+	RejectedOutOfRange = "out of range" // Does not satisfy the requirement
+	RejectedPreRelease = "pre-release"  // Satisfies the range, but pre-releases were not requested
+	RejectedYanked     = "yanked"       // Removed or deleted from its package registry
+	RejectedSuperseded = "superseded"   // Eligible, but a higher version was chosen
+)
+
+// VersionCandidate represents a version of the component considered when resolving a version requirement.
+type VersionCandidate struct {
+	Version  string `json:"version"`
+	Date     string `json:"date,omitempty"`
+	Status   string `json:"status,omitempty"`   // Classified registry status of the version
+	Selected bool   `json:"selected,omitempty"` // The version the requirement resolved to
+	Rejected string `json:"rejected,omitempty"` // Why the version was not chosen
+}
+
+// ComponentResolutionOutput explains how a version requirement resolved to a concrete version of a component.
+type ComponentResolutionOutput struct {
+	Purl              string             `json:"purl"`
+	Requirement       string             `json:"requirement"`
+	Version           string             `json:"version,omitempty"`            // Resolved version (empty if none is eligible)
+	Rule              string             `json:"rule"`                         // Ecosystem rule used to choose the version
+	Candidates        []VersionCandidate `json:"candidates"`                   // Selected version and the nearest others, highest first
+	OmittedCandidates int                `json:"omitted_candidates,omitempty"` // Number of known versions not listed as candidates
+}
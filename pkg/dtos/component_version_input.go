@@ -9,8 +9,13 @@ import (
 )
 
 type ComponentVersionsInput struct {
-	Purl  string `json:"purl"`
-	Limit int    `json:"limit"`
+	Purl        string `json:"purl"`
+	Limit       int    `json:"limit"`
+	Order       string `json:"order,omitempty"`       // date (default, newest release first) or version (highest version first)
+	Requirement string `json:"requirement,omitempty"` // Only list the versions in this range (i.e. ^1.2, ~=3.4 or [1.0,2.0))
+	// Exclude pre-releases (i.e. alpha, beta, rc, experimental or commit hash versions)
+	ExcludePreReleases bool `json:"exclude_pre_releases,omitempty"`
+	ExcludeYanked      bool `json:"exclude_yanked,omitempty"` // Exclude versions whose status maps to removed or deleted
 }
 
 func ExportComponentVersionsInput(s *zap.SugaredLogger, output ComponentVersionsInput) ([]byte, error) {
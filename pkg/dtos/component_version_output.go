@@ -22,9 +22,12 @@ type ComponentOutput struct {
 }
 
 type ComponentVersion struct {
-	Date     string             `json:"date"`
-	Licenses []ComponentLicense `json:"licenses"`
-	Version  string             `json:"version"`
+	Date              string             `json:"date"`
+	Licenses          []ComponentLicense `json:"licenses"`
+	LicenseExpression string             `json:"license_expression,omitempty"` // SPDX expression of the licenses (if derivable)
+	Version           string             `json:"version"`
+	HighestMatch      bool               `json:"highest_match,omitempty"` // Highest version satisfying the requirement
+	Status            string             `json:"status,omitempty"`        // Classified registry status of the version
 }
 
 type ComponentLicense struct {
@@ -0,0 +1,6 @@
+package dtos
+
+// ComponentURLInput requests the purls (and versions) of a repository, registry or download URL.
+type ComponentURLInput struct {
+	URL string `json:"url"`
+}
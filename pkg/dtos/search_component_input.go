@@ -9,12 +9,36 @@ import (
 )
 
 type ComponentSearchInput struct {
-	Search    string `json:"search"`
-	Vendor    string `json:"vendor" `
-	Component string `json:"component"`
-	Package   string `json:"package"`
-	Limit     int    `json:"limit"`
-	Offset    int    `json:"offset"`
+	Search       string `json:"search"`
+	Vendor       string `json:"vendor" `
+	Component    string `json:"component"`
+	Package      string `json:"package"`             // Package (purl) type (defaults to github)
+	AllTypes     bool   `json:"all_types,omitempty"` // Search across every purl type when no package type is supplied
+	Namespace    string `json:"namespace,omitempty"` // List a Maven groupId, npm @scope, Go module path prefix or owner
+	Limit        int    `json:"limit"`
+	Offset       int    `json:"offset"`
+	PerTypeLimit int    `json:"per_type_limit,omitempty"` // Max results per purl type when searching across all types
+	Cursor       string `json:"cursor,omitempty"`         // Opaque cursor (from next_cursor) to request the next page
+	Fuzzy        bool   `json:"fuzzy,omitempty"`          // Typo tolerant search by name similarity
+	Facets       bool   `json:"facets,omitempty"`         // Return the match counts by purl type, license, status and vendor
+	Enrich       bool   `json:"enrich,omitempty"`         // Include the latest version, license, status and popularity of each result
+	Sort         string `json:"sort,omitempty"`           // relevance (default), name, stars, forks, latest_release, first_release or versions
+	Order        string `json:"order,omitempty"`          // asc or desc (defaults to the natural order of the sort key)
+	ComponentSearchFilter
+}
+
+// ComponentSearchFilter restricts the search results using the component metadata. Empty fields are not applied.
+type ComponentSearchFilter struct {
+	Licenses          []string `json:"licenses,omitempty"`            // License names or SPDX identifiers (any of)
+	LicenseIDs        []int64  `json:"license_ids,omitempty"`         // License identifiers (any of)
+	Statuses          []string `json:"statuses,omitempty"`            // Only include these statuses (i.e. active)
+	ExcludeStatuses   []string `json:"exclude_statuses,omitempty"`    // Exclude these statuses (i.e. deleted, unpublished)
+	Verified          bool     `json:"verified,omitempty"`            // Only include verified components
+	MinStars          int64    `json:"min_stars,omitempty"`           // Minimum number of git stars
+	LatestVersionFrom string   `json:"latest_version_from,omitempty"` // Latest version released on or after (YYYY-MM-DD)
+	LatestVersionTo   string   `json:"latest_version_to,omitempty"`   // Latest version released on or before (YYYY-MM-DD)
+	PushedFrom        string   `json:"pushed_from,omitempty"`         // Last git push on or after (YYYY-MM-DD)
+	PushedTo          string   `json:"pushed_to,omitempty"`           // Last git push on or before (YYYY-MM-DD)
 }
 
 func ParseComponentSearchInput(s *zap.SugaredLogger, input []byte) (ComponentSearchInput, error) {
@@ -10,13 +10,51 @@ import (
 
 type ComponentsSearchOutput struct {
 	Components []ComponentSearchOutput `json:"components"`
+	Total      int                     `json:"total,omitempty"`        // Total number of matching components
+	HasMore    bool                    `json:"has_more,omitempty"`     // More results are available after this page
+	NextCursor string                  `json:"next_cursor,omitempty"`  // Opaque cursor to request the next page
+	DidYouMean []string                `json:"did_you_mean,omitempty"` // Closest component names (fuzzy searches only)
+	Facets     *ComponentSearchFacets  `json:"facets,omitempty"`       // Match counts (facet searches only)
+}
+
+// ComponentSearchFacets contains the number of matches for each value of the facet fields (most common first).
+type ComponentSearchFacets struct {
+	PurlType []FacetCount `json:"purl_type"`
+	License  []FacetCount `json:"license"`
+	Status   []FacetCount `json:"status"` // Mapped status
+	Vendor   []FacetCount `json:"vendor"`
+}
+
+// FacetCount is the number of matches with a given facet value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
 }
 
 type ComponentSearchOutput struct {
-	Name      string `json:"name"`      // Deprecated. Component and name fields will contain the same data until
-	Component string `json:"component"` // the component field is removed
-	Purl      string `json:"purl"`
-	URL       string `json:"url"`
+	Name       string                  `json:"name"`      // Deprecated. Component and name fields will contain the same data until
+	Component  string                  `json:"component"` // the component field is removed
+	Purl       string                  `json:"purl"`
+	PurlType   string                  `json:"purl_type,omitempty"`
+	URL        string                  `json:"url"`
+	Version    string                  `json:"version,omitempty"`    // Version matching the URL (URL lookups only)
+	Score      float64                 `json:"score,omitempty"`      // Search relevance score (higher is more relevant)
+	Similarity float64                 `json:"similarity,omitempty"` // Name similarity (0-1) to the search term (fuzzy searches only)
+	Details    *ComponentSearchDetails `json:"details,omitempty"`    // Enriched searches only
+}
+
+// ComponentSearchDetails contains the latest version, license, status and popularity of a search result.
+type ComponentSearchDetails struct {
+	LatestVersion     string `json:"latest_version,omitempty"`
+	LatestVersionDate string `json:"latest_version_date,omitempty"`
+	License           string `json:"license,omitempty"`           // Declared license
+	SpdxID            string `json:"spdx_id,omitempty"`           // SPDX identifier of the declared license
+	Status            string `json:"status,omitempty"`            // Mapped status
+	RepositoryStatus  string `json:"repository_status,omitempty"` // Status as reported by the package repository
+	Stars             int64  `json:"stars,omitempty"`
+	Forks             int64  `json:"forks,omitempty"`
+	Issues            int64  `json:"issues,omitempty"`
+	Versions          int64  `json:"versions,omitempty"` // Number of versions
 }
 
 func ExportComponentSearchOutput(s *zap.SugaredLogger, output ComponentsSearchOutput) ([]byte, error) {
@@ -0,0 +1,11 @@
+package dtos
+
+// ComponentSuggestInput requests the most popular components whose name or purl name starts with a prefix
+// (autocomplete).
+type ComponentSuggestInput struct {
+	Prefix   string `json:"prefix"`
+	Package  string `json:"package"`             // Package (purl) type (defaults to github)
+	AllTypes bool   `json:"all_types,omitempty"` // Suggest across every purl type when no package type is supplied
+	Limit    int    `json:"limit"`               // Number of suggestions (defaults to 10, at most 25)
+	Enrich   bool   `json:"enrich,omitempty"`    // Include the latest version, license, status and popularity of each suggestion
+}
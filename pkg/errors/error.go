@@ -27,6 +27,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"scanoss.com/components/pkg/api"
 )
 
 // ServiceError represents a service-level error with HTTP status mapping and additional context..
@@ -95,7 +96,7 @@ func HandleServiceError(ctx context.Context, s *zap.SugaredLogger, err error) *c
 	if IsServiceError(err) {
 		serviceErr, _ = GetServiceError(err)
 		// Set HTTP trailer based on custom error
-		trailerErr := grpc.SetTrailer(ctx, metadata.Pairs("x-http-code", fmt.Sprintf("%d", serviceErr.GetHTTPCode())))
+		trailerErr := grpc.SetTrailer(ctx, metadata.Pairs(api.HTTPCodeTrailer, fmt.Sprintf("%d", serviceErr.GetHTTPCode())))
 		if trailerErr != nil {
 			s.Debugf("error setting x-http-code to trailer: %v", trailerErr)
 		}
@@ -115,7 +116,7 @@ func HandleServiceError(ctx context.Context, s *zap.SugaredLogger, err error) *c
 	}
 
 	// Default to 500 for unknown errors
-	trailerErr := grpc.SetTrailer(ctx, metadata.Pairs("x-http-code", "500"))
+	trailerErr := grpc.SetTrailer(ctx, metadata.Pairs(api.HTTPCodeTrailer, "500"))
 	if trailerErr != nil {
 		s.Debugf("error setting x-http-code to trailer: %v", trailerErr)
 	}
@@ -21,12 +21,19 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
 	purlhelper "github.com/scanoss/go-purl-helper/pkg"
 	"go.uber.org/zap"
 )
 
+// maxURLPurls is the maximum number of purls (and versions) returned for a URL (see GetPurlsByURL).
+const maxURLPurls = 100
+
+// maxPurlVersions is the maximum number of versions returned for a purl (see GetVersionsByPurlString).
+const maxPurlVersions = 5000
+
 type AllURLsModel struct {
 	ctx context.Context
 	s   *zap.SugaredLogger
@@ -34,15 +41,25 @@ type AllURLsModel struct {
 }
 
 type AllURL struct {
-	Version   string         `db:"version"`
-	Component string         `db:"component"`
-	License   string         `db:"license"`
-	LicenseID string         `db:"license_id"`
-	IsSpdx    bool           `db:"is_spdx"`
-	PurlName  string         `db:"purl_name"`
-	MineID    int32          `db:"mine_id"`
-	Date      sql.NullString `db:"date"`
-	URL       string         `db:"-"`
+	Version       string         `db:"version"`
+	Component     string         `db:"component"`
+	License       string         `db:"license"`
+	LicenseID     string         `db:"license_id"`
+	IsSpdx        bool           `db:"is_spdx"`
+	PurlName      string         `db:"purl_name"`
+	MineID        int32          `db:"mine_id"`
+	Date          sql.NullString `db:"date"`
+	Semver        string         `db:"semver"`         // Normalised semver of the version (empty if unknown)
+	VersionStatus string         `db:"version_status"` // Registry status of the version (i.e. active or yanked)
+	URL           string         `db:"-"`
+}
+
+// URLPurl is the purl (and version) of a package downloaded from a URL.
+type URLPurl struct {
+	PurlType  string `db:"purl_type"`
+	PurlName  string `db:"purl_name"`
+	Component string `db:"component"`
+	Version   string `db:"version"`
 }
 
 func NewAllURLModel(ctx context.Context, s *zap.SugaredLogger, q *database.DBQueryContext) *AllURLsModel {
@@ -90,12 +107,16 @@ func (m *AllURLsModel) GetUrlsByPurlNameType(purlName, purlType string, limit in
 								l.is_spdx      AS is_spdx,
 								purl_name,
 								mine_id,
-								u.date
+								u.date,
+								COALESCE(v.semver, '') AS semver,
+								COALESCE(u.version_status, '') AS version_status
 				FROM all_urls u
 						 LEFT JOIN
 					 mines m ON u.mine_id = m.id
 						 LEFT JOIN
 					 licenses l ON u.license_id = l.id
+						 LEFT JOIN
+					 versions v ON u.version_id = v.id
 				WHERE m.purl_type = $1
 				  AND u.purl_name = $2
 				order BY date DESC NULLS LAST
@@ -110,3 +131,66 @@ func (m *AllURLsModel) GetUrlsByPurlNameType(purlName, purlType string, limit in
 	m.s.Debugf("Found %v results for %v, %v.", len(allUrls), purlType, purlName)
 	return allUrls, nil
 }
+
+// GetVersionsByPurlString returns one row for each version of the supplied purl, with its date, semver and registry
+// status (but no license), most recent first. Only the most recent maxPurlVersions versions are returned.
+func (m *AllURLsModel) GetVersionsByPurlString(purlString string) ([]AllURL, error) {
+	if len(purlString) == 0 {
+		m.s.Errorf("Please specify a valid Purl String to query")
+		return nil, errors.New("please specify a valid Purl String to query")
+	}
+	purl, err := purlhelper.PurlFromString(purlString)
+	if err != nil {
+		return nil, err
+	}
+	purlName, err := purlhelper.PurlNameFromString(purlString) // Make sure we just have the bare minimum for a Purl Name
+	if err != nil {
+		return nil, err
+	}
+	var versions []AllURL
+	err = m.q.SelectContext(m.ctx, &versions,
+		"SELECT u.version, MAX(u.component) AS component, u.purl_name, MAX(u.date) AS date,"+
+			" COALESCE(MAX(v.semver), '') AS semver, COALESCE(MAX(u.version_status), '') AS version_status"+
+			" FROM all_urls u INNER JOIN mines m ON u.mine_id = m.id LEFT JOIN versions v ON u.version_id = v.id"+
+			" WHERE m.purl_type = $1 AND u.purl_name = $2 AND u.version IS NOT NULL AND u.version != ''"+
+			" GROUP BY u.purl_name, u.version ORDER BY MAX(u.date) IS NULL, MAX(u.date) DESC, u.version DESC LIMIT $3",
+		purl.Type, purlName, maxPurlVersions)
+	if err != nil {
+		m.s.Errorf("Failed to query all urls table for %v - %v: %v", purl.Type, purlName, err)
+		return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+	}
+	if len(versions) >= maxPurlVersions {
+		m.s.Warnf("Only the %v most recent versions of %v, %v were returned.", maxPurlVersions, purl.Type, purlName)
+	}
+	m.s.Debugf("Found %v versions for %v, %v.", len(versions), purl.Type, purlName)
+	return versions, nil
+}
+
+// GetPurlsByURL returns the purls and versions of the packages downloaded from the supplied URL
+// (matching both its http and https forms).
+func (m *AllURLsModel) GetPurlsByURL(url string) ([]URLPurl, error) {
+	if len(url) == 0 {
+		m.s.Errorf("Please specify a valid URL to query")
+		return nil, errors.New("please specify a valid URL to query")
+	}
+	alternate := url
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		alternate = "http://" + strings.TrimPrefix(url, "https://")
+	case strings.HasPrefix(url, "http://"):
+		alternate = "https://" + strings.TrimPrefix(url, "http://")
+	}
+	var purls []URLPurl
+	err := m.q.SelectContext(m.ctx, &purls,
+		"SELECT DISTINCT m.purl_type, u.purl_name, COALESCE(u.component, '') AS component, COALESCE(u.version, '') AS version"+
+			" FROM all_urls u INNER JOIN mines m ON u.mine_id = m.id"+
+			" WHERE u.url IN ($1, $2) AND u.purl_name IS NOT NULL"+
+			" ORDER BY m.purl_type, u.purl_name, version LIMIT $3",
+		url, alternate, maxURLPurls)
+	if err != nil {
+		m.s.Errorf("Failed to query all urls table for %v: %v", url, err)
+		return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+	}
+	m.s.Debugf("Found %v purls for %v.", len(purls), url)
+	return purls, nil
+}
@@ -18,6 +18,7 @@ package models
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
@@ -255,3 +256,84 @@ func TestGetUrlsByPurlString(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVersionsByPurlString(t *testing.T) {
+	db, conn, allUrlsModel := setupTest(t)
+	defer cleanup(db, conn)
+
+	versions, err := allUrlsModel.GetVersionsByPurlString("pkg:npm/react")
+	if err != nil {
+		t.Fatalf("GetVersionsByPurlString() error = %v", err)
+	}
+	urls, err := allUrlsModel.GetUrlsByPurlString("pkg:npm/react", 10000)
+	if err != nil {
+		t.Fatalf("GetUrlsByPurlString() error = %v", err)
+	}
+	want := make(map[string]bool)
+	for _, u := range urls {
+		if len(u.Version) > 0 {
+			want[u.Version] = true
+		}
+	}
+	seen := make(map[string]bool)
+	for i, v := range versions {
+		if i > 0 && v.Date.Valid && (!versions[i-1].Date.Valid || versions[i-1].Date.String < v.Date.String) {
+			t.Errorf("GetVersionsByPurlString() version %v is not in date order after %+v", v, versions[i-1])
+		}
+		if seen[v.Version] || !want[v.Version] {
+			t.Errorf("GetVersionsByPurlString() unexpected or duplicate version: %+v", v)
+		}
+		seen[v.Version] = true
+	}
+	if len(seen) != len(want) {
+		t.Errorf("GetVersionsByPurlString() returned %v versions, want %v", len(seen), len(want))
+	}
+	for _, purl := range []string{"", "pkg::pypi"} {
+		if _, err = allUrlsModel.GetVersionsByPurlString(purl); err == nil {
+			t.Errorf("GetVersionsByPurlString(%q) expected an error", purl)
+		}
+	}
+}
+
+func TestGetPurlsByURL(t *testing.T) {
+	db, conn, allUrlsModel := setupTest(t)
+	defer cleanup(db, conn)
+
+	tests := []struct {
+		name    string
+		url     string
+		want    []URLPurl
+		wantErr bool
+	}{
+		{
+			name: "download url",
+			url:  "https://registry.npmjs.org/react/-/react-18.0.0.tgz",
+			want: []URLPurl{{PurlType: "npm", PurlName: "react", Component: "react", Version: "18.0.0"}},
+		},
+		{
+			name: "http download url",
+			url:  "http://rubygems.org/downloads/tablestyle-0.0.12.gem",
+			want: []URLPurl{{PurlType: "gem", PurlName: "tablestyle", Component: "tablestyle", Version: "0.0.12"}},
+		},
+		{
+			name: "unknown url",
+			url:  "https://registry.npmjs.org/react/-/react-0.0.0.tgz",
+		},
+		{
+			name:    "empty url",
+			url:     "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := allUrlsModel.GetPurlsByURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPurlsByURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) && (len(got) > 0 || len(tt.want) > 0) {
+				t.Errorf("GetPurlsByURL() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
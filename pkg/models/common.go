@@ -54,6 +54,12 @@ func LoadTestSQLData(db *sqlx.DB, ctx context.Context, conn *sqlx.Conn) error {
 	return loadTestSQLDataFiles(db, ctx, conn, files)
 }
 
+// LoadTestNamespaceSQLData loads the test projects grouped by namespace (Maven groups and npm scopes), on top of
+// the required test SQL files (see LoadTestSQLData).
+func LoadTestNamespaceSQLData(db *sqlx.DB, ctx context.Context, conn *sqlx.Conn) error {
+	return loadTestSQLDataFiles(db, ctx, conn, []string{"../models/tests/projects_namespace.sql"})
+}
+
 // loadTestSQLDataFiles loads a list of test SQL files.
 func loadTestSQLDataFiles(db *sqlx.DB, ctx context.Context, conn *sqlx.Conn, files []string) error {
 	for _, file := range files {
@@ -66,7 +72,7 @@ func loadTestSQLDataFiles(db *sqlx.DB, ctx context.Context, conn *sqlx.Conn, fil
 }
 
 // sqliteSetup sets up an in-memory SQL Lite DB for testing.
-func sqliteSetup(t *testing.T) *sqlx.DB {
+func sqliteSetup(t testing.TB) *sqlx.DB {
 	db, err := sqlx.Connect("sqlite", ":memory:")
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -75,7 +81,7 @@ func sqliteSetup(t *testing.T) *sqlx.DB {
 }
 
 // sqliteConn sets up a connection to a test DB.
-func sqliteConn(t *testing.T, ctx context.Context, db *sqlx.DB) *sqlx.Conn {
+func sqliteConn(t testing.TB, ctx context.Context, db *sqlx.DB) *sqlx.Conn {
 	conn, err := db.Connx(ctx) // Get a connection from the pool
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
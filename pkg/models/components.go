@@ -18,344 +18,219 @@ package models
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"strings"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
 	"go.uber.org/zap"
 )
 
-var defaultPurlType = "github"
+// DefaultPurlType is the purl type searched when none is supplied.
+var DefaultPurlType = "github"
 var defaultMaxVersionLimit = 50
 var defaultMaxComponentLimit = 50
 var defaultLikeValue = "LIKE"
+var defaultPerTypeLimit = 10
 
 type ComponentModel struct {
 	ctx          context.Context
 	s            *zap.SugaredLogger
 	q            *database.DBQueryContext
 	likeOperator string
+	fullText     *fullTextIndex    // Availability of the full-text search index (shared by the models of a database)
+	suggestions  *suggestionCache  // Recent suggestions (shared by the models of a database)
+	filter       ComponentFilter   // Filter applied to the searches
+	facets       bool              // Count the facets of the search matches
+	sort         ComponentSort     // Order of the search results
+	searchOrder  map[string]string // Configured ORDER BY clause of the purl types (see SetSearchOrder)
 }
 
+// componentSelect is the common select clause for the component queries.
+const componentSelect = "SELECT p.component, p.vendor, COALESCE(p.purl_name, '') AS purl_name, m.purl_type, p.git_stars, p.git_forks, p.versions," +
+	" p.first_version_date, p.latest_version_date, p.license, p.status FROM projects p"
+
 type Component struct {
-	Component string `db:"component"`
-	PurlType  string `db:"purl_type"`
-	PurlName  string `db:"purl_name"`
-	URL       string `db:"-"`
+	Component         string         `db:"component"`
+	Vendor            string         `db:"vendor"`
+	PurlType          string         `db:"purl_type"`
+	PurlName          string         `db:"purl_name"`
+	GitStars          sql.NullInt64  `db:"git_stars"`
+	GitForks          sql.NullInt64  `db:"git_forks"`
+	Versions          sql.NullInt64  `db:"versions"`
+	FirstVersionDate  sql.NullString `db:"first_version_date"`
+	LatestVersionDate sql.NullString `db:"latest_version_date"`
+	License           sql.NullString `db:"license"`
+	Status            sql.NullString `db:"status"`
+	URL               string         `db:"-"`
+	Score             float64        `db:"-"` // Search relevance score (see RankComponents)
+	Similarity        float64        `db:"-"` // Name similarity to the search term (fuzzy searches only)
 }
 
 func NewComponentModel(ctx context.Context, s *zap.SugaredLogger, q *database.DBQueryContext, likeOperator string) *ComponentModel {
 	if len(likeOperator) == 0 {
 		likeOperator = defaultLikeValue
 	}
-	return &ComponentModel{ctx: ctx, s: s, q: q, likeOperator: likeOperator}
+	return &ComponentModel{ctx: ctx, s: s, q: q, likeOperator: likeOperator, fullText: &fullTextIndex{},
+		suggestions: newSuggestionCache(defaultMaxSuggestEntries, defaultSuggestCacheTTL)}
 }
 
-// preProcessQueryJob Replace the clause #ORDER in the queries (if exist) according to the purlType.
-func preProcessQueryJob(qListIn []QueryJob, purlType string) ([]QueryJob, error) {
-	if len(qListIn) == 0 {
-		return []QueryJob{}, errors.New("cannot pre process query jobs empty or with limit less than 0")
-	}
-
-	qList := make([]QueryJob, len(qListIn))
-	copy(qList, qListIn)
-	// order by git_created_at NULLS LAST, git_forks DESC NULLS LAST , git_watchers DESC NULLS FIRST
-	mapPurlTypeToOrderByClause := map[string]string{
-		"github": "ORDER BY git_created_at NULLS LAST , git_forks DESC NULLS LAST, git_stars DESC NULLS LAST",
-		"pypi":   "ORDER BY first_version_date NULLS LAST, versions NULLS LAST",
-		"npm":    "ORDER BY first_version_date NULLS LAST, versions NULLS LAST",
-		"gem":    "ORDER BY first_version_date NULLS LAST, versions NULLS LAST",
-	}
-
-	for i := range qList {
-		// Adds or remove the ORDER BY clause in SQL query
-		qList[i].Query = strings.Replace(qList[i].Query, "#ORDER", mapPurlTypeToOrderByClause[purlType], 1)
-		qList[i].Query = strings.TrimRight(qList[i].Query, " ")
+// SetDB identifies the database queried by the model, so the full-text search index is only detected once per
+// database rather than once per model (see hasFullText), and the suggestions are cached per database.
+func (m *ComponentModel) SetDB(db *sqlx.DB) {
+	index, _ := fullTextIndexes.LoadOrStore(db, &fullTextIndex{})
+	m.fullText = index.(*fullTextIndex)
+	cache, ok := suggestionCaches.Load(db)
+	if !ok {
+		cache, _ = suggestionCaches.LoadOrStore(db, newSuggestionCache(defaultMaxSuggestEntries, defaultSuggestCacheTTL))
 	}
+	m.suggestions = cache.(*suggestionCache)
+}
 
-	return qList, nil
+// isPostgres reports whether the model is querying a PostgreSQL database.
+// ILIKE is only used on PostgreSQL, so the LIKE operator identifies the database.
+func (m *ComponentModel) isPostgres() bool {
+	return strings.EqualFold(m.likeOperator, "ILIKE")
 }
 
-func (m *ComponentModel) GetComponents(search, purlType string, limit, offset int) ([]Component, error) {
+// GetComponents searches for components matching the supplied text by name, vendor or purl name.
+// The results are ranked by relevance (see relevanceScore) and the requested page returned.
+func (m *ComponentModel) GetComponents(search, purlType string, limit, offset int, cursor string) (ComponentPage, error) {
 	m.s.Infof("search parameter: %v", search)
 	if len(search) == 0 {
 		m.s.Error("Please specify a valid Component Name to query")
-		return nil, errors.New("please specify a valid component Name to query")
-	}
-	if limit > defaultMaxComponentLimit || limit <= 0 {
-		limit = defaultMaxComponentLimit
-	}
-	if offset < 0 {
-		offset = 0
+		return ComponentPage{}, errors.New("please specify a valid component Name to query")
 	}
+	limit, offset = searchLimits(limit, offset)
 	if len(purlType) == 0 {
-		purlType = defaultPurlType
-	}
-
-	queryJobs := []QueryJob{
-		{
-			Query: "SELECT p.component, p.purl_name, m.purl_type from projects p" +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.component " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4;",
-			Args: []any{search, purlType, limit, offset},
-		},
-		{
-			Query: "SELECT p.component, p.purl_name, m.purl_type FROM projects p" +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.vendor " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4;",
-			Args: []any{search, purlType, limit, offset},
-		},
-		{
-			Query: "SELECT p.component, p.purl_name, m.purl_type from projects p" +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.purl_name " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{"%" + search + "%" + search + "%", purlType, 1, offset},
-		},
-		{
-			Query: "SELECT p.component, p.purl_name, m.purl_type from projects p" +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.purl_name " + m.likeOperator + " $1" +
-				" AND p.purl_name NOT " + m.likeOperator + " $2" +
-				" AND m.purl_type = $3" +
-				" #ORDER " +
-				" LIMIT $4 OFFSET $5",
-			Args: []any{"%" + search + "%", "%" + search + "%" + search + "%", purlType, 1, offset},
-		},
-		{
-			Query: "SELECT p.component, p.purl_name, m.purl_type from projects p" +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.purl_name " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER " +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{search + "%", purlType, 1, offset},
-		},
-		{
-			Query: "SELECT p.component, p.purl_name, m.purl_type from projects p" +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.purl_name LIKE $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{"%" + search, purlType, 1, offset},
-		},
-	}
-
-	queryJobs, err := preProcessQueryJob(queryJobs, purlType)
-	if err != nil {
-		return []Component{}, err
-	}
-
-	allComponents, _ := RunQueries[Component](m.q, m.ctx, queryJobs)
-	allComponents = RemoveDuplicated[Component](allComponents)
-
-	if limit < len(allComponents) {
-		allComponents = allComponents[:limit]
-	}
-
-	return allComponents, nil
+		purlType = DefaultPurlType
+	}
+	return m.searchComponents(componentSearch{
+		match:    m.textMatch(search),
+		fullText: m.fullTextMatch(search, false),
+		purlType: purlType,
+		name:     search,
+		vendor:   search,
+	}, limit, offset, cursor)
 }
 
-func (m *ComponentModel) GetComponentsByNameType(compName, purlType string, limit, offset int) ([]Component, error) {
+func (m *ComponentModel) GetComponentsByNameType(compName, purlType string, limit, offset int, cursor string) (ComponentPage, error) {
 	if len(compName) == 0 {
 		m.s.Error("Please specify a valid Component Name to query")
-		return []Component{}, errors.New("please specify a valid component Name to query")
-	}
-
-	if limit > defaultMaxComponentLimit || limit <= 0 {
-		limit = defaultMaxComponentLimit
+		return ComponentPage{}, errors.New("please specify a valid component Name to query")
 	}
-
-	if offset < 0 {
-		offset = 0
-	}
-
+	limit, offset = searchLimits(limit, offset)
 	if len(purlType) == 0 {
-		purlType = defaultPurlType
-	}
-
-	queryJobs := []QueryJob{
-		{
-			Query: "SELECT component, purl_name, m.purl_type FROM projects p " +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.component " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{compName, purlType, 1, offset},
-		},
-		{
-			Query: "SELECT component, purl_name, m.purl_type FROM projects p " +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.component " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{"%" + compName + "%", purlType, 1, offset},
-		},
-		{
-			Query: "SELECT component, purl_name, m.purl_type FROM projects p " +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.component " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{compName + "%", purlType, 1, offset},
-		},
-		{
-			Query: "SELECT component, purl_name, m.purl_type FROM projects p " +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.component " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{"%" + compName, purlType, 1, offset},
-		},
-	}
-
-	queryJobs, err := preProcessQueryJob(queryJobs, purlType)
-	if err != nil {
-		return []Component{}, err
-	}
-
-	allComponents, _ := RunQueries[Component](m.q, m.ctx, queryJobs)
-	allComponents = RemoveDuplicated[Component](allComponents)
-
-	if limit < len(allComponents) {
-		allComponents = allComponents[:limit]
-	}
-
-	return allComponents, nil
+		purlType = DefaultPurlType
+	}
+	return m.searchComponents(componentSearch{
+		match:    m.nameMatch(compName),
+		fullText: m.fullTextMatch(compName, true),
+		purlType: purlType,
+		name:     compName,
+	}, limit, offset, cursor)
 }
 
-func (m *ComponentModel) GetComponentsByVendorType(vendorName, purlType string, limit, offset int) ([]Component, error) {
+func (m *ComponentModel) GetComponentsByVendorType(vendorName, purlType string, limit, offset int, cursor string) (ComponentPage, error) {
 	if len(vendorName) == 0 {
 		m.s.Error("Please specify a valid Component Name to query")
-		return []Component{}, errors.New("please specify a valid component Name to query")
-	}
-
-	if limit > defaultMaxComponentLimit || limit <= 0 {
-		limit = defaultMaxComponentLimit
+		return ComponentPage{}, errors.New("please specify a valid component Name to query")
 	}
-
-	if offset < 0 {
-		offset = 0
-	}
-
+	limit, offset = searchLimits(limit, offset)
 	if len(purlType) == 0 {
-		purlType = defaultPurlType
-	}
-
-	queryJobs := []QueryJob{
-		{
-			Query: "SELECT component, purl_name, m.purl_type FROM projects p " +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.vendor = $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{vendorName, purlType, 1, offset},
-		},
-		{
-			Query: "SELECT component, purl_name, m.purl_type FROM projects p " +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.vendor " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{"%" + vendorName + "%", purlType, 1, offset},
-		},
-		{
-			Query: "SELECT component, purl_name, m.purl_type FROM projects p " +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.vendor " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{vendorName + "%", purlType, 1, offset},
-		},
-		{
-			Query: "SELECT component, purl_name, m.purl_type FROM projects p " +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.vendor " + m.likeOperator + " $1" +
-				" AND m.purl_type = $2" +
-				" #ORDER" +
-				" LIMIT $3 OFFSET $4",
-			Args: []any{"%" + vendorName, purlType, 1, offset},
-		},
-	}
-	queryJobs, err := preProcessQueryJob(queryJobs, purlType)
-	if err != nil {
-		return []Component{}, err
-	}
-	allComponents, _ := RunQueries[Component](m.q, m.ctx, queryJobs)
-	allComponents = RemoveDuplicated[Component](allComponents)
-
-	if limit < len(allComponents) {
-		allComponents = allComponents[:limit]
+		purlType = DefaultPurlType
 	}
-	return allComponents, nil
+	return m.searchComponents(componentSearch{
+		match:    m.vendorMatch(vendorName),
+		purlType: purlType,
+		vendor:   vendorName,
+	}, limit, offset, cursor)
 }
 
-func (m *ComponentModel) GetComponentsByNameVendorType(compName, vendor, purlType string, limit, offset int) ([]Component, error) {
+func (m *ComponentModel) GetComponentsByNameVendorType(compName, vendor, purlType string, limit, offset int, cursor string) (ComponentPage, error) {
 	if len(compName) == 0 || len(vendor) == 0 {
 		m.s.Error("Please specify a valid Component Name to query")
-		return []Component{}, errors.New("please specify a valid component Name to query")
+		return ComponentPage{}, errors.New("please specify a valid component Name to query")
 	}
+	limit, offset = searchLimits(limit, offset)
+	if len(purlType) == 0 {
+		purlType = DefaultPurlType
+	}
+	return m.searchComponents(componentSearch{
+		match:    m.nameVendorMatch(compName, vendor),
+		purlType: purlType,
+		name:     compName,
+		vendor:   vendor,
+	}, limit, offset, cursor)
+}
+
+// GetComponentsAllTypes searches for components across every purl type (ecosystem) using free text, a component name
+// and/or vendor. At most perTypeLimit matches (the first in the requested order) are returned for each purl type,
+// and the results are ranked by relevance (see relevanceScore) before the requested page is returned.
+func (m *ComponentModel) GetComponentsAllTypes(search, compName, vendor string, limit, offset, perTypeLimit int, cursor string) (ComponentPage, error) {
+	if len(search) == 0 && len(compName) == 0 && len(vendor) == 0 {
+		m.s.Error("Please specify a valid Component Name to query")
+		return ComponentPage{}, errors.New("please specify a valid component Name to query")
+	}
+	limit, offset = searchLimits(limit, offset)
+	if perTypeLimit <= 0 {
+		perTypeLimit = defaultPerTypeLimit
+	}
+	spec := componentSearch{perTypeLimit: perTypeLimit}
+	switch {
+	case len(search) > 0:
+		spec.match, spec.fullText = m.textMatch(search), m.fullTextMatch(search, false)
+		spec.name, spec.vendor = search, search
+	case len(compName) > 0 && len(vendor) > 0:
+		spec.match = m.nameVendorMatch(compName, vendor)
+		spec.name, spec.vendor = compName, vendor
+	case len(compName) > 0:
+		spec.match, spec.fullText = m.nameMatch(compName), m.fullTextMatch(compName, true)
+		spec.name = compName
+	default:
+		spec.match = m.vendorMatch(vendor)
+		spec.vendor = vendor
+	}
+	return m.searchComponents(spec, limit, offset, cursor)
+}
 
+// searchLimits returns the page size and offset to use for a search, applying the defaults and maximum.
+func searchLimits(limit, offset int) (int, int) {
 	if limit > defaultMaxComponentLimit || limit <= 0 {
 		limit = defaultMaxComponentLimit
 	}
+	return limit, max(offset, 0)
+}
 
-	if offset < 0 {
-		offset = 0
-	}
-
-	if len(purlType) == 0 {
-		purlType = defaultPurlType
+// textMatch returns the match condition of a free text search, by component name, vendor or purl name.
+func (m *ComponentModel) textMatch(search string) func(args *queryArgs) string {
+	return func(args *queryArgs) string {
+		term := args.add(search)
+		return "p.component " + m.likeOperator + " " + term + " OR p.vendor " + m.likeOperator + " " + term +
+			" OR p.purl_name " + m.likeOperator + " " + args.add("%"+search+"%")
 	}
+}
 
-	queryJobs := []QueryJob{
-		{
-			Query: "SELECT component, purl_name, m.purl_type FROM projects p " +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.vendor " + m.likeOperator + " $1 AND p.component " + m.likeOperator + " $2" +
-				" AND m.purl_type = $3" +
-				" #ORDER" +
-				" LIMIT $4 OFFSET $5",
-			Args: []any{vendor, compName, purlType, 1, offset},
-		},
-		{
-			Query: "SELECT component, purl_name, m.purl_type FROM projects p " +
-				" LEFT JOIN mines m ON p.mine_id = m.id" +
-				" WHERE p.vendor " + m.likeOperator + " $1 AND p.component " + m.likeOperator + " $2" +
-				" AND m.purl_type = $3" +
-				" #ORDER" +
-				" LIMIT $4 OFFSET $5",
-			Args: []any{"%" + vendor + "%", "%" + compName + "%", purlType, 1, offset},
-		},
+// nameMatch returns the match condition of the components with a name containing compName.
+func (m *ComponentModel) nameMatch(compName string) func(args *queryArgs) string {
+	return func(args *queryArgs) string {
+		return "p.component " + m.likeOperator + " " + args.add("%"+compName+"%")
 	}
+}
 
-	queryJobs, err := preProcessQueryJob(queryJobs, purlType)
-	if err != nil {
-		return []Component{}, err
+// vendorMatch returns the match condition of the components with a vendor containing vendorName.
+func (m *ComponentModel) vendorMatch(vendorName string) func(args *queryArgs) string {
+	return func(args *queryArgs) string {
+		return "p.vendor " + m.likeOperator + " " + args.add("%"+vendorName+"%")
 	}
+}
 
-	allComponents, _ := RunQueries[Component](m.q, m.ctx, queryJobs)
-	allComponents = RemoveDuplicated[Component](allComponents)
-
-	if limit < len(allComponents) {
-		allComponents = allComponents[:limit]
+// nameVendorMatch returns the match condition of the components with a name containing compName
+// and a vendor containing vendor.
+func (m *ComponentModel) nameVendorMatch(compName, vendor string) func(args *queryArgs) string {
+	return func(args *queryArgs) string {
+		return "p.vendor " + m.likeOperator + " " + args.add("%"+vendor+"%") +
+			" AND p.component " + m.likeOperator + " " + args.add("%"+compName+"%")
 	}
-
-	return allComponents, nil
 }
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	purlhelper "github.com/scanoss/go-purl-helper/pkg"
+)
+
+// ComponentDetails contains the project metadata and released versions of a component, used to enrich search results.
+type ComponentDetails struct {
+	PurlType          string             `db:"purl_type"`
+	PurlName          string             `db:"purl_name"`
+	License           sql.NullString     `db:"license"`
+	SpdxID            sql.NullString     `db:"spdx_id"`
+	Status            sql.NullString     `db:"status"`
+	GitStars          sql.NullInt64      `db:"git_stars"`
+	GitForks          sql.NullInt64      `db:"git_forks"`
+	GitIssues         sql.NullInt64      `db:"git_issues"`
+	Versions          sql.NullInt64      `db:"versions"`
+	LatestVersionDate sql.NullString     `db:"latest_version_date"` // Latest release date of the project (if known)
+	Releases          []ComponentRelease `db:"-"`                   // Released versions (in no particular order)
+}
+
+// ComponentRelease is a released version of a component. The latest version is chosen using the version ordering
+// rules of its ecosystem, which cannot be expressed in SQL.
+type ComponentRelease struct {
+	PurlName string         `db:"purl_name"`
+	Version  string         `db:"version"`
+	Semver   string         `db:"semver"` // Normalised semver of the version (empty if unknown)
+	Date     sql.NullString `db:"date"`   // Release date (if known)
+}
+
+// componentDetailsQuery selects the project details of the components (purl names from $2) of a purl type ($1).
+const componentDetailsQuery = "SELECT m.purl_type, p.purl_name, p.license, l.spdx_id, p.status," +
+	" p.git_stars, p.git_forks, p.git_issues, p.versions, p.latest_version_date" +
+	" FROM projects p" +
+	" INNER JOIN mines m ON p.mine_id = m.id" +
+	" LEFT JOIN licenses l ON p.license_id = l.id" +
+	" WHERE m.purl_type = $1 AND p.purl_name IN (#NAMES)"
+
+// componentReleasesQuery selects the distinct versions of the components (purl names from $2) of a purl type ($1)
+// from all_urls, with their release date.
+const componentReleasesQuery = "SELECT u.purl_name, u.version, COALESCE(MAX(v.semver), '') AS semver, MAX(u.date) AS date" +
+	" FROM all_urls u" +
+	" INNER JOIN mines m ON u.mine_id = m.id" +
+	" LEFT JOIN versions v ON u.version_id = v.id" +
+	" WHERE m.purl_type = $1 AND u.purl_name IN (#NAMES) AND u.version IS NOT NULL AND u.version <> ''" +
+	" GROUP BY u.purl_name, u.version"
+
+// GetComponentDetails retrieves the details and released versions of the supplied components (two queries per purl
+// type), keyed by purl (see ComponentPurl). Components without a project entry are not included.
+func (m *ComponentModel) GetComponentDetails(components []Component) (map[string]ComponentDetails, error) {
+	namesByType := make(map[string][]string)
+	var purlTypes []string
+	for _, c := range components {
+		if _, ok := namesByType[c.PurlType]; !ok {
+			purlTypes = append(purlTypes, c.PurlType)
+		}
+		namesByType[c.PurlType] = append(namesByType[c.PurlType], c.PurlName)
+	}
+	details := make(map[string]ComponentDetails, len(components))
+	for _, purlType := range purlTypes {
+		names := namesByType[purlType]
+		args := []any{purlType}
+		placeholders := make([]string, len(names))
+		for i, name := range names {
+			args = append(args, name)
+			placeholders[i] = "$" + strconv.Itoa(i+2)
+		}
+		var results []ComponentDetails
+		query := strings.ReplaceAll(componentDetailsQuery, "#NAMES", strings.Join(placeholders, ", "))
+		if err := m.q.SelectContext(m.ctx, &results, query, args...); err != nil {
+			m.s.Errorf("Failed to query component details for %v: %v", purlType, err)
+			return nil, err
+		}
+		var releases []ComponentRelease
+		query = strings.ReplaceAll(componentReleasesQuery, "#NAMES", strings.Join(placeholders, ", "))
+		if err := m.q.SelectContext(m.ctx, &releases, query, args...); err != nil {
+			m.s.Errorf("Failed to query component releases for %v: %v", purlType, err)
+			return nil, err
+		}
+		releasesByName := make(map[string][]ComponentRelease)
+		for _, r := range releases {
+			releasesByName[r.PurlName] = append(releasesByName[r.PurlName], r)
+		}
+		for _, d := range results {
+			d.Releases = releasesByName[d.PurlName]
+			details[ComponentPurl(d.PurlType, d.PurlName)] = d
+		}
+	}
+	return details, nil
+}
+
+// ComponentPurl returns the purl (without version) of a purl type and name, as returned by the component searches.
+func ComponentPurl(purlType, purlName string) string {
+	return "pkg:" + purlType + "/" + purlName
+}
+
+// ComponentVersionPurl returns the purl of a version of a component (without version if empty).
+func ComponentVersionPurl(purlType, purlName, version string) string {
+	if len(version) == 0 {
+		return ComponentPurl(purlType, purlName)
+	}
+	return ComponentPurl(purlType, purlName) + "@" + version
+}
+
+// NamespacePurl returns the purl (without version) of a purl type and name listed by a namespace search, with its
+// namespace properly encoded (i.e. pkg:npm/%40babel/core). Other searches keep the unencoded purl (see ComponentPurl).
+func NamespacePurl(purlType, purlName string) string {
+	purlString := ComponentPurl(purlType, purlName)
+	if purl, err := purlhelper.PurlFromString(purlString); err == nil {
+		return purl.ToString()
+	}
+	return purlString
+}
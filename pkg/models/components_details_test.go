@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestGetComponentDetails(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	details, err := component.GetComponentDetails([]Component{
+		{PurlType: "npm", PurlName: "react"},
+		{PurlType: "npm", PurlName: "react-dom"},
+		{PurlType: "gem", PurlName: "tablestyle"},
+		{PurlType: "npm", PurlName: "not-a-component"},
+	})
+	if err != nil {
+		t.Fatalf("GetComponentDetails() error = %v", err)
+	}
+	if len(details) != 3 {
+		t.Fatalf("GetComponentDetails() expected 3 components, got %v", details)
+	}
+	react := details["pkg:npm/react"]
+	if len(react.Releases) != 718 || react.Status.String != "active" || react.License.String != "MIT" ||
+		react.SpdxID.String != "MIT" || react.GitStars.Int64 == 0 {
+		t.Errorf("GetComponentDetails() unexpected react details: %+v", react)
+	}
+	i := slices.IndexFunc(react.Releases, func(r ComponentRelease) bool { return r.Version == "18.0.0" })
+	if i < 0 || react.Releases[i].Date.String != "2022-03-29" || react.Releases[i].PurlName != "react" {
+		t.Errorf("GetComponentDetails() expected the 18.0.0 react release, got %+v", react.Releases)
+	}
+	if dom := details["pkg:npm/react-dom"]; len(dom.Releases) != 671 || dom.GitForks.Int64 != 36701 {
+		t.Errorf("GetComponentDetails() unexpected react-dom details: %+v", dom)
+	}
+	if gem := details["pkg:gem/tablestyle"]; gem.LatestVersionDate.String != "2013-08-26" || gem.GitStars.Valid || gem.Versions.Int64 != 8 {
+		t.Errorf("GetComponentDetails() unexpected tablestyle details: %+v", gem)
+	}
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// Facet settings.
+const (
+	MaxFacetValues    = 20        // Maximum number of values returned for each facet (statuses excepted)
+	UnknownFacetValue = "unknown" // Value used for matches without the facet field
+)
+
+// FacetCount is the number of search matches with a given facet value.
+type FacetCount struct {
+	Value string `db:"value"`
+	Count int    `db:"count"`
+}
+
+// ComponentFacets contains the number of search matches for each value of the facet fields, most common first.
+// The purl types are counted across every type (ignoring the purl type searched), so the other ecosystems with
+// matches can be listed. The statuses are the project statuses, and are all returned so they can be mapped.
+type ComponentFacets struct {
+	PurlType []FacetCount
+	License  []FacetCount
+	Status   []FacetCount
+	Vendor   []FacetCount
+}
+
+// SetFacets sets whether the facets of all subsequent component searches are counted (see ComponentPage.Facets).
+func (m *ComponentModel) SetFacets(enabled bool) {
+	m.facets = enabled
+}
+
+// searchFacets counts the components satisfying the match condition (and filter) by each facet field, grouping
+// them in the database. The counts include every match, regardless of pagination and any per-type cap.
+func (m *ComponentModel) searchFacets(search componentSearch, match func(args *queryArgs) string) (*ComponentFacets, error) {
+	allTypes := search
+	allTypes.purlType = ""
+	var facets ComponentFacets
+	var err error
+	if facets.PurlType, err = m.facetCounts(allTypes, match, "purl_type", MaxFacetValues); err != nil {
+		return nil, err
+	}
+	if facets.License, err = m.facetCounts(search, match, "license", MaxFacetValues); err != nil {
+		return nil, err
+	}
+	if facets.Status, err = m.facetCounts(search, match, "status", 0); err != nil {
+		return nil, err
+	}
+	if facets.Vendor, err = m.facetCounts(search, match, "vendor", MaxFacetValues); err != nil {
+		return nil, err
+	}
+	return &facets, nil
+}
+
+// facetCounts returns the number of matches for the most common values (all of them if limit is 0) of a column.
+func (m *ComponentModel) facetCounts(search componentSearch, match func(args *queryArgs) string, column string, limit int) ([]FacetCount, error) {
+	var args queryArgs
+	matches := m.matchQuery(&args, search, match)
+	value := "COALESCE(NULLIF(TRIM(t." + column + "), ''), " + args.add(UnknownFacetValue) + ")"
+	query := "SELECT " + value + " AS value, COUNT(*) AS count FROM (" + matches + ") t" +
+		" GROUP BY 1 ORDER BY COUNT(*) DESC, 1"
+	if limit > 0 {
+		query += " LIMIT " + args.add(limit)
+	}
+	counts := []FacetCount{}
+	if err := m.q.SelectContext(m.ctx, &counts, query, args...); err != nil {
+		m.s.Errorf("Failed to count the component search %s facet: %v", column, err)
+		return nil, err
+	}
+	return counts, nil
+}
+
+// countFacets counts the components ranked in code (see RankFuzzyComponents) by each facet field, with the purl
+// types counted from the matches of every type.
+func countFacets(matches, allTypes []Component) *ComponentFacets {
+	purlTypes, licenses, statuses, vendors := facetCounter{}, facetCounter{}, facetCounter{}, facetCounter{}
+	for _, c := range allTypes {
+		purlTypes.add(c.PurlType)
+	}
+	for _, c := range matches {
+		licenses.add(c.License.String)
+		statuses.add(c.Status.String)
+		vendors.add(c.Vendor)
+	}
+	return &ComponentFacets{
+		PurlType: purlTypes.counts(MaxFacetValues),
+		License:  licenses.counts(MaxFacetValues),
+		Status:   statuses.counts(0),
+		Vendor:   vendors.counts(MaxFacetValues),
+	}
+}
+
+// facetCounter counts the number of occurrences of each facet value.
+type facetCounter map[string]int
+
+// add counts a value, treating empty values as unknown.
+func (f facetCounter) add(value string) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		value = UnknownFacetValue
+	}
+	f[value]++
+}
+
+// counts returns the most common values (all of them if limit is 0) sorted by descending count and then value.
+func (f facetCounter) counts(limit int) []FacetCount {
+	counts := make([]FacetCount, 0, len(f))
+	for value, count := range f {
+		counts = append(counts, FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestSearchFacets(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	page, err := component.GetComponentsByNameType("re", "npm", 2, 0, "")
+	if err != nil || page.Facets != nil {
+		t.Fatalf("GetComponentsByNameType() expected no facets, got %+v (%v)", page.Facets, err)
+	}
+	component.SetFacets(true)
+	page, err = component.GetComponentsByNameType("re", "npm", 2, 0, "")
+	if err != nil || page.Facets == nil || page.Total != 7 || len(page.Components) != 2 {
+		t.Fatalf("GetComponentsByNameType() expected facets, got %+v (%v)", page, err)
+	}
+	// Every npm match is counted (not just the page), and the purl types ignore the npm filter
+	facets := page.Facets
+	if want := []FacetCount{{Value: "npm", Count: 7}, {Value: "pypi", Count: 2}}; !slices.Equal(facets.PurlType, want) {
+		t.Errorf("purl type facet = %+v, want %+v", facets.PurlType, want)
+	}
+	if want := []FacetCount{{Value: "MIT", Count: 7}}; !slices.Equal(facets.License, want) {
+		t.Errorf("license facet = %+v, want %+v", facets.License, want)
+	}
+	if want := []FacetCount{{Value: UnknownFacetValue, Count: 6}, {Value: "active", Count: 1}}; !slices.Equal(facets.Status, want) {
+		t.Errorf("status facet = %+v, want %+v", facets.Status, want)
+	}
+	if len(facets.Vendor) != 7 || facets.Vendor[0] != (FacetCount{Value: "Ben Newman", Count: 1}) {
+		t.Errorf("vendor facet = %+v", facets.Vendor)
+	}
+	// Fuzzy matches are counted in code
+	page, err = component.GetComponentsFuzzy("raect", "npm", 0, 0, "")
+	if err != nil || page.Facets == nil {
+		t.Fatalf("GetComponentsFuzzy() expected facets, got %+v (%v)", page.Facets, err)
+	}
+	if !slices.Contains(page.Facets.PurlType, FacetCount{Value: "npm", Count: page.Total}) {
+		t.Errorf("GetComponentsFuzzy() unexpected purl type facet: %+v (total %d)", page.Facets.PurlType, page.Total)
+	}
+}
+
+func TestFacetCounter(t *testing.T) {
+	counter := facetCounter{}
+	for _, value := range []string{"b", " a ", "", "b", "c"} {
+		counter.add(value)
+	}
+	want := []FacetCount{{Value: "b", Count: 2}, {Value: "a", Count: 1}, {Value: "c", Count: 1}, {Value: UnknownFacetValue, Count: 1}}
+	if got := counter.counts(0); !slices.Equal(got, want) {
+		t.Errorf("counts() = %+v, want %+v", got, want)
+	}
+	if got := counter.counts(1); !slices.Equal(got, want[:1]) {
+		t.Errorf("counts(1) = %+v, want %+v", got, want[:1])
+	}
+}
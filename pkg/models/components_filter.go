@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ComponentFilter restricts the component search results using the projects metadata. Zero values are not applied.
+type ComponentFilter struct {
+	Licenses          []string  // License names or SPDX identifiers (any of)
+	LicenseIDs        []int64   // License identifiers (any of)
+	Statuses          []string  // Only include components with one of these statuses
+	ExcludeStatuses   []string  // Exclude components with any of these statuses (i.e. deleted or unpublished)
+	Verified          bool      // Only include verified components
+	MinStars          int64     // Minimum number of git stars
+	LatestVersionFrom time.Time // Latest version released on or after this day
+	LatestVersionTo   time.Time // Latest version released on or before this day
+	PushedFrom        time.Time // Last git push on or after this day
+	PushedTo          time.Time // Last git push on or before this day
+}
+
+// SetFilter sets the filter applied to all subsequent component searches.
+func (m *ComponentModel) SetFilter(filter ComponentFilter) {
+	m.filter = filter
+}
+
+// applyFilter replaces the #FILTER clause in the queries with the conditions of the current filter (if any).
+// The filter arguments are appended to those of each query.
+func (m *ComponentModel) applyFilter(queryJobs []QueryJob) []QueryJob {
+	qList := make([]QueryJob, len(queryJobs))
+	for i, job := range queryJobs {
+		where, args := m.filter.condition(m.likeOperator, len(job.Args)+1)
+		qList[i] = QueryJob{
+			Query: strings.Replace(job.Query, "#FILTER", where, 1),
+			Args:  append(append([]any{}, job.Args...), args...),
+		}
+	}
+	return qList
+}
+
+// condition returns the filter as an SQL condition (starting with AND) using numbered arguments from firstArg.
+func (f ComponentFilter) condition(likeOperator string, firstArg int) (string, []any) {
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(firstArg+len(args)-1)
+	}
+	if len(f.Licenses) > 0 {
+		var licenses []string
+		for _, license := range f.Licenses {
+			n := arg(license)
+			licenses = append(licenses, "p.license "+likeOperator+" "+n+
+				" OR p.license_id IN (SELECT id FROM licenses WHERE spdx_id "+likeOperator+" "+n+" OR license_name "+likeOperator+" "+n+")")
+		}
+		conditions = append(conditions, "("+strings.Join(licenses, " OR ")+")")
+	}
+	if len(f.LicenseIDs) > 0 {
+		var ids []string
+		for _, id := range f.LicenseIDs {
+			ids = append(ids, arg(id))
+		}
+		conditions = append(conditions, "p.license_id IN ("+strings.Join(ids, ", ")+")")
+	}
+	if len(f.Statuses) > 0 {
+		var statuses []string
+		for _, status := range f.Statuses {
+			statuses = append(statuses, arg(strings.ToLower(status)))
+		}
+		conditions = append(conditions, "lower(p.status) IN ("+strings.Join(statuses, ", ")+")")
+	}
+	if len(f.ExcludeStatuses) > 0 {
+		var statuses []string
+		for _, status := range f.ExcludeStatuses {
+			statuses = append(statuses, arg(strings.ToLower(status)))
+		}
+		conditions = append(conditions, "(p.status IS NULL OR lower(p.status) NOT IN ("+strings.Join(statuses, ", ")+"))")
+	}
+	if f.Verified {
+		conditions = append(conditions, "p.verified IS NOT NULL")
+	}
+	if f.MinStars > 0 {
+		conditions = append(conditions, "p.git_stars >= "+arg(f.MinStars))
+	}
+	// Dates are compared as days, so the end of a range is exclusive of the following day
+	if !f.LatestVersionFrom.IsZero() {
+		conditions = append(conditions, "p.latest_version_date >= "+arg(f.LatestVersionFrom.Format(time.DateOnly)))
+	}
+	if !f.LatestVersionTo.IsZero() {
+		conditions = append(conditions, "p.latest_version_date < "+arg(f.LatestVersionTo.AddDate(0, 0, 1).Format(time.DateOnly)))
+	}
+	if !f.PushedFrom.IsZero() {
+		conditions = append(conditions, "p.git_pushed_at >= "+arg(f.PushedFrom.Format(time.DateOnly)))
+	}
+	if !f.PushedTo.IsZero() {
+		conditions = append(conditions, "p.git_pushed_at < "+arg(f.PushedTo.AddDate(0, 0, 1).Format(time.DateOnly)))
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "AND " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestComponentFilterCondition(t *testing.T) {
+	if where, args := (ComponentFilter{}).condition("LIKE", 3); len(where) > 0 || len(args) > 0 {
+		t.Errorf("expected no condition for an empty filter, got %v %v", where, args)
+	}
+	filter := ComponentFilter{
+		LicenseIDs:      []int64{1, 2},
+		ExcludeStatuses: []string{"Deleted"},
+		MinStars:        10,
+		PushedTo:        time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+	where, args := filter.condition("ILIKE", 3)
+	want := "AND p.license_id IN ($3, $4) AND (p.status IS NULL OR lower(p.status) NOT IN ($5)) AND p.git_stars >= $6 AND p.git_pushed_at < $7"
+	if where != want {
+		t.Errorf("condition() = %v, want %v", where, want)
+	}
+	if len(args) != 5 || args[2] != "deleted" || args[4] != "2025-01-01" {
+		t.Errorf("condition() unexpected args: %v", args)
+	}
+}
+
+func TestGetComponentsFiltered(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	day := func(value string) time.Time {
+		d, _ := time.Parse(time.DateOnly, value)
+		return d
+	}
+	tests := []struct {
+		name   string
+		filter ComponentFilter
+		want   []string
+	}{
+		{name: "none", filter: ComponentFilter{},
+			want: []string{"react", "react-checkbox-tree", "react-dom", "react-router-dom", "react-split-pane", "react-syntax-highlighter"}},
+		{name: "min stars", filter: ComponentFilter{MinStars: 10000}, want: []string{"react", "react-dom", "react-router-dom"}},
+		{name: "status", filter: ComponentFilter{Statuses: []string{"Active"}}, want: []string{"react"}},
+		{name: "exclude status", filter: ComponentFilter{ExcludeStatuses: []string{"active"}, MinStars: 10000},
+			want: []string{"react-dom", "react-router-dom"}},
+		{name: "license", filter: ComponentFilter{Licenses: []string{"mit"}, MinStars: 10000}, want: []string{"react", "react-dom", "react-router-dom"}},
+		{name: "license not found", filter: ComponentFilter{Licenses: []string{"Apache-2.0", "GPL-2.0-only"}}},
+		{name: "license id", filter: ComponentFilter{LicenseIDs: []int64{5614}, MinStars: 10000}, want: []string{"react", "react-dom", "react-router-dom"}},
+		{name: "verified", filter: ComponentFilter{Verified: true, MinStars: 10000}, want: []string{"react", "react-dom", "react-router-dom"}},
+		{name: "latest version range", filter: ComponentFilter{LatestVersionFrom: day("2021-12-17"), LatestVersionTo: day("2021-12-24")},
+			want: []string{"react-dom", "react-router-dom"}},
+		{name: "pushed before", filter: ComponentFilter{PushedTo: day("2021-08-11")}, want: []string{"react-router-dom"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+			component.SetFilter(tt.filter)
+			page, err := component.GetComponentsByNameType("react", "npm", 0, 0, "")
+			if err != nil {
+				t.Fatalf("components.GetComponentsByNameType() error = %v", err)
+			}
+			var got []string
+			for _, c := range page.Components {
+				got = append(got, c.Component)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("GetComponentsByNameType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	// The filter applies to cross-ecosystem and fuzzy searches too
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	component.SetFilter(ComponentFilter{MinStars: 100000})
+	page, err := component.GetComponentsAllTypes("", "react", "", 0, 0, 0, "")
+	if err != nil {
+		t.Fatalf("components.GetComponentsAllTypes() error = %v", err)
+	}
+	for _, c := range page.Components {
+		if c.GitStars.Int64 < 100000 {
+			t.Errorf("GetComponentsAllTypes() returned %v with %v stars", c.PurlName, c.GitStars.Int64)
+		}
+	}
+	page, err = component.GetComponentsFuzzy("raect", "npm", 0, 0, "")
+	if err != nil || page.Total != 1 || page.Components[0].Component != "react" {
+		t.Errorf("GetComponentsFuzzy() expected only react, got %+v (%v)", page.Components, err)
+	}
+}
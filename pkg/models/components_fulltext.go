@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Full-text index objects. PostgreSQL uses a weighted tsvector column on projects (component A, vendor B and
+// purl name C), while SQLite uses an FTS5 table over the component, vendor and purl_name columns of projects.
+const (
+	pgSearchVectorColumn = "search_vector"
+	sqliteFTSTable       = "projects_fts"
+)
+
+// fullTextIndex records whether the full-text search index is available. Only a successful check is recorded, so
+// the index is checked again after a failure.
+type fullTextIndex struct {
+	mu        sync.Mutex
+	checked   bool
+	available bool
+}
+
+// fullTextIndexes holds the full-text index availability of each database (see SetDB).
+var fullTextIndexes sync.Map
+
+// hasFullText reports whether the full-text search index is available. The check is done once per database
+// (see SetDB), or once per model if the database is not known, and is retried if it fails.
+func (m *ComponentModel) hasFullText() bool {
+	m.fullText.mu.Lock()
+	defer m.fullText.mu.Unlock()
+	if m.fullText.checked {
+		return m.fullText.available
+	}
+	var query string
+	if m.isPostgres() {
+		query = "SELECT attname FROM pg_attribute WHERE attrelid = to_regclass('projects')" +
+			" AND attname = '" + pgSearchVectorColumn + "' AND NOT attisdropped"
+	} else {
+		query = "SELECT name FROM sqlite_master WHERE type = 'table' AND name = '" + sqliteFTSTable + "'"
+	}
+	var names []string
+	if err := m.q.SelectContext(m.ctx, &names, query); err != nil {
+		m.s.Debugf("Failed to check for the full-text search index: %v", err)
+		return false
+	}
+	m.fullText.checked = true
+	m.fullText.available = len(names) > 0
+	if m.fullText.available {
+		m.s.Debugf("Using the full-text search index")
+	}
+	return m.fullText.available
+}
+
+// fullTextMatch returns the condition matching the search term against the full-text index, restricted to the
+// component name if requested. Every word of the term must match the start of a word in the index.
+// The condition replaces the wildcard match of the search, which is only used if it finds nothing (see searchComponents).
+// Nil is returned if the index is not available or the term has no words.
+func (m *ComponentModel) fullTextMatch(term string, nameOnly bool) func(args *queryArgs) string {
+	words := fullTextWords(term)
+	if len(words) == 0 || !m.hasFullText() {
+		return nil
+	}
+	if m.isPostgres() {
+		weight := ""
+		if nameOnly {
+			weight = "A"
+		}
+		for i, w := range words {
+			words[i] = w + ":*" + weight
+		}
+		return func(args *queryArgs) string {
+			return "p." + pgSearchVectorColumn + " @@ to_tsquery('simple', " + args.add(strings.Join(words, " & ")) + ")"
+		}
+	}
+	for i, w := range words {
+		words[i] = `"` + w + `"*`
+	}
+	match := strings.Join(words, " AND ")
+	if nameOnly {
+		match = "component : (" + match + ")"
+	}
+	return func(args *queryArgs) string {
+		return "p.rowid IN (SELECT rowid FROM " + sqliteFTSTable + " WHERE " + sqliteFTSTable + " MATCH " + args.add(match) + ")"
+	}
+}
+
+// fullTextWords splits the search term into lowercase words, the same way the index tokenizers do
+// (any character other than a letter or digit is a separator).
+func fullTextWords(term string) []string {
+	return strings.FieldsFunc(strings.ToLower(term), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
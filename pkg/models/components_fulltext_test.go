@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+// checkedIndex returns a full-text index that has already been checked.
+func checkedIndex(available bool) *fullTextIndex {
+	return &fullTextIndex{checked: true, available: available}
+}
+
+func TestFullTextMatch(t *testing.T) {
+	condition := func(m *ComponentModel, term string, nameOnly bool) (string, []any) {
+		match := m.fullTextMatch(term, nameOnly)
+		if match == nil {
+			return "", nil
+		}
+		var args queryArgs
+		return match(&args), args
+	}
+	pg := &ComponentModel{likeOperator: "ILIKE", fullText: checkedIndex(true)}
+	where, args := condition(pg, "React-DOM", false)
+	if where != "p.search_vector @@ to_tsquery('simple', $1)" || len(args) != 1 || args[0] != "react:* & dom:*" {
+		t.Errorf("unexpected PostgreSQL condition: %v (%v)", where, args)
+	}
+	if _, args = condition(pg, "angular", true); len(args) != 1 || args[0] != "angular:*A" {
+		t.Errorf("unexpected PostgreSQL name condition: %v", args)
+	}
+	sqlite := &ComponentModel{likeOperator: "LIKE", fullText: checkedIndex(true)}
+	if _, args = condition(sqlite, "chart.js", true); len(args) != 1 || args[0] != `component : ("chart"* AND "js"*)` {
+		t.Errorf("unexpected SQLite name condition: %v", args)
+	}
+	if where, _ = condition(sqlite, `"*" ()`, false); len(where) > 0 {
+		t.Errorf("expected no condition for a term without words, got %v", where)
+	}
+	none := &ComponentModel{likeOperator: "LIKE", fullText: checkedIndex(false)}
+	if where, _ = condition(none, "angular", false); len(where) > 0 {
+		t.Errorf("expected no condition without a full-text index, got %v", where)
+	}
+}
+
+func TestGetComponentsFullText(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	q := database.NewDBSelectContext(s, db, conn, false)
+	shared := NewComponentModel(ctx, s, q, database.GetLikeOperator(db))
+	shared.SetDB(db)
+	if shared.hasFullText() {
+		t.Errorf("expected no full-text index before it is created")
+	}
+	// Words are matched individually, so "react dom" matches react-dom (which a wildcard search would not)
+	component := NewComponentModel(ctx, s, q, database.GetLikeOperator(db))
+	if page, _ := component.GetComponents("react dom", "npm", 10, 0, ""); page.Total != 0 {
+		t.Errorf("expected no wildcard matches for 'react dom', got %+v", page.Components)
+	}
+	if err = loadTestSQLDataFiles(db, ctx, conn, []string{"../models/tests/projects_fts.sql"}); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	// The detection is done once per database, so only a model of a new database (or without one) sees the index
+	shared = NewComponentModel(ctx, s, q, database.GetLikeOperator(db))
+	shared.SetDB(db)
+	if shared.hasFullText() {
+		t.Errorf("expected the full-text index detection to be shared by the models of a database")
+	}
+	component = NewComponentModel(ctx, s, q, database.GetLikeOperator(db))
+	if !component.hasFullText() {
+		t.Fatalf("expected the full-text index to be detected")
+	}
+	page, err := component.GetComponents("react dom", "npm", 10, 0, "")
+	if err != nil || page.Total == 0 || page.Components[0].PurlName != "react-dom" {
+		t.Errorf("GetComponents() expected react-dom first, got %+v (%v)", page.Components, err)
+	}
+	page, err = component.GetComponents("angular", "github", 10, 0, "")
+	if err != nil || page.Total == 0 || page.Components[0].PurlName != "angular/angular" {
+		t.Errorf("GetComponents() expected angular/angular first, got %+v (%v)", page.Components, err)
+	}
+	page, err = component.GetComponentsByNameType("react", "npm", 10, 0, "")
+	if err != nil || page.Total == 0 {
+		t.Fatalf("GetComponentsByNameType() expected matches, got %+v (%v)", page, err)
+	}
+	for _, c := range page.Components {
+		if len(fullTextWords(c.Component)) == 0 || fullTextWords(c.Component)[0] != "react" {
+			t.Errorf("GetComponentsByNameType() unexpected match: %v", c.Component)
+		}
+	}
+	// Substrings (which are not the start of a word) still match when the full-text index finds nothing,
+	// up to a maximum number of matches
+	page, err = component.GetComponentsByNameType("eact", "npm", 10, 0, "")
+	if err != nil || page.Total < 2 {
+		t.Errorf("GetComponentsByNameType() expected substring matches, got %+v (%v)", page, err)
+	}
+	defer func(max int) { defaultMaxWildcardFallback = max }(defaultMaxWildcardFallback)
+	defaultMaxWildcardFallback = 1
+	capped, err := component.GetComponentsByNameType("eact", "npm", 10, 0, "")
+	if err != nil || capped.Total != 1 || len(capped.Components) != 1 || capped.Components[0].PurlName != page.Components[0].PurlName {
+		t.Errorf("GetComponentsByNameType() expected the first substring match only, got %+v (%v)", capped, err)
+	}
+	page, err = component.GetComponentsAllTypes("", "react", "", 10, 0, 5, "")
+	if err != nil || page.Total == 0 || page.Components[0].Component != "react" {
+		t.Errorf("GetComponentsAllTypes() expected react first, got %+v (%v)", page.Components, err)
+	}
+}
+
+func TestGetComponentsFullTextFallback(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	// A table with the index name, that cannot be searched, falls back to the wildcard queries
+	if _, err = conn.ExecContext(ctx, "CREATE TABLE projects_fts (component text)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	page, err := component.GetComponents("angular", "github", 10, 0, "")
+	if err != nil || page.Total == 0 || page.Components[0].PurlName != "angular/angular" {
+		t.Errorf("GetComponents() expected angular/angular first, got %+v (%v)", page.Components, err)
+	}
+}
+
+func TestHasFullTextRetry(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = loadTestSQLDataFiles(db, ctx, conn, []string{"../models/tests/projects.sql", "../models/tests/projects_fts.sql"}); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	// A failed check is not recorded, so the models of the database check again
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	failing := NewComponentModel(cancelled, s, database.NewDBSelectContext(s, db, nil, false), database.GetLikeOperator(db))
+	failing.SetDB(db)
+	if failing.hasFullText() {
+		t.Errorf("expected the full-text index check to fail")
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	component.SetDB(db)
+	if !component.hasFullText() {
+		t.Errorf("expected the full-text index to be detected after a failed check")
+	}
+}
@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Fuzzy search settings.
+var (
+	defaultFuzzyThreshold     = 0.4  // Minimum similarity (0-1) for a component to be a fuzzy match
+	defaultMaxFuzzyCandidates = 2000 // Maximum number of candidates to compare
+	fuzzyLengthTolerance      = 3    // Maximum difference in name length for the portable (non pg_trgm) candidates
+	scoreSimilarityWeight     = 100.0
+)
+
+// GetComponentsFuzzy searches for components whose name is similar to the supplied term (i.e. "reqests" or "lodsh"),
+// tolerating typos. On PostgreSQL the candidates are selected using the pg_trgm similarity operator, otherwise
+// (or if pg_trgm is not installed) a portable trigram overlap pre-filter is used. Every candidate is scored by its
+// similarity (trigram or edit distance, whichever is higher) and popularity, and the requested page returned.
+// An empty purl type searches across all types.
+func (m *ComponentModel) GetComponentsFuzzy(term, purlType string, limit, offset int, cursor string) (ComponentPage, error) {
+	term = strings.TrimSpace(term)
+	if len(term) == 0 {
+		m.s.Error("Please specify a valid Component Name to query")
+		return ComponentPage{}, errors.New("please specify a valid component Name to query")
+	}
+	if limit > defaultMaxComponentLimit || limit <= 0 {
+		limit = defaultMaxComponentLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	matches, err := m.fuzzyMatches(term, purlType)
+	if err != nil {
+		return ComponentPage{}, err
+	}
+	page, err := m.paginate(matches, limit, offset, cursor)
+	if err != nil || !m.facets {
+		return page, err
+	}
+	allTypes := matches
+	if len(purlType) > 0 {
+		// The purl types are counted from the matches of every type (the results are unchanged)
+		if allTypes, err = m.fuzzyMatches(term, ""); err != nil {
+			return ComponentPage{}, err
+		}
+	}
+	page.Facets = countFacets(matches, allTypes)
+	return page, nil
+}
+
+// fuzzyMatches returns the candidates of the purl type (every type if empty) similar enough to the term, ranked by
+// similarity and popularity.
+func (m *ComponentModel) fuzzyMatches(term, purlType string) ([]Component, error) {
+	var candidates []Component
+	var err error
+	if m.isPostgres() {
+		candidates, err = m.getTrigramCandidates(term, purlType)
+		if err != nil {
+			m.s.Warnf("Trigram search failed (is pg_trgm installed?), using the portable fuzzy search: %v", err)
+		}
+	}
+	if !m.isPostgres() || err != nil {
+		candidates, err = m.getFuzzyCandidates(term, purlType)
+		if err != nil {
+			m.s.Errorf("Failed to search for fuzzy component matches: %v", err)
+			return nil, err
+		}
+	}
+	return RankFuzzyComponents(RemoveDuplicated[Component](candidates), term), nil
+}
+
+// getTrigramCandidates uses the pg_trgm similarity operator (%) to find components with a similar name.
+func (m *ComponentModel) getTrigramCandidates(term, purlType string) ([]Component, error) {
+	query := componentSelect +
+		" INNER JOIN mines m ON p.mine_id = m.id" +
+		" WHERE p.component % $1"
+	args := []any{strings.ToLower(term)}
+	if len(purlType) > 0 {
+		query += " AND m.purl_type = $2"
+		args = append(args, purlType)
+	} else {
+		query += " AND m.purl_type <> ''"
+	}
+	query += " #FILTER ORDER BY similarity(p.component, $1) DESC LIMIT $" + strconv.Itoa(len(args)+1)
+	args = append(args, defaultMaxFuzzyCandidates)
+	return RunQueries[Component](m.q, m.ctx, m.applyFilter([]QueryJob{{Query: query, Args: args}}))
+}
+
+// getFuzzyCandidates selects the components with a similar name length that share the most trigrams with the term,
+// to be compared in code. Each trigram of the term is matched with a LIKE pattern (anchored for the padded trigrams at
+// the start and end of a word), so the candidates do not depend on pg_trgm or the popularity of the component.
+func (m *ComponentModel) getFuzzyCandidates(term, purlType string) ([]Component, error) {
+	length := len([]rune(term))
+	var args queryArgs
+	overlap := trigramOverlap(&args, strings.ToLower(term))
+	query := componentSelect +
+		" INNER JOIN mines m ON p.mine_id = m.id" +
+		" WHERE length(p.component) BETWEEN " + args.add(max(1, length-fuzzyLengthTolerance)) + " AND " + args.add(length+fuzzyLengthTolerance) +
+		" AND " + overlap + " > 0"
+	if len(purlType) > 0 {
+		query += " AND m.purl_type = " + args.add(purlType)
+	} else {
+		query += " AND m.purl_type <> ''"
+	}
+	query += " #FILTER ORDER BY " + overlap + " DESC, abs(length(p.component) - " + args.add(length) + ")," +
+		" p.git_stars DESC NULLS LAST, p.purl_name LIMIT " + args.add(defaultMaxFuzzyCandidates)
+	return RunQueries[Component](m.q, m.ctx, m.applyFilter([]QueryJob{{Query: query, Args: args}}))
+}
+
+// trigramOverlap returns the SQL expression counting the trigrams of the term (see trigrams) found in the
+// component name.
+func trigramOverlap(args *queryArgs, term string) string {
+	set := trigrams(term)
+	parts := make([]string, 0, len(set))
+	for _, t := range slices.Sorted(maps.Keys(set)) {
+		pattern := escapeLike(strings.TrimSpace(t))
+		if !strings.HasPrefix(t, " ") {
+			pattern = "%" + pattern
+		}
+		if !strings.HasSuffix(t, " ") {
+			pattern += "%"
+		}
+		parts = append(parts, "CASE WHEN lower(p.component) LIKE "+args.add(pattern)+` ESCAPE '\' THEN 1 ELSE 0 END`)
+	}
+	if len(parts) == 0 {
+		return "0"
+	}
+	return "(" + strings.Join(parts, " + ") + ")"
+}
+
+// RankFuzzyComponents scores the candidates by their name similarity to the term and their popularity,
+// returning only those at or above the similarity threshold, sorted by descending score.
+func RankFuzzyComponents(candidates []Component, term string) []Component {
+	term = strings.ToLower(strings.TrimSpace(term))
+	matches := make([]Component, 0, len(candidates))
+	for _, c := range candidates {
+		similarity := NameSimilarity(strings.ToLower(c.Component), term)
+		if similarity < defaultFuzzyThreshold {
+			continue
+		}
+		c.Similarity = math.Round(similarity*100) / 100
+		c.Score = math.Round((similarity*scoreSimilarityWeight+popularityScore(c))*100) / 100
+		matches = append(matches, c)
+	}
+	sortComponents(matches)
+	return matches
+}
+
+// NameSimilarity returns the similarity (0-1) of two names, using the higher of their
+// trigram similarity (as calculated by pg_trgm) and normalised edit distance.
+func NameSimilarity(a, b string) float64 {
+	return math.Max(trigramSimilarity(a, b), editSimilarity(a, b))
+}
+
+// trigramSimilarity returns the number of shared trigrams divided by the number of distinct trigrams in both strings.
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	var shared int
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+// trigrams returns the set of trigrams in a string. Like pg_trgm, each word is padded with two spaces
+// at the start and one at the end, and non-alphanumeric characters separate words.
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, word := range fullTextWords(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+// editSimilarity returns 1 minus the Levenshtein distance divided by the length of the longer string.
+func editSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein returns the minimum number of single character edits required to change a into b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{a: "requests", b: "requests", min: 1, max: 1},
+		{a: "requests", b: "reqests", min: 0.85, max: 0.9},
+		{a: "lodash", b: "lodsh", min: 0.8, max: 0.85},
+		{a: "react", b: "raect", min: 0.55, max: 0.65},
+		{a: "angular", b: "protobuf", min: 0, max: 0.2},
+		{a: "", b: "react", min: 0, max: 0},
+	}
+	for _, tt := range tests {
+		if got := NameSimilarity(tt.a, tt.b); got < tt.min || got > tt.max {
+			t.Errorf("NameSimilarity(%q, %q) = %v, want [%v, %v]", tt.a, tt.b, got, tt.min, tt.max)
+		}
+	}
+	// Matches the pg_trgm similarity: 4 shared trigrams out of 9 distinct
+	if got := trigramSimilarity("lodash", "lodsh"); math.Abs(got-4.0/9.0) > 0.0001 {
+		t.Errorf("trigramSimilarity() = %v, want %v", got, 4.0/9.0)
+	}
+	if got := levenshtein([]rune("kitten"), []rune("sitting")); got != 3 {
+		t.Errorf("levenshtein() = %v, want 3", got)
+	}
+}
+
+func TestGetComponentsFuzzy(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	tests := []struct {
+		term, purlType, want string
+	}{
+		{term: "reqests", purlType: "pypi", want: "requests"},
+		{term: "reqests", purlType: "", want: "requests"},
+		{term: "raect", purlType: "npm", want: "react"},
+		{term: "angulr", purlType: "github", want: "angular"},
+	}
+	for _, tt := range tests {
+		page, err := component.GetComponentsFuzzy(tt.term, tt.purlType, 5, 0, "")
+		if err != nil {
+			t.Fatalf("components.GetComponentsFuzzy() error = %v", err)
+		}
+		if len(page.Components) == 0 || page.Components[0].Component != tt.want {
+			t.Errorf("GetComponentsFuzzy(%q, %q) expected %v first, got %+v", tt.term, tt.purlType, tt.want, page.Components)
+			continue
+		}
+		for i, c := range page.Components {
+			if c.Similarity < defaultFuzzyThreshold {
+				t.Errorf("%v returned with a similarity below the threshold: %v", c.PurlName, c.Similarity)
+			}
+			if i > 0 && c.Score > page.Components[i-1].Score {
+				t.Errorf("results not sorted by score: %+v", page.Components)
+			}
+		}
+	}
+	// Popularity orders equally similar matches (angular/angular is the most starred)
+	page, _ := component.GetComponentsFuzzy("angulr", "github", 5, 0, "")
+	if len(page.Components) > 0 && page.Components[0].PurlName != "angular/angular" {
+		t.Errorf("expected angular/angular to be the top match, got %v", page.Components[0].PurlName)
+	}
+	// The portable candidates are the closest names rather than the most popular ones, so a component without any
+	// stars is still found when the candidate limit is smaller than the number of names of a similar length
+	defer func(limit int) { defaultMaxFuzzyCandidates = limit }(defaultMaxFuzzyCandidates)
+	defaultMaxFuzzyCandidates = 3
+	page, err = component.GetComponentsFuzzy("tablestyel", "", 5, 0, "")
+	if err != nil || len(page.Components) == 0 || page.Components[0].PurlName != "tablestyle" {
+		t.Errorf("expected tablestyle to be the top match, got %+v (%v)", page.Components, err)
+	}
+	var args queryArgs
+	trigramOverlap(&args, "React")
+	if want := []any{"r%", "re%", "%act%", "%ct", "%eac%", "%rea%"}; !slices.Equal(args, want) {
+		t.Errorf("trigramOverlap() patterns = %v, want %v", args, want)
+	}
+	page, err = component.GetComponentsFuzzy("zzzzzzzz", "npm", 5, 0, "")
+	if err != nil || page.Total != 0 {
+		t.Errorf("expected no fuzzy matches, got %+v (%v)", page, err)
+	}
+	if _, err = component.GetComponentsFuzzy(" ", "npm", 5, 0, ""); err == nil {
+		t.Errorf("expected an error for an empty search term")
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnknownNamespaceType is returned when the purl type of a namespace is not supplied and cannot be inferred.
+var ErrUnknownNamespaceType = errors.New("cannot infer the package type of the namespace")
+
+// NamespacePurlType infers the purl type of a namespace from its format: an npm scope (@scope), a Go module path
+// prefix (domain/path) or a Maven group (dotted groupId). It returns an empty string if the type cannot be inferred.
+func NamespacePurlType(namespace string) string {
+	namespace = strings.Trim(strings.TrimSpace(namespace), "/")
+	first, _, hasPath := strings.Cut(namespace, "/")
+	switch {
+	case strings.HasPrefix(namespace, "@") || strings.HasPrefix(strings.ToLower(namespace), "%40"):
+		return "npm"
+	case hasPath && strings.Contains(first, "."):
+		return "golang"
+	case !hasPath && strings.Contains(strings.TrimSuffix(namespace, ":"), "."):
+		return "maven"
+	}
+	return ""
+}
+
+// namespacePatterns returns the (lowercase, escaped) LIKE patterns matching the purl names in a namespace, and the
+// purl name matching the namespace itself (Go module path prefixes can be modules too).
+func namespacePatterns(namespace, purlType string) ([]string, string) {
+	namespace = strings.ToLower(strings.Trim(strings.TrimSpace(namespace), "/"))
+	switch purlType {
+	case "npm":
+		// Scopes are stored with either a plain or an escaped @
+		scope := strings.TrimPrefix(strings.TrimPrefix(namespace, "%40"), "@")
+		return []string{escapeLike("@"+scope) + "/%", escapeLike("%40"+scope) + "/%"}, ""
+	case "maven":
+		group := strings.TrimSuffix(namespace, ":") // Allow groupId: as well as groupId
+		return []string{escapeLike(group) + "/%"}, ""
+	case "golang":
+		return []string{escapeLike(namespace) + "/%"}, namespace
+	}
+	return []string{escapeLike(namespace) + "/%"}, ""
+}
+
+// GetComponentsByNamespace lists the components in a namespace of a purl type: the artifacts of a Maven groupId,
+// the packages of an npm scope, the modules under a Go module path prefix, or the projects of an owner (i.e. GitHub).
+// The results can optionally be restricted to those with a name containing the supplied name.
+func (m *ComponentModel) GetComponentsByNamespace(namespace, purlType, name string, limit, offset int, cursor string) (ComponentPage, error) {
+	m.s.Infof("namespace parameter: %v (%v)", namespace, purlType)
+	if len(strings.Trim(strings.TrimSpace(namespace), "/@")) == 0 {
+		m.s.Error("Please specify a valid namespace to query")
+		return ComponentPage{}, errors.New("please specify a valid namespace to query")
+	}
+	if len(purlType) == 0 {
+		purlType = NamespacePurlType(namespace)
+		if len(purlType) == 0 {
+			return ComponentPage{}, ErrUnknownNamespaceType
+		}
+	}
+	limit, offset = searchLimits(limit, offset)
+	patterns, exact := namespacePatterns(namespace, purlType)
+	name = strings.TrimSpace(name)
+	match := func(args *queryArgs) string {
+		var conditions []string
+		for _, pattern := range patterns {
+			conditions = append(conditions, "lower(p.purl_name) LIKE "+args.add(pattern)+` ESCAPE '\'`)
+		}
+		if len(exact) > 0 {
+			conditions = append(conditions, "lower(p.purl_name) = "+args.add(exact))
+		}
+		condition := "(" + strings.Join(conditions, " OR ") + ")"
+		if len(name) > 0 {
+			condition += " AND p.component " + m.likeOperator + " " + args.add("%"+name+"%")
+		}
+		return condition
+	}
+	return m.searchComponents(componentSearch{match: match, purlType: purlType, name: name}, limit, offset, cursor)
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestNamespacePurlType(t *testing.T) {
+	tests := map[string]string{
+		"@babel":               "npm",
+		"%40babel":             "npm",
+		"org.apache.commons":   "maven",
+		"org.apache.commons:":  "maven",
+		"github.com/hashicorp": "golang",
+		"golang.org/x/":        "golang",
+		"angular":              "",
+		"angular/angular":      "",
+		"":                     "",
+	}
+	for namespace, want := range tests {
+		if got := NamespacePurlType(namespace); got != want {
+			t.Errorf("NamespacePurlType(%q) = %q, want %q", namespace, got, want)
+		}
+	}
+}
+
+func TestGetComponentsByNamespace(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	if err = LoadTestNamespaceSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL namespace test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	tests := []struct {
+		name, namespace, purlType, component string
+		want                                 []string
+	}{
+		{name: "maven group", namespace: "org.apache.commons",
+			want: []string{"org.apache.commons/commons-lang3", "org.apache.commons/commons-text"}},
+		{name: "maven group and name", namespace: "org.apache.commons:", component: "text", want: []string{"org.apache.commons/commons-text"}},
+		{name: "npm scope", namespace: "@babel", want: []string{"@babel/core", "@babel/parser"}},
+		{name: "npm scope without @", namespace: "babel", purlType: "npm", want: []string{"@babel/core", "@babel/parser"}},
+		{name: "github owner", namespace: "angular", purlType: "github", component: "angular", want: []string{"angular/angular"}},
+		{name: "empty namespace", namespace: "org.apache.common"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := component.GetComponentsByNamespace(tt.namespace, tt.purlType, tt.component, 0, 0, "")
+			if err != nil {
+				t.Fatalf("components.GetComponentsByNamespace() error = %v", err)
+			}
+			var got []string
+			for _, c := range page.Components {
+				got = append(got, c.PurlName)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("GetComponentsByNamespace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err = component.GetComponentsByNamespace("angular", "", "", 0, 0, ""); !errors.Is(err, ErrUnknownNamespaceType) {
+		t.Errorf("GetComponentsByNamespace() expected an unknown type error, got %v", err)
+	}
+	if _, err = component.GetComponentsByNamespace(" @ ", "npm", "", 0, 0, ""); err == nil {
+		t.Errorf("GetComponentsByNamespace() expected an error for an empty namespace")
+	}
+	if purl := NamespacePurl("npm", "@babel/core"); purl != "pkg:npm/%40babel/core" {
+		t.Errorf("NamespacePurl() = %v, want pkg:npm/%%40babel/core", purl)
+	}
+	// Only the namespace search results are encoded
+	if purl := ComponentPurl("npm", "@babel/core"); purl != "pkg:npm/@babel/core" {
+		t.Errorf("ComponentPurl() = %v, want pkg:npm/@babel/core", purl)
+	}
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+// Default ORDER BY clauses of the purl types, ordering the search results with the same relevance.
+const (
+	gitOrderByClause     = "ORDER BY git_created_at NULLS LAST , git_forks DESC NULLS LAST, git_stars DESC NULLS LAST"
+	packageOrderByClause = "ORDER BY first_version_date NULLS LAST, versions NULLS LAST"
+)
+
+// defaultOrderByClauses maps every known purl type (mine) to its default ORDER BY clause.
+// Git hosting sites order by repository age and popularity, everything else by first release and versions.
+var defaultOrderByClauses = map[string]string{
+	"github":        gitOrderByClause,
+	"gitlab":        gitOrderByClause,
+	"gitee":         gitOrderByClause,
+	"bitbucket":     gitOrderByClause,
+	"sourceforge":   gitOrderByClause,
+	"googlesource":  gitOrderByClause,
+	"pypi":          packageOrderByClause,
+	"npm":           packageOrderByClause,
+	"gem":           packageOrderByClause,
+	"maven":         packageOrderByClause,
+	"nuget":         packageOrderByClause,
+	"cargo":         packageOrderByClause,
+	"golang":        packageOrderByClause,
+	"composer":      packageOrderByClause,
+	"cocoapods":     packageOrderByClause,
+	"pub":           packageOrderByClause,
+	"hex":           packageOrderByClause,
+	"hackage":       packageOrderByClause,
+	"conda":         packageOrderByClause,
+	"conan":         packageOrderByClause,
+	"swift":         packageOrderByClause,
+	"cpan":          packageOrderByClause,
+	"deb":           packageOrderByClause,
+	"rpm":           packageOrderByClause,
+	"wordpress":     packageOrderByClause,
+	"drupal":        packageOrderByClause,
+	"angular":       packageOrderByClause,
+	"apache":        packageOrderByClause,
+	"apple":         packageOrderByClause,
+	"gnome":         packageOrderByClause,
+	"gnu":           packageOrderByClause,
+	"isc":           packageOrderByClause,
+	"java2s":        packageOrderByClause,
+	"jquery":        packageOrderByClause,
+	"kernel":        packageOrderByClause,
+	"mozilla":       packageOrderByClause,
+	"nasm":          packageOrderByClause,
+	"nmap":          packageOrderByClause,
+	"postgresql":    packageOrderByClause,
+	"slf4j":         packageOrderByClause,
+	"stackoverflow": packageOrderByClause,
+	"sudo":          packageOrderByClause,
+	"videolan":      packageOrderByClause,
+	"zlib":          packageOrderByClause,
+}
+
+// defaultOrderTerms are the parsed default ORDER BY clauses of the purl types.
+var defaultOrderTerms = parseOrderByClauses(defaultOrderByClauses)
+
+// SetSearchOrder sets the configured ORDER BY clauses of the purl types (i.e. from the server config), used by all
+// subsequent searches. The clauses must already be validated (see config.ParseSearchOrder). Other purl types keep
+// their default ordering.
+func (m *ComponentModel) SetSearchOrder(clauses map[string]string) {
+	m.searchOrder = clauses
+}
+
+// purlTypeOrder returns the ordering of a purl type (empty if there is none), and whether it was configured
+// (see SetSearchOrder) rather than being the default.
+func (m *ComponentModel) purlTypeOrder(purlType string) ([]sortTerm, bool) {
+	if clause, ok := m.searchOrder[purlType]; ok {
+		return parseOrderByClause(clause), true
+	}
+	return defaultOrderTerms[purlType], false
+}
+
+// parseOrderByClauses parses the ORDER BY clause of each purl type into its sort terms.
+func parseOrderByClauses(clauses map[string]string) map[string][]sortTerm {
+	terms := make(map[string][]sortTerm, len(clauses))
+	for purlType, clause := range clauses {
+		terms[purlType] = parseOrderByClause(clause)
+	}
+	return terms
+}
+
+// parseOrderByClause parses an ORDER BY clause of "column [ASC|DESC] [NULLS FIRST|NULLS LAST]" terms.
+// Columns that are not search result columns are ignored. NULL values sort last unless NULLS FIRST is specified.
+func parseOrderByClause(clause string) []sortTerm {
+	clause = strings.TrimSpace(clause)
+	if len(clause) >= len("ORDER BY") && strings.EqualFold(clause[:len("ORDER BY")], "ORDER BY") {
+		clause = clause[len("ORDER BY"):]
+	}
+	var terms []sortTerm
+	for _, term := range strings.Split(clause, ",") {
+		fields := strings.Fields(strings.ToLower(term))
+		if len(fields) == 0 || !slices.Contains(searchColumns, fields[0]) {
+			continue
+		}
+		t := sortTerm{column: fields[0]}
+		for i, field := range fields[1:] {
+			switch {
+			case field == "desc":
+				t.descending = true
+			case field == "first" && i > 0 && fields[i] == "nulls":
+				t.nullsFirst = true
+			}
+		}
+		terms = append(terms, t)
+	}
+	return terms
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Relevance score weights. Name matches dominate, with popularity used to order similar matches.
+const (
+	scoreExactName      = 100.0
+	scoreExactVendor    = 50.0
+	scoreNamePrefix     = 40.0
+	scoreVendorPrefix   = 20.0
+	scoreNameSubstring  = 20.0
+	scorePurlSubstring  = 10.0
+	scoreStarsWeight    = 4.0 // Per order of magnitude of git stars
+	scoreVersionsWeight = 3.0 // Per order of magnitude of versions
+	scoreMaxPopularity  = 30.0
+)
+
+// relevanceScore returns the SQL expression scoring the relevance of a component (in hundredths, so it can be compared
+// exactly) against the supplied component name and vendor search terms (either may be empty): the best name match
+// (exact, prefix, substring or purl name substring), the best vendor match (exact or prefix), plus a capped
+// logarithmic popularity score based on the git stars and number of versions.
+func relevanceScore(args *queryArgs, name, vendor string) string {
+	parts := matchScoreParts(args, name, vendor)
+	popularity := "(CASE WHEN p.git_stars > 0 THEN log(p.git_stars + 1.0) * " + hundredths(scoreStarsWeight) + " ELSE 0 END" +
+		" + CASE WHEN p.versions > 0 THEN log(p.versions + 1.0) * " + hundredths(scoreVersionsWeight) + " ELSE 0 END)"
+	parts = append(parts, "CASE WHEN "+popularity+" > "+hundredths(scoreMaxPopularity)+
+		" THEN "+hundredths(scoreMaxPopularity)+" ELSE "+popularity+" END")
+	return "CAST(ROUND(" + strings.Join(parts, " + ") + ") AS INTEGER)"
+}
+
+// matchScore returns the SQL expression scoring how well a component matches the supplied search terms (in
+// hundredths), which is the relevance score without the popularity (see relevanceScore).
+func matchScore(args *queryArgs, name, vendor string) string {
+	parts := matchScoreParts(args, name, vendor)
+	if len(parts) == 0 {
+		return "0"
+	}
+	return "(" + strings.Join(parts, " + ") + ")"
+}
+
+// matchScoreParts returns the SQL expressions scoring the best name and vendor matches (for the non-empty terms).
+func matchScoreParts(args *queryArgs, name, vendor string) []string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	vendor = strings.ToLower(strings.TrimSpace(vendor))
+	var parts []string
+	if len(name) > 0 {
+		exact, prefix, substring := args.add(name), args.add(escapeLike(name)+"%"), args.add("%"+escapeLike(name)+"%")
+		parts = append(parts, "CASE WHEN lower(p.component) = "+exact+" THEN "+hundredths(scoreExactName)+
+			" WHEN lower(p.component) LIKE "+prefix+` ESCAPE '\' THEN `+hundredths(scoreNamePrefix)+
+			" WHEN lower(p.component) LIKE "+substring+` ESCAPE '\' THEN `+hundredths(scoreNameSubstring)+
+			" WHEN lower(p.purl_name) LIKE "+substring+` ESCAPE '\' THEN `+hundredths(scorePurlSubstring)+
+			" ELSE 0 END")
+	}
+	if len(vendor) > 0 {
+		exact, prefix := args.add(vendor), args.add(escapeLike(vendor)+"%")
+		parts = append(parts, "CASE WHEN lower(p.vendor) = "+exact+" THEN "+hundredths(scoreExactVendor)+
+			" WHEN lower(p.vendor) LIKE "+prefix+` ESCAPE '\' THEN `+hundredths(scoreVendorPrefix)+
+			" ELSE 0 END")
+	}
+	return parts
+}
+
+// hundredths returns a score weight in hundredths, as an SQL literal.
+func hundredths(weight float64) string {
+	return strconv.Itoa(int(weight * 100))
+}
+
+// popularityScore returns a (capped) logarithmic score based on the git stars and number of versions.
+// It matches the popularity part of relevanceScore, for the matches ranked in code (see RankFuzzyComponents).
+func popularityScore(c Component) float64 {
+	var score float64
+	if c.GitStars.Valid && c.GitStars.Int64 > 0 {
+		score += math.Log10(float64(c.GitStars.Int64)+1) * scoreStarsWeight
+	}
+	if c.Versions.Valid && c.Versions.Int64 > 0 {
+		score += math.Log10(float64(c.Versions.Int64)+1) * scoreVersionsWeight
+	}
+	return math.Min(score, scoreMaxPopularity)
+}
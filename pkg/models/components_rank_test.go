@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestRelevanceScore(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	_, err = conn.ExecContext(ctx, `CREATE TABLE ranked (component text, vendor text, purl_name text, git_stars integer, versions integer);
+		INSERT INTO ranked VALUES
+			('react-dom-utils', 'someone', 'someone/react-dom-utils', NULL, NULL), -- Prefix
+			('my-react', 'other', 'other/my-react', 5000, NULL),                    -- Substring (popular)
+			('react', 'fork', 'fork/react', NULL, NULL),                           -- Exact name
+			('react', 'facebook', 'facebook/react', 200000, NULL),                 -- Exact name (popular)
+			('utils', 'react', 'react/utils', NULL, NULL),                         -- Exact vendor
+			('preact', 'x', 'x/preact', NULL, NULL),                               -- Substring
+			('react', 'big', 'big/react', 1152921504606846976, 1152921504606846976) -- Capped popularity`)
+	if err != nil {
+		t.Fatalf("failed to create the ranked table: %v", err)
+	}
+	var args queryArgs
+	query := "SELECT p.purl_name AS purl_name, " + relevanceScore(&args, "React", "react") + " AS score FROM ranked p" +
+		" ORDER BY score DESC, purl_name"
+	var rows []struct {
+		PurlName string `db:"purl_name"`
+		Score    int64  `db:"score"`
+	}
+	if err = conn.SelectContext(ctx, &rows, query, args...); err != nil {
+		t.Fatalf("failed to score the components: %v", err)
+	}
+	want := []string{"big/react", "facebook/react", "fork/react", "react/utils", "someone/react-dom-utils", "other/my-react", "x/preact"}
+	scores := make(map[string]int64)
+	for i, r := range rows {
+		scores[r.PurlName] = r.Score
+		if i < len(want) && r.PurlName != want[i] {
+			t.Errorf("position %d: got %v (score %v), want %v", i, r.PurlName, r.Score, want[i])
+		}
+	}
+	if scores["facebook/react"] <= scores["fork/react"] {
+		t.Errorf("expected popularity to break ties between exact matches: %v <= %v", scores["facebook/react"], scores["fork/react"])
+	}
+	if scores["fork/react"] != int64(scoreExactName*100) {
+		t.Errorf("expected an exact name score of %v, got %v", scoreExactName*100, scores["fork/react"])
+	}
+	// Popularity is capped, so it cannot outrank a better name match
+	if scores["big/react"] != int64((scoreExactName+scoreMaxPopularity)*100) {
+		t.Errorf("expected a capped popularity score, got %v", scores["big/react"])
+	}
+	stars := func(n int64) sql.NullInt64 { return sql.NullInt64{Int64: n, Valid: true} }
+	if got := popularityScore(Component{GitStars: stars(1 << 60), Versions: stars(1 << 60)}); got != scoreMaxPopularity {
+		t.Errorf("popularityScore() = %v, want %v", got, scoreMaxPopularity)
+	}
+}
+
+func TestGetComponentsRanked(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	page, err := component.GetComponents("angular", "github", 10, 0, "")
+	if err != nil {
+		t.Fatalf("components.GetComponents() error = %v", err)
+	}
+	components := page.Components
+	if len(components) == 0 {
+		t.Fatalf("expected components to be returned")
+	}
+	if components[0].PurlName != "angular/angular" {
+		t.Errorf("expected angular/angular to be ranked first, got %v", components[0].PurlName)
+	}
+	for i := 1; i < len(components); i++ {
+		if components[i].Score > components[i-1].Score {
+			t.Errorf("results are not sorted by score: %v (%v) > %v (%v)", components[i].PurlName, components[i].Score,
+				components[i-1].PurlName, components[i-1].Score)
+		}
+	}
+}
+
+func TestGetComponentsSearchOrder(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	// By default, the prefix matches are ordered by popularity
+	page, err := component.GetComponentsByNameType("react", "npm", 0, 0, "")
+	if err != nil {
+		t.Fatalf("components.GetComponentsByNameType() error = %v", err)
+	}
+	if len(page.Components) < 3 || page.Components[0].Component != "react" || page.Components[1].Component != "react-dom" {
+		t.Fatalf("unexpected default order: %v", componentNames(page.Components))
+	}
+	// The configured ordering replaces the popularity, but the matches are still ranked by how well they match
+	component.SetSearchOrder(map[string]string{"npm": "git_stars ASC NULLS LAST"})
+	ordered, err := component.GetComponentsByNameType("react", "npm", 0, 0, "")
+	if err != nil {
+		t.Fatalf("components.GetComponentsByNameType() error = %v", err)
+	}
+	names := componentNames(ordered.Components)
+	if len(names) != len(page.Components) || names[0] != "react" || names[1] != "react-checkbox-tree" {
+		t.Fatalf("unexpected configured order: %v", names)
+	}
+	// Paging with a cursor follows the configured ordering
+	var paged []string
+	cursor := ""
+	for {
+		next, err := component.GetComponentsByNameType("react", "npm", 2, 0, cursor)
+		if err != nil {
+			t.Fatalf("components.GetComponentsByNameType() error = %v", err)
+		}
+		paged = append(paged, componentNames(next.Components)...)
+		if !next.HasMore {
+			break
+		}
+		cursor = next.NextCursor
+	}
+	if !slices.Equal(paged, names) {
+		t.Errorf("paged order %v, want %v", paged, names)
+	}
+	// Other purl types keep their default ordering
+	if got, configured := component.purlTypeOrder("maven"); configured || len(got) == 0 {
+		t.Errorf("unexpected maven ordering: %v (configured %v)", got, configured)
+	}
+}
+
+// componentNames returns the names of the components, in order.
+func componentNames(components []Component) []string {
+	names := make([]string, 0, len(components))
+	for _, c := range components {
+		names = append(names, c.Component)
+	}
+	return names
+}
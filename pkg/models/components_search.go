@@ -0,0 +1,373 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"database/sql"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxWildcardFallback is the maximum number of wildcard matches returned when the full-text search finds none
+// (see searchComponents).
+var defaultMaxWildcardFallback = 2000
+
+// queryArgs holds the arguments of an SQL query as it is built.
+type queryArgs []any
+
+// add appends an argument to the query, returning its placeholder.
+func (a *queryArgs) add(value any) string {
+	*a = append(*a, value)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+// searchColumns are the projects columns returned by a component search: those of a Component, plus the other
+// columns the results can be ordered by (see SetSearchOrder), so the cursor of the next page can be built.
+var searchColumns = []string{"component", "vendor", "purl_name", "git_stars", "git_forks", "git_issues", "versions",
+	"first_version_date", "latest_version_date", "license", "status", "git_created_at", "git_updated_at",
+	"git_pushed_at", "first_indexed_date", "last_indexed_date"}
+
+// resultColumns are the columns of the search results (the search columns plus the purl type, the match score and
+// the relevance score).
+var resultColumns = strings.Join(searchColumns, ", ") + ", purl_type, match_score, score"
+
+// searchRow is a single row of the component search results.
+type searchRow struct {
+	Component
+	Relevance        int64          `db:"score"`       // Relevance score in hundredths (see relevanceScore)
+	MatchScore       int64          `db:"match_score"` // Match score in hundredths (see matchScore)
+	GitIssues        sql.NullInt64  `db:"git_issues"`
+	GitCreatedAt     sql.NullString `db:"git_created_at"`
+	GitUpdatedAt     sql.NullString `db:"git_updated_at"`
+	GitPushedAt      sql.NullString `db:"git_pushed_at"`
+	FirstIndexedDate sql.NullString `db:"first_indexed_date"`
+	LastIndexedDate  sql.NullString `db:"last_indexed_date"`
+}
+
+// value returns the value of a column of the row (nil if it is NULL), to build the cursor of the next page.
+func (r searchRow) value(column string) any {
+	nullInt := func(n sql.NullInt64) any {
+		if !n.Valid {
+			return nil
+		}
+		return n.Int64
+	}
+	nullString := func(s sql.NullString) any {
+		if !s.Valid {
+			return nil
+		}
+		return s.String
+	}
+	switch column {
+	case "score":
+		return r.Relevance
+	case "match_score":
+		return r.MatchScore
+	case "component":
+		return r.Component.Component
+	case "vendor":
+		return r.Vendor
+	case "purl_type":
+		return r.PurlType
+	case "purl_name":
+		return r.PurlName
+	case "git_stars":
+		return nullInt(r.GitStars)
+	case "git_forks":
+		return nullInt(r.GitForks)
+	case "git_issues":
+		return nullInt(r.GitIssues)
+	case "versions":
+		return nullInt(r.Versions)
+	case "first_version_date":
+		return nullString(r.FirstVersionDate)
+	case "latest_version_date":
+		return nullString(r.LatestVersionDate)
+	case "git_created_at":
+		return nullString(r.GitCreatedAt)
+	case "git_updated_at":
+		return nullString(r.GitUpdatedAt)
+	case "git_pushed_at":
+		return nullString(r.GitPushedAt)
+	case "first_indexed_date":
+		return nullString(r.FirstIndexedDate)
+	case "last_indexed_date":
+		return nullString(r.LastIndexedDate)
+	}
+	return nil
+}
+
+// componentSearch describes a component search, which is run as a single SQL query (plus a count of the matches).
+type componentSearch struct {
+	match        func(args *queryArgs) string // Returns the WHERE condition matching the components
+	fullText     func(args *queryArgs) string // Returns the full-text WHERE condition (nil if it cannot be used)
+	purlType     string                       // Purl type to search (empty searches every type)
+	name         string                       // Component name (or free text) the relevance is scored against
+	vendor       string                       // Vendor the relevance is scored against
+	perTypeLimit int                          // Maximum number of results per purl type (0 for no cap)
+	maxMatches   int                          // Maximum number of matches, in the sort order (0 for no cap)
+}
+
+// searchComponents runs the search and returns the requested page of results, in the requested sort order
+// (see sortTerms). Pages are read directly from the database, starting after the cursor position (keyset pagination)
+// or skipping offset results if there is no cursor. When the full-text index can be used, its condition replaces the
+// match condition. Only if it finds nothing is the (slower) match condition run, capped to the first
+// defaultMaxWildcardFallback matches, so substrings that are not the start of a word are still found. If the
+// full-text search fails, the match condition is used instead.
+func (m *ComponentModel) searchComponents(search componentSearch, limit, offset int, cursor string) (ComponentPage, error) {
+	terms := m.sortTerms(search.purlType)
+	var after []any
+	if len(cursor) > 0 {
+		var err error
+		if after, err = decodeCursor(cursor, terms); err != nil {
+			return ComponentPage{}, err
+		}
+		offset = 0
+	}
+	if search.fullText != nil {
+		page, err := m.searchPage(search, search.fullText, terms, after, limit, offset)
+		if err != nil {
+			m.s.Warnf("Full-text search failed, falling back to wildcard queries: %v", err)
+			return m.searchPage(search, search.match, terms, after, limit, offset)
+		}
+		if page.Total > 0 {
+			return page, nil
+		}
+		search.maxMatches = defaultMaxWildcardFallback
+	}
+	return m.searchPage(search, search.match, terms, after, limit, offset)
+}
+
+// searchPage queries a page of the components satisfying the match condition, and the total number of matches.
+func (m *ComponentModel) searchPage(search componentSearch, match func(args *queryArgs) string, terms []sortTerm,
+	after []any, limit, offset int) (ComponentPage, error) {
+	var args queryArgs
+	matches := m.matchQuery(&args, search, match)
+	if search.perTypeLimit > 0 {
+		matches = "SELECT r.*, ROW_NUMBER() OVER (PARTITION BY purl_type ORDER BY " + orderByTerms(terms) + ") AS type_rank" +
+			" FROM (" + matches + ") r"
+	}
+	var conditions []string
+	if search.perTypeLimit > 0 {
+		conditions = append(conditions, "type_rank <= "+args.add(search.perTypeLimit))
+	}
+	var totals []int
+	countQuery := "SELECT COUNT(*) FROM (" + matches + ") t" + whereClause(conditions)
+	if err := m.q.SelectContext(m.ctx, &totals, countQuery, args...); err != nil {
+		m.s.Errorf("Failed to count component search matches: %v", err)
+		return ComponentPage{}, err
+	}
+	page := ComponentPage{}
+	if len(totals) > 0 {
+		page.Total = totals[0]
+	}
+	if m.facets {
+		facets, err := m.searchFacets(search, match)
+		if err != nil {
+			return ComponentPage{}, err
+		}
+		page.Facets = facets
+	}
+	if len(after) > 0 {
+		conditions = append(conditions, keysetCondition(&args, terms, after))
+	}
+	query := "SELECT " + resultColumns + " FROM (" + matches + ") t" + whereClause(conditions) +
+		" ORDER BY " + orderByTerms(terms) + " LIMIT " + args.add(limit+1)
+	if offset > 0 {
+		query += " OFFSET " + args.add(offset)
+	}
+	var rows []searchRow
+	if err := m.q.SelectContext(m.ctx, &rows, query, args...); err != nil {
+		m.s.Errorf("Failed to search for components: %v", err)
+		return ComponentPage{}, err
+	}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		page.HasMore = true
+		page.NextCursor = encodeCursor(cursorValues(rows[len(rows)-1], terms))
+	}
+	page.Components = searchRowComponents(rows)
+	return page, nil
+}
+
+// matchQuery returns the query selecting the search columns, purl type and relevance score of every component
+// satisfying the match condition, purl type and filter (see SetFilter), up to the maximum number of matches (if any).
+func (m *ComponentModel) matchQuery(args *queryArgs, search componentSearch, match func(args *queryArgs) string) string {
+	columns := make([]string, 0, len(searchColumns))
+	for _, column := range searchColumns {
+		if column == "purl_name" {
+			// Coalesced, so it is never NULL in the sort and keyset terms (see nullable)
+			columns = append(columns, "COALESCE(p.purl_name, '') AS purl_name")
+			continue
+		}
+		columns = append(columns, "p."+column)
+	}
+	query := "SELECT " + strings.Join(columns, ", ") + ", m.purl_type, " + matchScore(args, search.name, search.vendor) + " AS match_score, " +
+		relevanceScore(args, search.name, search.vendor) + " AS score" +
+		" FROM projects p INNER JOIN mines m ON p.mine_id = m.id" +
+		" WHERE (" + match(args) + ")"
+	if len(search.purlType) > 0 {
+		query += " AND m.purl_type = " + args.add(search.purlType)
+	} else {
+		query += " AND m.purl_type <> ''"
+	}
+	filter, filterArgs := m.filter.condition(m.likeOperator, len(*args)+1)
+	*args = append(*args, filterArgs...)
+	if len(filter) > 0 {
+		query += " " + filter
+	}
+	if search.maxMatches > 0 {
+		query += " ORDER BY " + orderByTerms(m.sortTerms(search.purlType)) + " LIMIT " + args.add(search.maxMatches)
+	}
+	return query
+}
+
+// searchRowComponents converts the search result rows to components.
+func searchRowComponents(rows []searchRow) []Component {
+	components := make([]Component, 0, len(rows))
+	for _, r := range rows {
+		c := r.Component
+		c.Score = float64(r.Relevance) / 100
+		components = append(components, c)
+	}
+	return components
+}
+
+// whereClause returns the conditions as a WHERE clause (empty if there are none).
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// sortTerm is a single term of the search result order.
+type sortTerm struct {
+	column     string // Search result column
+	descending bool
+	nullsFirst bool // NULL values sort first (they sort last by default)
+}
+
+// numericColumns are the search result columns with integer values.
+var numericColumns = []string{"score", "match_score", "git_stars", "git_forks", "git_issues", "versions"}
+
+// nullable reports whether the column of the term can be NULL. The purl name is coalesced to an empty string by the
+// search (see matchQuery), so components without one still have a unique position to continue from.
+func (t sortTerm) nullable() bool {
+	return t.column != "score" && t.column != "match_score" && t.column != "purl_type" && t.column != "purl_name"
+}
+
+// String returns the term as an ORDER BY term.
+func (t sortTerm) String() string {
+	term := t.column + " ASC"
+	if t.descending {
+		term = t.column + " DESC"
+	}
+	if !t.nullable() {
+		return term
+	}
+	if t.nullsFirst {
+		return term + " NULLS FIRST"
+	}
+	return term + " NULLS LAST"
+}
+
+// sortTerms returns the order of the search results: the requested sort key (see SetSort), or when sorting by
+// relevance, the relevance score followed by the default ordering of the purl type. If the ordering of the purl type
+// was configured (see SetSearchOrder), it replaces the popularity part of the relevance instead: the results are
+// ordered by how well they match, then by the configured ordering, and then by relevance. Ties are ordered by
+// purl type and name, so every result has a unique position to continue from.
+func (m *ComponentModel) sortTerms(purlType string) []sortTerm {
+	var terms []sortTerm
+	if m.sort.isRelevance() {
+		var order []sortTerm
+		configured := false
+		if len(purlType) > 0 {
+			order, configured = m.purlTypeOrder(purlType)
+		}
+		if configured {
+			terms = append(terms, sortTerm{column: "match_score", descending: m.sort.descending()})
+			terms = append(terms, order...)
+			terms = append(terms, sortTerm{column: "score", descending: m.sort.descending()})
+		} else {
+			terms = append(terms, sortTerm{column: "score", descending: m.sort.descending()})
+			terms = append(terms, order...)
+		}
+	} else {
+		terms = append(terms, sortTerm{column: sortKeys[m.sort.Key].column, descending: m.sort.descending()})
+	}
+	return append(terms, sortTerm{column: "purl_type"}, sortTerm{column: "purl_name"})
+}
+
+// orderByTerms returns the terms as a list of ORDER BY terms.
+func orderByTerms(terms []sortTerm) string {
+	list := make([]string, 0, len(terms))
+	for _, t := range terms {
+		list = append(list, t.String())
+	}
+	return strings.Join(list, ", ")
+}
+
+// keysetCondition returns the condition selecting the results that sort after the given values of the terms.
+func keysetCondition(args *queryArgs, terms []sortTerm, values []any) string {
+	condition := ""
+	for i := len(terms) - 1; i >= 0; i-- {
+		t := terms[i]
+		var parts []string
+		if values[i] == nil {
+			if t.nullsFirst {
+				parts = append(parts, t.column+" IS NOT NULL")
+			}
+			if len(condition) > 0 {
+				parts = append(parts, "("+t.column+" IS NULL AND "+condition+")")
+			}
+		} else {
+			value := args.add(values[i])
+			operator := " > "
+			if t.descending {
+				operator = " < "
+			}
+			parts = append(parts, t.column+operator+value)
+			if t.nullable() && !t.nullsFirst {
+				parts = append(parts, t.column+" IS NULL")
+			}
+			if len(condition) > 0 {
+				parts = append(parts, "("+t.column+" = "+value+" AND "+condition+")")
+			}
+		}
+		if len(parts) == 0 {
+			parts = append(parts, "1 = 0")
+		}
+		condition = "(" + strings.Join(parts, " OR ") + ")"
+	}
+	return condition
+}
+
+// cursorValues returns the values of the sort terms of a row.
+func cursorValues(r searchRow, terms []sortTerm) []any {
+	values := make([]any, 0, len(terms))
+	for _, t := range terms {
+		values = append(values, r.value(t.column))
+	}
+	return values
+}
+
+// isNumericColumn reports whether a search result column has integer values.
+func isNumericColumn(column string) bool {
+	return slices.Contains(numericColumns, column)
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Component search sort keys.
+const (
+	SortRelevance     = "relevance"      // Relevance score (default, descending)
+	SortName          = "name"           // Component name (ascending)
+	SortStars         = "stars"          // Git stars (descending)
+	SortForks         = "forks"          // Git forks (descending)
+	SortLatestRelease = "latest_release" // Latest version release date, newest first
+	SortFirstRelease  = "first_release"  // First version release date, oldest first
+	SortVersions      = "versions"       // Number of versions (descending)
+)
+
+// Sort directions.
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// ErrInvalidSort is returned when an unknown sort key or direction is requested.
+var ErrInvalidSort = errors.New("invalid search sort")
+
+// sortKeys maps each sort key to its projects column and whether it sorts descending by default.
+var sortKeys = map[string]struct {
+	column     string
+	descending bool
+}{
+	SortRelevance:     {descending: true},
+	SortName:          {column: "component"},
+	SortStars:         {column: "git_stars", descending: true},
+	SortForks:         {column: "git_forks", descending: true},
+	SortLatestRelease: {column: "latest_version_date", descending: true},
+	SortFirstRelease:  {column: "first_version_date"},
+	SortVersions:      {column: "versions", descending: true},
+}
+
+// ComponentSort is the order of the component search results. The zero value sorts by descending relevance.
+type ComponentSort struct {
+	Key     string // Sort key (empty is relevance)
+	Reverse bool   // Reverse the default direction of the key
+}
+
+// ParseComponentSort returns the sort for a key and direction (asc or desc, empty for the key default).
+func ParseComponentSort(key, direction string) (ComponentSort, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if len(key) == 0 {
+		key = SortRelevance
+	}
+	sk, ok := sortKeys[key]
+	if !ok {
+		return ComponentSort{}, fmt.Errorf("%w: unknown sort key %q", ErrInvalidSort, key)
+	}
+	var descending bool
+	switch strings.ToLower(strings.TrimSpace(direction)) {
+	case "":
+		descending = sk.descending
+	case SortAscending:
+		descending = false
+	case SortDescending:
+		descending = true
+	default:
+		return ComponentSort{}, fmt.Errorf("%w: unknown sort direction %q", ErrInvalidSort, direction)
+	}
+	if key == SortRelevance && descending == sk.descending {
+		key = "" // Keep the zero value for the default order
+	}
+	return ComponentSort{Key: key, Reverse: descending != sk.descending}, nil
+}
+
+// SetSort sets the order of all subsequent component searches.
+func (m *ComponentModel) SetSort(sort ComponentSort) {
+	m.sort = sort
+}
+
+// isRelevance reports whether the sort is by relevance.
+func (s ComponentSort) isRelevance() bool {
+	return len(s.Key) == 0 || s.Key == SortRelevance
+}
+
+// descending reports whether the sort is in descending order.
+func (s ComponentSort) descending() bool {
+	key := s.Key
+	if len(key) == 0 {
+		key = SortRelevance
+	}
+	return sortKeys[key].descending != s.Reverse
+}
+
+// value returns the sort key value of a component as a string that sorts in the same order,
+// and whether it has a value (components without one are sorted last).
+func (s ComponentSort) value(c Component) (string, bool) {
+	switch s.Key {
+	case SortName:
+		return strings.ToLower(c.Component), true
+	case SortStars:
+		return fmt.Sprintf("%020d", c.GitStars.Int64), c.GitStars.Valid
+	case SortForks:
+		return fmt.Sprintf("%020d", c.GitForks.Int64), c.GitForks.Valid
+	case SortVersions:
+		return fmt.Sprintf("%020d", c.Versions.Int64), c.Versions.Valid
+	case SortLatestRelease:
+		return c.LatestVersionDate.String, len(c.LatestVersionDate.String) > 0
+	case SortFirstRelease:
+		return c.FirstVersionDate.String, len(c.FirstVersionDate.String) > 0
+	}
+	return "", false
+}
+
+// position returns the sort position of the given component.
+func (s ComponentSort) position(c Component) componentCursor {
+	pos := componentCursor{Score: c.Score, PurlType: c.PurlType, PurlName: c.PurlName}
+	if !s.isRelevance() {
+		var ok bool
+		pos.Value, ok = s.value(c)
+		pos.Null = !ok
+	}
+	return pos
+}
+
+// less reports whether the component sorts before the given position. Ties are sorted by purl type and name.
+func (s ComponentSort) less(c Component, pos componentCursor) bool {
+	if s.isRelevance() {
+		if c.Score != pos.Score {
+			return (c.Score > pos.Score) == s.descending()
+		}
+	} else {
+		value, ok := s.value(c)
+		if ok != !pos.Null {
+			return ok // Components without a value go last
+		}
+		if value != pos.Value {
+			return (value > pos.Value) == s.descending()
+		}
+	}
+	if c.PurlType != pos.PurlType {
+		return c.PurlType < pos.PurlType
+	}
+	return c.PurlName < pos.PurlName
+}
+
+// sortComponents sorts the components in a deterministic order.
+func (s ComponentSort) sortComponents(components []Component) {
+	sort.SliceStable(components, func(i, j int) bool {
+		return s.less(components[i], s.position(components[j]))
+	})
+}
+
+// paginate sorts the components ranked in code (see RankFuzzyComponents) by the requested order
+// and returns the requested page.
+func (m *ComponentModel) paginate(components []Component, limit, offset int, cursor string) (ComponentPage, error) {
+	if !m.sort.isRelevance() {
+		m.sort.sortComponents(components)
+	}
+	return paginateSorted(components, m.sort, limit, offset, cursor)
+}
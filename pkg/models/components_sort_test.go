@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestParseComponentSort(t *testing.T) {
+	tests := []struct {
+		key, direction string
+		want           ComponentSort
+		wantOrderBy    string
+		wantErr        bool
+	}{
+		{key: "", direction: "", want: ComponentSort{}, wantOrderBy: "score DESC, purl_type ASC, purl_name ASC"},
+		{key: "Relevance", direction: "desc", want: ComponentSort{}, wantOrderBy: "score DESC, purl_type ASC, purl_name ASC"},
+		{key: "relevance", direction: "asc", want: ComponentSort{Key: SortRelevance, Reverse: true},
+			wantOrderBy: "score ASC, purl_type ASC, purl_name ASC"},
+		{key: "stars", direction: "", want: ComponentSort{Key: SortStars}, wantOrderBy: "git_stars DESC NULLS LAST, purl_type ASC, purl_name ASC"},
+		{key: "stars", direction: "ASC", want: ComponentSort{Key: SortStars, Reverse: true}, wantOrderBy: "git_stars ASC NULLS LAST, purl_type ASC, purl_name ASC"},
+		{key: " name ", direction: "", want: ComponentSort{Key: SortName}, wantOrderBy: "component ASC NULLS LAST, purl_type ASC, purl_name ASC"},
+		{key: "first_release", direction: "desc", want: ComponentSort{Key: SortFirstRelease, Reverse: true},
+			wantOrderBy: "first_version_date DESC NULLS LAST, purl_type ASC, purl_name ASC"},
+		{key: "downloads", wantErr: true},
+		{key: "stars", direction: "up", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"_"+tt.direction, func(t *testing.T) {
+			got, err := ParseComponentSort(tt.key, tt.direction)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSort) {
+					t.Errorf("ParseComponentSort() expected an invalid sort error, got %v", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseComponentSort() = %+v (%v), want %+v", got, err, tt.want)
+			}
+			if orderBy := orderByTerms((&ComponentModel{sort: got}).sortTerms("")); orderBy != tt.wantOrderBy {
+				t.Errorf("sortTerms() = %v, want %v", orderBy, tt.wantOrderBy)
+			}
+		})
+	}
+}
+
+func TestGetComponentsSorted(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	tests := []struct {
+		key, direction string
+		want           string
+	}{
+		{key: SortName, want: "react,react-checkbox-tree,react-dom,react-router-dom,react-split-pane,react-syntax-highlighter"},
+		{key: SortStars, want: "react,react-dom,react-router-dom,react-split-pane,react-syntax-highlighter,react-checkbox-tree"},
+		{key: SortStars, direction: SortAscending,
+			want: "react-checkbox-tree,react-syntax-highlighter,react-split-pane,react-router-dom,react,react-dom"},
+		{key: SortForks, want: "react,react-dom,react-router-dom,react-split-pane,react-syntax-highlighter,react-checkbox-tree"},
+		{key: SortLatestRelease, want: "react,react-dom,react-router-dom,react-syntax-highlighter,react-checkbox-tree,react-split-pane"},
+		{key: SortFirstRelease, want: "react,react-dom,react-split-pane,react-syntax-highlighter,react-checkbox-tree,react-router-dom"},
+		{key: SortVersions, want: "react,react-dom,react-syntax-highlighter,react-split-pane,react-router-dom,react-checkbox-tree"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"_"+tt.direction, func(t *testing.T) {
+			order, err := ParseComponentSort(tt.key, tt.direction)
+			if err != nil {
+				t.Fatalf("ParseComponentSort() error = %v", err)
+			}
+			component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+			component.SetSort(order)
+			// Page through the results two at a time, to check the cursor follows the sort order
+			var got []string
+			cursor := ""
+			for range 10 {
+				page, err := component.GetComponentsByNameType("react", "npm", 2, 0, cursor)
+				if err != nil {
+					t.Fatalf("components.GetComponentsByNameType() error = %v", err)
+				}
+				for _, c := range page.Components {
+					got = append(got, c.Component)
+				}
+				if !page.HasMore {
+					break
+				}
+				cursor = page.NextCursor
+			}
+			if joined := strings.Join(got, ","); joined != tt.want {
+				t.Errorf("GetComponentsByNameType() = %v, want %v", joined, tt.want)
+			}
+		})
+	}
+	// The sort also decides which components are kept for each purl type in cross-ecosystem searches
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	component.SetSort(ComponentSort{Key: SortName})
+	page, err := component.GetComponentsAllTypes("", "react", "", 0, 0, 2, "")
+	if err != nil {
+		t.Fatalf("components.GetComponentsAllTypes() error = %v", err)
+	}
+	var names []string
+	for _, c := range page.Components {
+		if c.PurlType == "npm" {
+			names = append(names, c.Component)
+		}
+	}
+	if !slices.Equal(names, []string{"react", "react-checkbox-tree"}) {
+		t.Errorf("GetComponentsAllTypes() npm components = %v, want [react react-checkbox-tree]", names)
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"container/list"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Suggestion settings.
+var (
+	defaultSuggestLimit      = 10
+	defaultMaxSuggestLimit   = 25
+	defaultSuggestCacheTTL   = 5 * time.Minute
+	defaultMaxSuggestEntries = 10000 // The least recently used entries are evicted beyond this size
+)
+
+// suggestionCaches holds the suggestion cache of each database (see SetDB).
+var suggestionCaches sync.Map
+
+// GetComponentSuggestions returns the most popular components whose name or purl name starts with the supplied
+// prefix (case-insensitive), for autocompletion. Unlike the search queries, a single LIKE 'prefix%' query is used,
+// which can be served by an index on lower(component) and lower(purl_name) (see README). Results are cached briefly
+// (per database, see SetDB), as the same prefixes are requested over and over while typing.
+// An empty purl type suggests across all types.
+func (m *ComponentModel) GetComponentSuggestions(prefix, purlType string, limit int) ([]Component, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if len(prefix) == 0 {
+		m.s.Error("Please specify a valid prefix to query")
+		return nil, errors.New("please specify a valid prefix to query")
+	}
+	if limit <= 0 {
+		limit = defaultSuggestLimit
+	}
+	limit = min(limit, defaultMaxSuggestLimit)
+	key := purlType + "\x00" + strconv.Itoa(limit) + "\x00" + prefix
+	if components, ok := m.suggestions.get(key); ok {
+		return components, nil
+	}
+	query := componentSelect +
+		" INNER JOIN mines m ON p.mine_id = m.id" +
+		` WHERE (lower(p.component) LIKE $1 ESCAPE '\' OR lower(p.purl_name) LIKE $1 ESCAPE '\')`
+	args := []any{escapeLike(prefix) + "%"}
+	if len(purlType) > 0 {
+		query += " AND m.purl_type = $2"
+		args = append(args, purlType)
+	} else {
+		query += " AND m.purl_type <> ''"
+	}
+	query += " ORDER BY p.git_stars DESC NULLS LAST, p.versions DESC NULLS LAST, p.purl_name LIMIT $" + strconv.Itoa(len(args)+1)
+	args = append(args, limit)
+	var components []Component
+	if err := m.q.SelectContext(m.ctx, &components, query, args...); err != nil {
+		m.s.Errorf("Failed to query component suggestions for %v: %v", prefix, err)
+		return nil, err
+	}
+	m.suggestions.put(key, components)
+	return components, nil
+}
+
+// escapeLike escapes the LIKE wildcards (and the escape character) in a value.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// suggestionCache is a bounded cache of suggestions, evicting the least recently used entries when it is full.
+// Entries also expire after a TTL, so the suggestions follow the database updates.
+type suggestionCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List               // Entries, most recently used first
+	entries  map[string]*list.Element // Entries by key
+}
+
+type suggestEntry struct {
+	key        string
+	components []Component
+	expires    time.Time
+}
+
+func newSuggestionCache(capacity int, ttl time.Duration) *suggestionCache {
+	return &suggestionCache{capacity: capacity, ttl: ttl, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// get returns the cached suggestions for a key, if they have not expired.
+func (c *suggestionCache) get(key string) ([]Component, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*suggestEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.components, true
+}
+
+// put caches the suggestions for a key, evicting the least recently used entry if the cache is full.
+func (c *suggestionCache) put(key string, components []Component) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &suggestEntry{key: key, components: components, expires: time.Now().Add(c.ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	if c.capacity <= 0 {
+		return
+	}
+	if c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*suggestEntry).key)
+	}
+	c.entries[key] = c.order.PushFront(entry)
+}
+
+// len returns the number of cached entries.
+func (c *suggestionCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
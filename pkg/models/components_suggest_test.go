@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestGetComponentSuggestions(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	if _, err = component.GetComponentSuggestions(" ", "", 0); err == nil {
+		t.Errorf("GetComponentSuggestions() expected an error for an empty prefix")
+	}
+	components, err := component.GetComponentSuggestions("ReAc", "npm", 3)
+	if err != nil {
+		t.Fatalf("GetComponentSuggestions() error = %v", err)
+	}
+	if len(components) != 3 || components[0].Component != "react" {
+		t.Fatalf("GetComponentSuggestions() expected react first, got %+v", components)
+	}
+	for i := 1; i < len(components); i++ {
+		if components[i].GitStars.Int64 > components[i-1].GitStars.Int64 {
+			t.Errorf("suggestions are not sorted by popularity: %+v", components)
+		}
+	}
+	// Purl names (i.e. vendor/name) are matched too, and wildcards in the prefix are literal
+	if components, err = component.GetComponentSuggestions("angular/", "github", 5); err != nil || len(components) != 1 ||
+		components[0].PurlName != "angular/angular" {
+		t.Errorf("GetComponentSuggestions() expected angular/angular, got %+v (%v)", components, err)
+	}
+	if components, err = component.GetComponentSuggestions("re%", "", 0); err != nil || len(components) != 0 {
+		t.Errorf("GetComponentSuggestions() expected no matches for a literal %%, got %+v (%v)", components, err)
+	}
+	if got, ok := component.suggestions.get("npm\x003\x00reac"); !ok || len(got) != 3 {
+		t.Errorf("expected the suggestions to be cached, got %v (%v)", got, ok)
+	}
+}
+
+func TestSuggestionCache(t *testing.T) {
+	cache := newSuggestionCache(2, time.Minute)
+	cache.put("a", []Component{{Component: "a"}})
+	cache.put("b", []Component{{Component: "b"}})
+	if _, ok := cache.get("a"); !ok { // a is now the most recently used
+		t.Fatalf("expected a to be cached")
+	}
+	cache.put("c", []Component{{Component: "c"}})
+	if _, ok := cache.get("b"); ok || cache.len() != 2 {
+		t.Errorf("expected b (the least recently used) to be evicted, got %d entries", cache.len())
+	}
+	if got, ok := cache.get("a"); !ok || got[0].Component != "a" {
+		t.Errorf("expected a to be cached, got %v (%v)", got, ok)
+	}
+	// Expired entries are not returned
+	cache = newSuggestionCache(2, -time.Second)
+	cache.put("a", nil)
+	if _, ok := cache.get("a"); ok || cache.len() != 0 {
+		t.Errorf("expected the expired entry to be removed")
+	}
+	// A disabled cache stores nothing
+	cache = newSuggestionCache(0, time.Minute)
+	if cache.put("a", nil); cache.len() != 0 {
+		t.Errorf("expected nothing to be cached")
+	}
+}
+
+// suggestionsModel returns a model of a database loaded with the test data, and a function to close it.
+func suggestionsModel(tb testing.TB) (*ComponentModel, func()) {
+	tb.Helper()
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		tb.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	zlog.SetLevel("warn")
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(tb)
+	conn := sqliteConn(tb, ctx, db)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		tb.Fatalf("failed to load SQL test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	return component, func() {
+		CloseConn(conn)
+		CloseDB(db)
+		zlog.SyncZap()
+	}
+}
+
+// suggestLatencyTarget is the target latency of an (uncached) suggestion lookup.
+const suggestLatencyTarget = 10 * time.Millisecond
+
+func TestGetComponentSuggestionsLatency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the latency test in short mode")
+	}
+	component, cleanup := suggestionsModel(t)
+	defer cleanup()
+	component.suggestions = newSuggestionCache(0, 0) // Measure the database lookups
+	result := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := component.GetComponentSuggestions("rea", "npm", 0); err != nil {
+				b.Fatalf("GetComponentSuggestions() error = %v", err)
+			}
+		}
+	})
+	if latency := time.Duration(result.NsPerOp()); latency > suggestLatencyTarget {
+		t.Errorf("GetComponentSuggestions() took %v per lookup, want at most %v", latency, suggestLatencyTarget)
+	}
+}
+
+func BenchmarkGetComponentSuggestions(b *testing.B) {
+	component, cleanup := suggestionsModel(b)
+	defer cleanup()
+	prefixes := []string{"r", "re", "rea", "reac", "react", "a", "an", "ang", "angu", "angul"}
+	b.Run("uncached", func(b *testing.B) {
+		component.suggestions = newSuggestionCache(0, 0)
+		for i := 0; i < b.N; i++ {
+			if _, err := component.GetComponentSuggestions(prefixes[i%len(prefixes)], "", 0); err != nil {
+				b.Fatalf("GetComponentSuggestions() error = %v", err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		component.suggestions = newSuggestionCache(defaultMaxSuggestEntries, defaultSuggestCacheTTL)
+		for i := 0; i < b.N; i++ {
+			if _, err := component.GetComponentSuggestions(prefixes[i%len(prefixes)], "", 0); err != nil {
+				b.Fatalf("GetComponentSuggestions() error = %v", err)
+			}
+		}
+	})
+	b.Run("evicting", func(b *testing.B) {
+		component.suggestions = newSuggestionCache(len(prefixes), defaultSuggestCacheTTL)
+		for i := 0; i < b.N; i++ {
+			if _, err := component.GetComponentSuggestions("rea"+strconv.Itoa(i%(2*len(prefixes))), "", 0); err != nil {
+				b.Fatalf("GetComponentSuggestions() error = %v", err)
+			}
+		}
+	})
+}
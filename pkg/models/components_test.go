@@ -82,115 +82,157 @@ func TestComponentsModel(t *testing.T) {
 			test.Limit,
 			test.Offset)
 
-		components, err := component.GetComponents(test.SearchParam, test.PurlType, test.Limit, test.Offset)
+		components, err := component.GetComponents(test.SearchParam, test.PurlType, test.Limit, test.Offset, "")
 		if err != nil {
 			t.Errorf("components.GetComponents() error = %v", err)
 		}
 		fmt.Printf("Components: %v\n", components)
 
-		components, err = component.GetComponentsByNameType(test.SearchParam, test.PurlType, test.Limit, test.Offset)
+		components, err = component.GetComponentsByNameType(test.SearchParam, test.PurlType, test.Limit, test.Offset, "")
 		if err != nil {
 			t.Errorf("components.GetComponentsByNameType() error = %v", err)
 		}
 		fmt.Printf("Components: %v\n", components)
 
-		components, err = component.GetComponentsByVendorType(test.SearchParam, test.PurlType, test.Limit, test.Offset)
+		components, err = component.GetComponentsByVendorType(test.SearchParam, test.PurlType, test.Limit, test.Offset, "")
 		if err != nil {
 			t.Errorf("components.GetComponentsByVendorType() error = %v", err)
 		}
 		fmt.Printf("Components: %v\n", components)
 
-		components, err = component.GetComponentsByNameVendorType(test.SearchParam, test.SearchParam, test.PurlType, test.Limit, test.Offset)
+		components, err = component.GetComponentsByNameVendorType(test.SearchParam, test.SearchParam, test.PurlType, test.Limit, test.Offset, "")
 		if err != nil {
 			t.Errorf("components.GetComponentsByVendorType() error = %v", err)
 		}
 		fmt.Printf("Components: %v\n", components)
 	}
 
-	_, err = component.GetComponents("", "", 0, 0)
+	_, err = component.GetComponents("", "", 0, 0, "")
 	if err == nil {
 		t.Errorf("An error was expected")
 	}
 
-	_, err = component.GetComponentsByNameType("", "", 0, 0)
+	_, err = component.GetComponentsByNameType("", "", 0, 0, "")
 	if err == nil {
 		t.Errorf("An error was expected")
 	}
 
-	_, err = component.GetComponentsByVendorType("", "", 0, 0)
+	_, err = component.GetComponentsByVendorType("", "", 0, 0, "")
 	if err == nil {
 		t.Errorf("An error was expected")
 	}
 
-	_, err = component.GetComponentsByNameVendorType("", "", "", 0, 0)
+	_, err = component.GetComponentsByNameVendorType("", "", "", 0, 0, "")
 	if err == nil {
 		t.Errorf("An error was expected")
 	}
 }
 
-func TestPreProcessQueryJobs(t *testing.T) {
+func TestPurlTypeOrder(t *testing.T) {
 	testTable := []struct {
-		qList    []QueryJob
 		purlType string
-		wanted   []QueryJob
+		wanted   string
 	}{
-		{
-			qList: []QueryJob{
-				{
-					Query: "SELECT * project #ORDER LIMIT $1",
-					Args:  []any{0},
-				},
-				{
-					Query: "SELECT * project #ORDER LIMIT $1",
-					Args:  []any{0},
-				},
-			},
-			purlType: "github",
-			wanted: []QueryJob{
-				{
-					Query: "SELECT * project ORDER BY git_created_at NULLS LAST , git_forks DESC NULLS LAST, git_stars DESC NULLS LAST LIMIT $1",
-					Args:  []any{0},
-				},
-				{
-					Query: "SELECT * project ORDER BY git_created_at NULLS LAST , git_forks DESC NULLS LAST, git_stars DESC NULLS LAST LIMIT $1",
-					Args:  []any{0},
-				},
-			},
-		},
-		{
-			qList: []QueryJob{{
-				Query: "SELECT * FROM project #ORDER",
-			}},
-			purlType: "NONEXISTENT",
-			wanted: []QueryJob{
-				{
-					Query: "SELECT * FROM project",
-				},
-			},
-		},
-		{
-			qList: []QueryJob{
-				{Query: "SELECT * FROM project"},
-			},
-			purlType: "github",
-			wanted: []QueryJob{
-				{Query: "SELECT * FROM project"},
-			},
-		},
+		{purlType: "github", wanted: "git_created_at ASC NULLS LAST, git_forks DESC NULLS LAST, git_stars DESC NULLS LAST"},
+		{purlType: "maven", wanted: "first_version_date ASC NULLS LAST, versions ASC NULLS LAST"},
+		{purlType: "NONEXISTENT", wanted: ""},
 	}
-
+	var component ComponentModel
 	for _, testInput := range testTable {
-		q, err := preProcessQueryJob(testInput.qList, testInput.purlType)
-		if err != nil {
-			t.Errorf("Error produced when pre processing QueryJobs: %v\n", err)
+		if got, configured := component.purlTypeOrder(testInput.purlType); orderByTerms(got) != testInput.wanted || configured {
+			t.Errorf("unexpected output for purlTypeOrder(%v)\nWanted: %v\nGot: %v", testInput.purlType, testInput.wanted, orderByTerms(got))
 		}
-		if !cmp.Equal(q, testInput.wanted) {
-			t.Errorf(" unexpected output for preProcessQueryJob()\nWanted: %v\n, Got:%v\n", testInput.wanted, q)
+	}
+	// Every mine has a default ordering, which can be overridden per purl type
+	component.SetSearchOrder(map[string]string{"maven": "ORDER BY versions DESC, unknown, git_stars NULLS FIRST"})
+	if got, configured := component.purlTypeOrder("maven"); orderByTerms(got) != "versions DESC NULLS LAST, git_stars ASC NULLS FIRST" || !configured {
+		t.Errorf("unexpected configured maven ordering: %v", orderByTerms(got))
+	}
+	if got, configured := component.purlTypeOrder("github"); !cmp.Equal(got, parseOrderByClause(gitOrderByClause), cmp.AllowUnexported(sortTerm{})) || configured {
+		t.Errorf("unexpected github ordering after an override: %v", got)
+	}
+}
+
+func TestDefaultOrderByClauses(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	var purlTypes []string
+	if err = conn.SelectContext(ctx, &purlTypes, "SELECT DISTINCT purl_type FROM mines WHERE purl_type <> ''"); err != nil {
+		t.Fatalf("failed to query the mines: %v", err)
+	}
+	for _, purlType := range purlTypes {
+		if len(defaultOrderByClauses[purlType]) == 0 {
+			t.Errorf("no default ordering for purl type %v", purlType)
 		}
 	}
+}
 
-	_, err := preProcessQueryJob([]QueryJob{}, "")
-	if err == nil {
-		t.Errorf("An error was expected with empty parameters \n")
+func TestGetComponentsAllTypes(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	err = LoadTestSQLData(db, ctx, conn)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+
+	page, err := component.GetComponentsAllTypes("e", "", "", 0, 0, 2, "")
+	if err != nil {
+		t.Fatalf("components.GetComponentsAllTypes() error = %v", err)
+	}
+	components := page.Components
+	perType := make(map[string]int)
+	for _, c := range components {
+		if len(c.PurlType) == 0 {
+			t.Errorf("expected a purl type for %v", c.PurlName)
+		}
+		perType[c.PurlType]++
+	}
+	if len(perType) < 2 {
+		t.Errorf("expected results from more than one purl type: %v", perType)
+	}
+	for purlType, count := range perType {
+		if count > 2 {
+			t.Errorf("expected at most 2 results for %v, got %d", purlType, count)
+		}
+	}
+	offsetPage, err := component.GetComponentsAllTypes("e", "", "", 0, 1, 2, "")
+	if err != nil {
+		t.Fatalf("components.GetComponentsAllTypes() error = %v", err)
+	}
+	if len(offsetPage.Components) != len(components)-1 || offsetPage.Components[0] != components[1] {
+		t.Errorf("expected the offset to skip one result: %d vs %d", len(offsetPage.Components), len(components))
+	}
+	page, err = component.GetComponentsAllTypes("angular", "", "", 5, 0, 0, "")
+	if err != nil || len(page.Components) == 0 || page.Components[0].PurlName != "angular/angular" {
+		t.Errorf("expected angular/angular to be ranked first: %v, %v", page.Components, err)
+	}
+	for _, test := range []struct{ compName, vendor string }{{"angular", ""}, {"", "angular"}, {"angular", "angular"}} {
+		if _, err = component.GetComponentsAllTypes("", test.compName, test.vendor, 5, 0, 0, ""); err != nil {
+			t.Errorf("components.GetComponentsAllTypes(%q, %q) error = %v", test.compName, test.vendor, err)
+		}
+	}
+	if _, err = component.GetComponentsAllTypes("", "", "", 0, 0, 0, ""); err == nil {
+		t.Errorf("An error was expected")
 	}
 }
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// ErrInvalidCursor is returned when a search cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid search cursor")
+
+// ComponentPage is a single page of ranked component search results.
+type ComponentPage struct {
+	Components []Component
+	Total      int              // Total number of matches
+	HasMore    bool             // There are more results after this page
+	NextCursor string           // Opaque cursor to request the next page (empty if there are no more results)
+	Facets     *ComponentFacets // Facet counts of every match (only counted if requested, see SetFacets)
+}
+
+// encodeCursor encodes the sort term values of the last result in a page as an opaque cursor.
+func encodeCursor(values []any) string {
+	data, _ := json.Marshal(values)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor decodes an opaque cursor back into the values of the sort terms, checking they match the terms.
+func decodeCursor(cursor string, terms []sortTerm) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var values []any
+	if err = json.Unmarshal(data, &values); err != nil || len(values) != len(terms) {
+		return nil, ErrInvalidCursor
+	}
+	for i, t := range terms {
+		switch v := values[i].(type) {
+		case nil:
+			if !t.nullable() {
+				return nil, ErrInvalidCursor
+			}
+		case float64:
+			if !isNumericColumn(t.column) {
+				return nil, ErrInvalidCursor
+			}
+			values[i] = int64(v)
+		case string:
+			if isNumericColumn(t.column) {
+				return nil, ErrInvalidCursor
+			}
+		default:
+			return nil, ErrInvalidCursor
+		}
+	}
+	return values, nil
+}
+
+// componentCursor is the position of the last component returned in a page of matches ranked in code
+// (see RankFuzzyComponents).
+type componentCursor struct {
+	Score    float64 `json:"s"`
+	Value    string  `json:"v,omitempty"` // Sort key value (when not sorting by relevance)
+	Null     bool    `json:"z,omitempty"` // The component has no sort key value
+	PurlType string  `json:"t"`
+	PurlName string  `json:"n"`
+}
+
+// sortComponents sorts components in a deterministic order: descending score, then purl type and purl name.
+func sortComponents(components []Component) {
+	ComponentSort{}.sortComponents(components)
+}
+
+// encodeMatchCursor encodes the position of the given component in the sort order as an opaque cursor.
+func encodeMatchCursor(c Component, order ComponentSort) string {
+	data, _ := json.Marshal(order.position(c))
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeMatchCursor decodes an opaque cursor back into its position.
+func decodeMatchCursor(cursor string) (componentCursor, error) {
+	var pos componentCursor
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return pos, ErrInvalidCursor
+	}
+	if err = json.Unmarshal(data, &pos); err != nil || len(pos.PurlType) == 0 { // Components may have no purl name
+		return pos, ErrInvalidCursor
+	}
+	return pos, nil
+}
+
+// paginateComponents returns the requested page of the components (sorted by relevance). When a cursor is supplied,
+// the page starts after the cursor position and the offset is ignored.
+func paginateComponents(components []Component, limit, offset int, cursor string) (ComponentPage, error) {
+	return paginateSorted(components, ComponentSort{}, limit, offset, cursor)
+}
+
+// paginateSorted returns the requested page of the components, which must already be sorted in the given order.
+func paginateSorted(components []Component, order ComponentSort, limit, offset int, cursor string) (ComponentPage, error) {
+	page := ComponentPage{Total: len(components)}
+	start := min(offset, len(components))
+	if len(cursor) > 0 {
+		pos, err := decodeMatchCursor(cursor)
+		if err != nil {
+			return ComponentPage{}, err
+		}
+		start = sort.Search(len(components), func(i int) bool {
+			c := components[i]
+			return !order.less(c, pos) && order.position(c) != pos
+		})
+	}
+	end := min(start+limit, len(components))
+	page.Components = components[start:end]
+	if end < len(components) && end > start {
+		page.HasMore = true
+		page.NextCursor = encodeMatchCursor(components[end-1], order)
+	}
+	return page, nil
+}
@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestPaginateComponents(t *testing.T) {
+	components := []Component{
+		{PurlType: "npm", PurlName: "b", Score: 10},
+		{PurlType: "github", PurlName: "z/a", Score: 50},
+		{PurlType: "npm", PurlName: "a", Score: 10},
+		{PurlType: "github", PurlName: "x/a", Score: 10},
+		{PurlType: "pypi", PurlName: "c", Score: 5},
+	}
+	sortComponents(components)
+	want := []string{"z/a", "x/a", "a", "b", "c"}
+	for i, w := range want {
+		if components[i].PurlName != w {
+			t.Fatalf("position %d: got %v, want %v", i, components[i].PurlName, w)
+		}
+	}
+	var got []string
+	cursor := ""
+	for pages := 0; pages < 10; pages++ {
+		page, err := paginateComponents(components, 2, 0, cursor)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if page.Total != len(components) {
+			t.Errorf("total = %d, want %d", page.Total, len(components))
+		}
+		for _, c := range page.Components {
+			got = append(got, c.PurlName)
+		}
+		if page.HasMore != (len(page.NextCursor) > 0) {
+			t.Errorf("has more (%v) does not match the next cursor (%q)", page.HasMore, page.NextCursor)
+		}
+		if !page.HasMore {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if len(got) != len(want) {
+		t.Fatalf("paged through %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("page order %v, want %v", got, want)
+			break
+		}
+	}
+	// Offsets are still supported when no cursor is supplied
+	page, _ := paginateComponents(components, 2, 3, "")
+	if len(page.Components) != 2 || page.Components[0].PurlName != "b" || page.HasMore {
+		t.Errorf("unexpected offset page: %+v", page)
+	}
+	page, _ = paginateComponents(components, 2, 10, "")
+	if len(page.Components) != 0 || page.HasMore {
+		t.Errorf("expected an empty page past the end: %+v", page)
+	}
+	for _, cursor := range []string{"not-base64!", "bm90LWpzb24", "e30"} {
+		if _, err := paginateComponents(components, 2, 0, cursor); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("expected an invalid cursor error for %q, got %v", cursor, err)
+		}
+	}
+}
+
+func TestGetComponentsCursor(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	all, err := component.GetComponentsByNameType("angular", "github", 0, 0, "")
+	if err != nil {
+		t.Fatalf("components.GetComponentsByNameType() error = %v", err)
+	}
+	if all.Total <= len(all.Components) || !all.HasMore {
+		t.Fatalf("expected more matches than fit in a single page: total %d, page %d", all.Total, len(all.Components))
+	}
+	seen := make(map[string]bool)
+	cursor := ""
+	for {
+		page, err := component.GetComponentsByNameType("angular", "github", 20, 0, cursor)
+		if err != nil {
+			t.Fatalf("components.GetComponentsByNameType() error = %v", err)
+		}
+		for _, c := range page.Components {
+			if seen[c.PurlName] {
+				t.Errorf("%v returned more than once", c.PurlName)
+			}
+			seen[c.PurlName] = true
+		}
+		if !page.HasMore {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if len(seen) != all.Total {
+		t.Errorf("paged through %d components, want %d", len(seen), all.Total)
+	}
+}
+
+func TestGetComponentsCursorNullNames(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	component := NewComponentModel(ctx, s, database.NewDBSelectContext(s, db, conn, false), database.GetLikeOperator(db))
+	before, err := component.GetComponentsAllTypes("", "angular", "", 0, 0, 0, "")
+	if err != nil || len(before.Components) < 3 {
+		t.Fatalf("expected several angular components: %v - %+v", err, before)
+	}
+	// Recreate the projects without the NOT NULL constraints, and drop the purl name of a match of each purl type
+	statements := []string{
+		"CREATE TABLE projects_nullable AS SELECT * FROM projects",
+		"DROP TABLE projects",
+		"ALTER TABLE projects_nullable RENAME TO projects",
+	}
+	nullTypes := make(map[string]bool)
+	for _, c := range before.Components[1:] {
+		if nullTypes[c.PurlType] {
+			continue
+		}
+		nullTypes[c.PurlType] = true
+		statements = append(statements, fmt.Sprintf("UPDATE projects SET purl_name = NULL WHERE purl_name = '%s'"+
+			" AND mine_id IN (SELECT id FROM mines WHERE purl_type = '%s')", c.PurlName, c.PurlType))
+	}
+	for _, statement := range statements {
+		if _, err = conn.ExecContext(ctx, statement); err != nil {
+			t.Fatalf("an error '%s' was not expected when executing %q", err, statement)
+		}
+	}
+	for _, sort := range []ComponentSort{{}, {Key: SortName}} {
+		component.SetSort(sort)
+		all, err := component.GetComponentsAllTypes("", "angular", "", 0, 0, 0, "")
+		if err != nil {
+			t.Fatalf("components.GetComponentsAllTypes() error = %v", err)
+		}
+		seen := make(map[string]bool)
+		var nullNames, paged int
+		cursor := ""
+		for {
+			page, err := component.GetComponentsAllTypes("", "angular", "", 3, 0, 0, cursor)
+			if err != nil {
+				t.Fatalf("components.GetComponentsAllTypes() error = %v", err)
+			}
+			for _, c := range page.Components {
+				seen[c.PurlType+"/"+c.PurlName] = true
+				if len(c.PurlName) == 0 {
+					nullNames++
+				}
+				paged++
+			}
+			if !page.HasMore {
+				break
+			}
+			cursor = page.NextCursor
+		}
+		if paged != all.Total || len(seen) != all.Total {
+			t.Errorf("sort %+v: paged through %d components (%d unique), want %d", sort, paged, len(seen), all.Total)
+		}
+		if nullNames != len(nullTypes) {
+			t.Errorf("sort %+v: returned %d components without a purl name, want %d", sort, nullNames, len(nullTypes))
+		}
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	terms := []sortTerm{{column: "score", descending: true}, {column: "git_created_at"}, {column: "purl_type"}, {column: "purl_name"}}
+	values := []any{int64(12345), nil, "github", "angular/angular"}
+	got, err := decodeCursor(encodeCursor(values), terms)
+	if err != nil || len(got) != len(values) {
+		t.Fatalf("decodeCursor() = %v (%v), want %v", got, err, values)
+	}
+	for i := range values {
+		if got[i] != values[i] {
+			t.Errorf("decodeCursor() value %d = %#v, want %#v", i, got[i], values[i])
+		}
+	}
+	invalid := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "not json", cursor: "bm90IGpzb24"},
+		{name: "wrong length", cursor: encodeCursor(values[:3])},
+		{name: "text score", cursor: encodeCursor([]any{"high", nil, "github", "angular/angular"})},
+		{name: "numeric name", cursor: encodeCursor([]any{int64(1), nil, "github", int64(2)})},
+		{name: "null purl type", cursor: encodeCursor([]any{int64(1), nil, nil, "angular/angular"})},
+		{name: "object value", cursor: encodeCursor([]any{int64(1), map[string]any{}, "github", "angular/angular"})},
+	}
+	for _, tt := range invalid {
+		if _, err = decodeCursor(tt.cursor, terms); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("decodeCursor(%v) expected an invalid cursor error, got %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+CREATE VIRTUAL TABLE projects_fts USING fts5(component, vendor, purl_name, content='projects');
+INSERT INTO projects_fts(projects_fts) VALUES ('rebuild');
@@ -0,0 +1,5 @@
+insert into projects (mine_id, vendor, component, first_version_date, latest_version_date, license, versions, source_vendor, source_component, git_created_at, git_updated_at, git_pushed_at, git_stars, git_issues, git_forks, git_license, source_mine_id, purl_name, source_purl_name, verified, license_id, git_license_id) values (0, 'The Apache Software Foundation', 'commons-lang3', '2011-07-19', '2021-03-01', 'Apache-2.0', 14, 'apache', 'commons-lang', '2009-05-21', '2022-01-10', '2022-01-10', 2401, 0, 1418, 'Apache-2.0', 5, 'org.apache.commons/commons-lang3', 'apache/commons-lang', '2022-01-11', null, null);
+insert into projects (mine_id, vendor, component, first_version_date, latest_version_date, license, versions, source_vendor, source_component, git_created_at, git_updated_at, git_pushed_at, git_stars, git_issues, git_forks, git_license, source_mine_id, purl_name, source_purl_name, verified, license_id, git_license_id) values (0, 'The Apache Software Foundation', 'commons-text', '2017-03-20', '2020-07-26', 'Apache-2.0', 9, 'apache', 'commons-text', '2014-07-21', '2022-01-10', '2022-01-09', 229, 0, 213, 'Apache-2.0', 5, 'org.apache.commons/commons-text', 'apache/commons-text', '2022-01-11', null, null);
+insert into projects (mine_id, vendor, component, first_version_date, latest_version_date, license, versions, source_vendor, source_component, git_created_at, git_updated_at, git_pushed_at, git_stars, git_issues, git_forks, git_license, source_mine_id, purl_name, source_purl_name, verified, license_id, git_license_id) values (0, 'The Apache Software Foundation', 'commons-io', '2002-06-20', '2021-07-15', 'Apache-2.0', 31, 'apache', 'commons-io', '2009-05-21', '2022-01-10', '2022-01-10', 883, 0, 588, 'Apache-2.0', 5, 'commons-io/commons-io', 'apache/commons-io', '2022-01-11', null, null);
+insert into projects (mine_id, vendor, component, first_version_date, latest_version_date, license, versions, source_vendor, source_component, git_created_at, git_updated_at, git_pushed_at, git_stars, git_issues, git_forks, git_license, source_mine_id, purl_name, source_purl_name, verified, license_id, git_license_id) values (2, 'babel', '@babel/core', '2015-05-27', '2021-12-06', 'MIT', 141, 'babel', 'babel', '2014-09-28', '2022-01-12', '2022-01-12', 39510, 731, 4771, 'MIT', 5, '@babel/core', 'babel/babel', '2022-01-11', 5614, null);
+insert into projects (mine_id, vendor, component, first_version_date, latest_version_date, license, versions, source_vendor, source_component, git_created_at, git_updated_at, git_pushed_at, git_stars, git_issues, git_forks, git_license, source_mine_id, purl_name, source_purl_name, verified, license_id, git_license_id) values (2, 'babel', '@babel/parser', '2017-09-28', '2021-12-06', 'MIT', 110, 'babel', 'babel', '2014-09-28', '2022-01-12', '2022-01-12', 39510, 731, 4771, 'MIT', 5, '@babel/parser', 'babel/babel', '2022-01-11', 5614, null);
@@ -23,11 +23,12 @@ import (
 	gs "github.com/scanoss/go-grpc-helper/pkg/grpc/server"
 	pb "github.com/scanoss/papi/api/componentsv2"
 	"google.golang.org/grpc"
+	"scanoss.com/components/pkg/api"
 	myconfig "scanoss.com/components/pkg/config"
 )
 
-// RunServer runs gRPC service to publish.
-func RunServer(config *myconfig.ServerConfig, v2API pb.ComponentsServer, port string,
+// RunServer runs gRPC service to publish (along with its ComponentsExtension service).
+func RunServer(config *myconfig.ServerConfig, v2API pb.ComponentsServer, extAPI api.ComponentsExtensionServer, port string,
 	allowedIPs, deniedIPs []string, startTLS bool) (*grpc.Server, error) {
 	// Start up Open Telemetry is requested
 	var oltpShutdown = func() {}
@@ -49,6 +50,7 @@ func RunServer(config *myconfig.ServerConfig, v2API pb.ComponentsServer, port st
 	}
 	// Register the service API and start the server in the background
 	pb.RegisterComponentsServer(server, v2API)
+	api.RegisterComponentsExtensionServer(server, extAPI)
 	go func() {
 		gs.StartGrpcServer(listen, server, startTLS)
 		oltpShutdown()
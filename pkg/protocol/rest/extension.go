@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	common "github.com/scanoss/papi/api/commonv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"scanoss.com/components/pkg/api"
+)
+
+// maxExtensionRequestSize is the maximum size of a ComponentsExtension REST request body.
+const maxExtensionRequestSize = 1 << 20
+
+// registerExtensionHandler forwards the ComponentsExtension REST requests (a POST of the JSON request to
+// ExtensionRESTPath/<method>) to the gRPC server, the same way the gateway forwards the componentsv2 requests.
+func registerExtensionHandler(srv *http.Server, mux *runtime.ServeMux, grpcGateway string, opts []grpc.DialOption) error {
+	conn, err := grpc.NewClient(grpcGateway, opts...)
+	if err != nil {
+		return err
+	}
+	srv.RegisterOnShutdown(func() { _ = conn.Close() })
+	return mux.HandlePath(http.MethodPost, api.ExtensionRESTPath+"/{method}", func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		method := params["method"]
+		if !api.IsExtensionMethod(method) {
+			writeExtensionError(w, http.StatusNotFound, "Unknown method: "+method)
+			return
+		}
+		body, failure, message := readExtensionRequest(w, r)
+		if failure != 0 {
+			writeExtensionError(w, failure, message)
+			return
+		}
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, api.ExtensionMethod(method))
+		if err != nil {
+			writeExtensionError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var resp json.RawMessage
+		var trailer metadata.MD
+		err = conn.Invoke(ctx, api.ExtensionMethod(method), json.RawMessage(body), &resp,
+			grpc.CallContentSubtype(api.JSONCodecName), grpc.Trailer(&trailer))
+		if err != nil {
+			st := status.Convert(err)
+			writeExtensionError(w, runtime.HTTPStatusFromCode(st.Code()), st.Message())
+			return
+		}
+		code := http.StatusOK
+		if values := trailer.Get(api.HTTPCodeTrailer); len(values) > 0 {
+			if c, err := strconv.Atoi(values[0]); err == nil && c >= 100 && c <= 599 {
+				code = c
+			}
+		}
+		writeExtensionResponse(w, code, resp)
+	})
+}
+
+// readExtensionRequest reads the JSON body of a ComponentsExtension REST request. If it is too large or is not valid
+// JSON, the HTTP code and message of the failure are returned instead.
+func readExtensionRequest(w http.ResponseWriter, r *http.Request) ([]byte, int, string) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxExtensionRequestSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request larger than %d bytes", tooLarge.Limit)
+		}
+		return nil, http.StatusBadRequest, "Invalid JSON request"
+	}
+	if !json.Valid(body) {
+		return nil, http.StatusBadRequest, "Invalid JSON request"
+	}
+	return body, 0, ""
+}
+
+// writeExtensionError writes a failure status as the response of a ComponentsExtension REST request.
+func writeExtensionError(w http.ResponseWriter, code int, message string) {
+	data, _ := json.Marshal(struct {
+		Status api.ExtensionStatus `json:"status"`
+	}{Status: api.ExtensionStatus{StatusResponse: &common.StatusResponse{Status: common.StatusCode_FAILED, Message: message}}})
+	writeExtensionResponse(w, code, data)
+}
+
+// writeExtensionResponse writes the JSON response of a ComponentsExtension REST request.
+func writeExtensionResponse(w http.ResponseWriter, code int, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(data); err != nil {
+		zlog.S.Debugf("Failed to write the response: %v", err)
+	}
+}
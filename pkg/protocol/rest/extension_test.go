@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadExtensionRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{name: "valid", body: `{"purl": "pkg:npm/react"}`},
+		{name: "invalid json", body: `{"purl": `, wantCode: http.StatusBadRequest},
+		{name: "at the limit", body: `"` + strings.Repeat("a", maxExtensionRequestSize-2) + `"`},
+		{name: "too large", body: `"` + strings.Repeat("a", maxExtensionRequestSize) + `"`, wantCode: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v2/components/ext/ResolveVersion", strings.NewReader(tt.body))
+			body, code, message := readExtensionRequest(httptest.NewRecorder(), r)
+			if code != tt.wantCode {
+				t.Errorf("readExtensionRequest() code = %v (%v), want %v", code, message, tt.wantCode)
+			}
+			if code == 0 && string(body) != tt.body {
+				t.Errorf("readExtensionRequest() returned an unexpected body of %d bytes", len(body))
+			}
+		})
+	}
+}
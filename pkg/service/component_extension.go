@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	gomodels "github.com/scanoss/go-models/pkg/models"
+	common "github.com/scanoss/papi/api/commonv2"
+	"go.uber.org/zap"
+	"scanoss.com/components/pkg/api"
+	myconfig "scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	se "scanoss.com/components/pkg/errors"
+	"scanoss.com/components/pkg/usecase"
+)
+
+// componentExtensionServer implements the ComponentsExtension service, sharing the database and config of the
+// componentsv2 service.
+type componentExtensionServer struct {
+	componentServer
+}
+
+func NewComponentExtensionServer(db *sqlx.DB, config *myconfig.ServerConfig) api.ComponentsExtensionServer {
+	return &componentExtensionServer{componentServer{
+		db:             db,
+		config:         config,
+		dbVersionModel: gomodels.NewDBVersionModel(db),
+	}}
+}
+
+// SearchComponents searches for components using the whole search input, returning the results along with their
+// pagination (and any suggestions, facets or details) in the response.
+func (d componentExtensionServer) SearchComponents(ctx context.Context, request *dtos.ComponentSearchInput) (*api.SearchComponentsResponse, error) {
+	requestStartTime := time.Now() // Capture the scan start time
+	s := ctxzap.Extract(ctx).Sugar()
+	s.Info("Processing component search request...")
+	if !hasSearchTerms(*request) {
+		return &api.SearchComponentsResponse{Status: d.failureStatus(ctx, s, se.NewBadRequestError("No data supplied", nil))}, nil
+	}
+	compUc := usecase.NewComponents(ctx, s, d.db, database.NewDBSelectContext(s, d.db, nil, d.config.Database.Trace), d.config.GetStatusMapper())
+	compUc.SetSearchOrder(d.config.GetSearchOrder())
+	output, err := compUc.SearchComponents(*request)
+	if err != nil {
+		return &api.SearchComponentsResponse{Status: d.failureStatus(ctx, s, err)}, nil
+	}
+	telemetryCompNameRequestTime(ctx, d.config, requestStartTime) // Record the request processing time
+	return &api.SearchComponentsResponse{Status: d.successStatus(), ComponentsSearchOutput: output}, nil
+}
+
+// SuggestComponents returns the most popular components whose name or purl name starts with the requested prefix,
+// for autocompletion.
+func (d componentExtensionServer) SuggestComponents(ctx context.Context, request *dtos.ComponentSuggestInput) (*api.SuggestComponentsResponse, error) {
+	requestStartTime := time.Now() // Capture the scan start time
+	s := ctxzap.Extract(ctx).Sugar()
+	s.Info("Processing component suggest request...")
+	compUc := usecase.NewComponents(ctx, s, d.db, database.NewDBSelectContext(s, d.db, nil, d.config.Database.Trace), d.config.GetStatusMapper())
+	output, err := compUc.SuggestComponents(*request)
+	if err != nil {
+		return &api.SuggestComponentsResponse{Status: d.failureStatus(ctx, s, err)}, nil
+	}
+	telemetryCompNameRequestTime(ctx, d.config, requestStartTime) // Record the request processing time
+	return &api.SuggestComponentsResponse{Status: d.successStatus(), ComponentsSearchOutput: output}, nil
+}
+
+// LookupURL returns the purls (and versions) of a repository, registry or download URL.
+func (d componentExtensionServer) LookupURL(ctx context.Context, request *dtos.ComponentURLInput) (*api.LookupURLResponse, error) {
+	requestStartTime := time.Now() // Capture the scan start time
+	s := ctxzap.Extract(ctx).Sugar()
+	s.Info("Processing URL lookup request...")
+	compUc := usecase.NewComponents(ctx, s, d.db, database.NewDBSelectContext(s, d.db, nil, d.config.Database.Trace), d.config.GetStatusMapper())
+	output, err := compUc.LookupURL(*request)
+	if err != nil {
+		return &api.LookupURLResponse{Status: d.failureStatus(ctx, s, err)}, nil
+	}
+	telemetryCompNameRequestTime(ctx, d.config, requestStartTime) // Record the request processing time
+	return &api.LookupURLResponse{Status: d.successStatus(), ComponentsSearchOutput: output}, nil
+}
+
+// GetComponentVersions lists the versions of a component using the whole versions input, returning the license
+// expression, status and requirement match of each version in the response.
+func (d componentExtensionServer) GetComponentVersions(ctx context.Context, request *dtos.ComponentVersionsInput) (*api.ComponentVersionsResponse, error) {
+	requestStartTime := time.Now() // Capture the scan start time
+	s := ctxzap.Extract(ctx).Sugar()
+	s.Info("Processing component versions request...")
+	if len(request.Purl) == 0 {
+		return &api.ComponentVersionsResponse{Status: d.failureStatus(ctx, s, se.NewBadRequestError("No purl supplied", nil))}, nil
+	}
+	compUc := usecase.NewComponents(ctx, s, d.db, database.NewDBSelectContext(s, d.db, nil, d.config.Database.Trace), d.config.GetStatusMapper())
+	output, err := compUc.GetComponentVersions(*request)
+	if err != nil {
+		return &api.ComponentVersionsResponse{Status: d.failureStatus(ctx, s, err)}, nil
+	}
+	telemetryCompVersionRequestTime(ctx, d.config, requestStartTime)
+	return &api.ComponentVersionsResponse{Status: d.successStatus(), ComponentVersionsOutput: output}, nil
+}
+
+// ResolveVersion explains how the version requirement of a component resolves to a concrete version, returning the
+// resolved version, the candidate versions and why each other version was rejected in the response.
+func (d componentExtensionServer) ResolveVersion(ctx context.Context, request *dtos.ComponentStatusInput) (*api.ResolveVersionResponse, error) {
+	requestStartTime := time.Now() // Capture the scan start time
+	s := ctxzap.Extract(ctx).Sugar()
+	s.Info("Processing version resolution request...")
+	if len(request.Purl) == 0 {
+		return &api.ResolveVersionResponse{Status: d.failureStatus(ctx, s, se.NewBadRequestError("No purl supplied", nil))}, nil
+	}
+	compUc := usecase.NewComponents(ctx, s, d.db, database.NewDBSelectContext(s, d.db, nil, d.config.Database.Trace), d.config.GetStatusMapper())
+	output, err := compUc.ResolveVersion(*request)
+	if err != nil {
+		return &api.ResolveVersionResponse{Status: d.failureStatus(ctx, s, err)}, nil
+	}
+	telemetryCompVersionRequestTime(ctx, d.config, requestStartTime) // Record the request processing time
+	return &api.ResolveVersionResponse{Status: d.successStatus(), ComponentResolutionOutput: output}, nil
+}
+
+// GetComponentsStatus returns the status of the components, along with the verdicts of the configured policy and
+// the applied waivers (if any) in the response.
+func (d componentExtensionServer) GetComponentsStatus(ctx context.Context, request *dtos.ComponentsStatusInput) (*api.ComponentsStatusResponse, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	s.Info("Processing components status request...")
+	if len(request.Components) == 0 {
+		return &api.ComponentsStatusResponse{Status: d.failureStatus(ctx, s, se.NewBadRequestError("No components supplied", nil))}, nil
+	}
+	compUc := usecase.NewComponents(ctx, s, d.db, database.NewDBSelectContext(s, d.db, nil, d.config.Database.Trace), d.config.GetStatusMapper())
+	output, err := compUc.GetComponentsStatus(*request)
+	if err != nil {
+		return &api.ComponentsStatusResponse{Status: d.failureStatus(ctx, s, err)}, nil
+	}
+	return &api.ComponentsStatusResponse{Status: d.successStatus(), ComponentsStatusOutput: output,
+		Policy: evaluatePolicy(d.config, output), Waivers: applyWaivers(d.config, output)}, nil
+}
+
+// successStatus returns the status of a successful response.
+func (d componentExtensionServer) successStatus() api.ExtensionStatus {
+	return api.ExtensionStatus{StatusResponse: &common.StatusResponse{
+		Status:  common.StatusCode_SUCCESS,
+		Message: "Success",
+		Db:      d.getDBVersion(),
+		Server:  &common.StatusResponse_Server{Version: d.config.App.Version},
+	}}
+}
+
+// failureStatus returns the status of a failed response, setting the HTTP code of the error (see HandleServiceError).
+func (d componentExtensionServer) failureStatus(ctx context.Context, s *zap.SugaredLogger, err error) api.ExtensionStatus {
+	status := se.HandleServiceError(ctx, s, err)
+	status.Db = d.getDBVersion()
+	status.Server = &common.StatusResponse_Server{Version: d.config.App.Version}
+	return api.ExtensionStatus{StatusResponse: status}
+}
@@ -0,0 +1,313 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	_ "modernc.org/sqlite"
+	"scanoss.com/components/pkg/api"
+	myconfig "scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/models"
+)
+
+// extensionClient starts an in-memory gRPC server with the ComponentsExtension service (using a database loaded
+// with the test data) and returns a client connected to it.
+func extensionClient(t *testing.T) *api.ComponentsExtensionClient {
+	t.Helper()
+	return extensionClientWithConfig(t, nil)
+}
+
+// extensionClientWithConfig returns a client of a ComponentsExtension test server, whose config can be adjusted.
+func extensionClientWithConfig(t *testing.T, configure func(*myconfig.ServerConfig)) *api.ComponentsExtensionClient {
+	t.Helper()
+	db, err := sqlx.Connect("sqlite", filepath.Join(t.TempDir(), "components.db"))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a test database", err)
+	}
+	t.Cleanup(func() { models.CloseDB(db) })
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.App.Version = appVersion
+	if configure != nil {
+		configure(myConfig)
+	}
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	api.RegisterComponentsExtensionServer(server, NewComponentExtensionServer(db, myConfig))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when connecting to the test server", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return api.NewComponentsExtensionClient(conn)
+}
+
+func TestComponentExtensionServer_SearchComponents(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	client := extensionClient(t)
+
+	request := dtos.ComponentSearchInput{Component: "react", Package: "npm"}
+	request.MinStars = 10000
+	request.Facets = true
+	resp, err := client.SearchComponents(context.Background(), &request)
+	if err != nil {
+		t.Fatalf("SearchComponents() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_SUCCESS {
+		t.Fatalf("SearchComponents() unexpected status: %v", resp.GetStatus())
+	}
+	var purls []string
+	for _, c := range resp.Components {
+		purls = append(purls, c.Purl)
+	}
+	slices.Sort(purls)
+	if want := []string{"pkg:npm/react", "pkg:npm/react-dom", "pkg:npm/react-router-dom"}; !slices.Equal(purls, want) || resp.Total != len(want) {
+		t.Errorf("SearchComponents() = %v (total %d), want %v", purls, resp.Total, want)
+	}
+	if resp.Facets == nil || !slices.Contains(resp.Facets.PurlType, dtos.FacetCount{Value: "npm", Count: 3}) {
+		t.Errorf("SearchComponents() unexpected facets: %+v", resp.Facets)
+	}
+
+	// The details are returned in the response, with the latest version chosen by version precedence
+	resp, err = client.SearchComponents(context.Background(), &dtos.ComponentSearchInput{Component: "react-dom", Package: "npm", Limit: 1, Enrich: true})
+	if err != nil {
+		t.Fatalf("SearchComponents() error = %v", err)
+	}
+	if len(resp.Components) != 1 || resp.Components[0].Details == nil || resp.Components[0].Details.LatestVersion != "17.0.2" {
+		t.Errorf("SearchComponents() unexpected details: %+v", resp.Components)
+	}
+
+	resp, err = client.SearchComponents(context.Background(), &dtos.ComponentSearchInput{})
+	if err != nil {
+		t.Fatalf("SearchComponents() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_FAILED || resp.GetStatus().GetMessage() != "No data supplied" {
+		t.Errorf("SearchComponents() expected a failure status, got %v", resp.GetStatus())
+	}
+}
+
+func TestComponentExtensionServer_SuggestComponents(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	client := extensionClient(t)
+
+	resp, err := client.SuggestComponents(context.Background(), &dtos.ComponentSuggestInput{Prefix: "reac", Package: "npm", Limit: 2})
+	if err != nil {
+		t.Fatalf("SuggestComponents() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_SUCCESS || len(resp.Components) != 2 || resp.Components[0].Purl != "pkg:npm/react" {
+		t.Errorf("SuggestComponents() unexpected response: %+v", resp)
+	}
+	resp, err = client.SuggestComponents(context.Background(), &dtos.ComponentSuggestInput{Package: "npm"})
+	if err != nil {
+		t.Fatalf("SuggestComponents() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_FAILED || resp.GetStatus().GetMessage() != "No prefix supplied" {
+		t.Errorf("SuggestComponents() expected a failure status, got %v", resp.GetStatus())
+	}
+}
+
+func TestComponentExtensionServer_LookupURL(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	client := extensionClient(t)
+
+	resp, err := client.LookupURL(context.Background(), &dtos.ComponentURLInput{URL: "https://registry.npmjs.org/react/-/react-18.0.0.tgz"})
+	if err != nil {
+		t.Fatalf("LookupURL() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_SUCCESS || len(resp.Components) != 1 || resp.Components[0].Purl != "pkg:npm/react@18.0.0" {
+		t.Errorf("LookupURL() unexpected response: %+v", resp)
+	}
+	resp, err = client.LookupURL(context.Background(), &dtos.ComponentURLInput{})
+	if err != nil {
+		t.Fatalf("LookupURL() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_FAILED || resp.GetStatus().GetMessage() != "No URL supplied" {
+		t.Errorf("LookupURL() expected a failure status, got %v", resp.GetStatus())
+	}
+}
+
+func TestComponentExtensionServer_GetComponentVersions(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	client := extensionClient(t)
+
+	// The merged license expression and highest match of each version are returned in the response
+	resp, err := client.GetComponentVersions(context.Background(), &dtos.ComponentVersionsInput{Purl: "pkg:pypi/grpcio", Requirement: "==1.12.1"})
+	if err != nil {
+		t.Fatalf("GetComponentVersions() error = %v", err)
+	}
+	versions := resp.Component.Versions
+	if resp.GetStatus().GetStatus() != common.StatusCode_SUCCESS || len(versions) != 1 || versions[0].LicenseExpression != "Apache-2.0 AND MIT" ||
+		!versions[0].HighestMatch {
+		t.Errorf("GetComponentVersions() unexpected response: %+v", resp)
+	}
+	resp, err = client.GetComponentVersions(context.Background(), &dtos.ComponentVersionsInput{})
+	if err != nil {
+		t.Fatalf("GetComponentVersions() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_FAILED || resp.GetStatus().GetMessage() != "No purl supplied" {
+		t.Errorf("GetComponentVersions() expected a failure status, got %v", resp.GetStatus())
+	}
+}
+
+func TestComponentExtensionServer_ResolveVersion(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	client := extensionClient(t)
+
+	resp, err := client.ResolveVersion(context.Background(), &dtos.ComponentStatusInput{Purl: "pkg:gem/tablestyle"})
+	if err != nil {
+		t.Fatalf("ResolveVersion() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_SUCCESS || resp.Version != "0.99.0" || len(resp.Candidates) == 0 {
+		t.Errorf("ResolveVersion() unexpected response: %+v", resp)
+	}
+	resp, err = client.ResolveVersion(context.Background(), &dtos.ComponentStatusInput{})
+	if err != nil {
+		t.Fatalf("ResolveVersion() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_FAILED || resp.GetStatus().GetMessage() != "No purl supplied" {
+		t.Errorf("ResolveVersion() expected a failure status, got %v", resp.GetStatus())
+	}
+}
+
+func TestComponentExtensionServer_GetComponentsStatus(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	client := extensionClientWithConfig(t, func(cfg *myconfig.ServerConfig) {
+		cfg.Policy.File = "../config/tests/policy.yaml"
+		if err := cfg.InitPolicyConfig(zlog.S); err != nil {
+			t.Fatalf("an error '%s' was not expected when loading the policy", err)
+		}
+		cfg.Waivers.File = "../config/tests/waivers.yaml"
+		if err := cfg.InitWaiversConfig(zlog.S); err != nil {
+			t.Fatalf("an error '%s' was not expected when loading the waivers", err)
+		}
+	})
+	request := dtos.ComponentsStatusInput{Components: []dtos.ComponentStatusInput{{Purl: "pkg:npm/no-such-package-xyz-123", Requirement: "1.0.0"}}}
+
+	// The policy verdicts and waivers are returned in the response, rather than as response headers
+	resp, err := client.GetComponentsStatus(context.Background(), &request)
+	if err != nil {
+		t.Fatalf("GetComponentsStatus() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_SUCCESS || len(resp.Components) != 1 || resp.Policy == nil {
+		t.Fatalf("GetComponentsStatus() unexpected response: %+v", resp)
+	}
+	if resp.Policy.Verdict != myconfig.PolicyWarn || len(resp.Policy.Components) != 1 || resp.Policy.Summary.Warn != 1 {
+		t.Errorf("GetComponentsStatus() unexpected policy verdicts: %+v", resp.Policy)
+	}
+	if resp.Waivers == nil || len(resp.Waivers.Waived) != 0 || len(resp.Waivers.Expired) != 0 {
+		t.Errorf("GetComponentsStatus() unexpected waivers for an unknown component: %+v", resp.Waivers)
+	}
+	// No policy or waivers are returned when none are configured
+	resp, err = extensionClient(t).GetComponentsStatus(context.Background(), &request)
+	if err != nil {
+		t.Fatalf("GetComponentsStatus() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_SUCCESS || resp.Policy != nil || resp.Waivers != nil {
+		t.Errorf("GetComponentsStatus() unexpected response without a policy or waivers: %+v", resp)
+	}
+	resp, err = client.GetComponentsStatus(context.Background(), &dtos.ComponentsStatusInput{})
+	if err != nil {
+		t.Fatalf("GetComponentsStatus() error = %v", err)
+	}
+	if resp.GetStatus().GetStatus() != common.StatusCode_FAILED || resp.GetStatus().GetMessage() != "No components supplied" {
+		t.Errorf("GetComponentsStatus() expected a failure status, got %v", resp.GetStatus())
+	}
+}
+
+func TestApplyWaivers(t *testing.T) {
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	statuses := dtos.ComponentsStatusOutput{Components: []dtos.ComponentStatusOutput{
+		{ // Yanked version covered by an active waiver
+			Purl:            "pkg:gem/tablestyle",
+			Requirement:     "0.1.0",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "active", RepositoryStatus: "active"},
+			VersionStatus:   &dtos.VersionStatusOutput{Version: "0.1.0", Status: "removed", RepositoryStatus: "yanked"},
+		},
+		{ // Archived component covered by an expired vendor waiver
+			Purl:            "pkg:github/scanoss/old",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "deprecated", RepositoryStatus: "archived"},
+		},
+		{ // Deprecated component without a waiver
+			Purl:            "pkg:npm/request",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "deprecated", RepositoryStatus: "deprecated"},
+		},
+	}}
+	if output := applyWaivers(cfg, statuses); output != nil {
+		t.Errorf("applyWaivers() expected nil without a waivers file, got %+v", output)
+	}
+	cfg.Waivers.File = "../config/tests/waivers.yaml"
+	if err = cfg.InitWaiversConfig(nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading the waivers", err)
+	}
+	output := applyWaivers(cfg, statuses)
+	if output == nil || len(output.Waived) != 1 || len(output.Expired) != 1 {
+		t.Fatalf("applyWaivers() unexpected output: %+v", output)
+	}
+	if w := output.Waived[0]; w.Purl != "pkg:gem/tablestyle" || w.Waiver == nil || w.Waiver.Owner != "ruby-team" {
+		t.Errorf("applyWaivers() unexpected waived component: %+v", w)
+	}
+	if e := output.Expired[0]; e.Purl != "pkg:github/scanoss/old" || e.Waiver == nil || !e.Waiver.Expired {
+		t.Errorf("applyWaivers() unexpected expired waiver: %+v", e)
+	}
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"time"
+
+	myconfig "scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/usecase"
+)
+
+// evaluatePolicy evaluates the configured policy (if any) against the component statuses, returning nil if no
+// policy has been configured.
+func evaluatePolicy(config *myconfig.ServerConfig, statuses dtos.ComponentsStatusOutput) *dtos.ComponentsPolicyOutput {
+	policy := config.GetPolicy()
+	if policy == nil {
+		return nil
+	}
+	output := usecase.EvaluatePolicy(policy, config.GetStatusMapper(), statuses, time.Now())
+	return &output
+}
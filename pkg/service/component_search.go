@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"scanoss.com/components/pkg/api"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// setSearchOptions sets the search options that are not part of the request message (cursor, fuzzy mode, all types,
+// sort order and namespace) from the request metadata.
+// Filtered, faceted and enriched searches use the ComponentsExtension service instead, which accepts the whole search
+// input and returns the facets and details in its response.
+func setSearchOptions(ctx context.Context, request *dtos.ComponentSearchInput) {
+	request.Cursor = incomingMetadata(ctx, api.SearchCursorHeader)
+	request.Fuzzy, _ = strconv.ParseBool(incomingMetadata(ctx, api.SearchFuzzyHeader))
+	request.AllTypes, _ = strconv.ParseBool(incomingMetadata(ctx, api.SearchAllTypesHeader))
+	request.Sort = incomingMetadata(ctx, api.SearchSortHeader)
+	request.Order = incomingMetadata(ctx, api.SearchOrderHeader)
+	request.Namespace = incomingMetadata(ctx, api.SearchNamespaceHeader)
+}
+
+// hasSearchTerms reports whether the search input contains something to search for.
+func hasSearchTerms(request dtos.ComponentSearchInput) bool {
+	return len(request.Search) > 0 || len(request.Component) > 0 || len(request.Vendor) > 0 || len(request.Namespace) > 0
+}
+
+// incomingMetadata returns the first value of the given key in the request metadata (empty if none).
+func incomingMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// setSearchHeaders returns the pagination details (and any did you mean suggestions) of the search results
+// as response header metadata.
+func setSearchHeaders(ctx context.Context, s *zap.SugaredLogger, output dtos.ComponentsSearchOutput) {
+	md := metadata.Pairs(
+		api.SearchTotalHeader, strconv.Itoa(output.Total),
+		api.SearchHasMoreHeader, strconv.FormatBool(output.HasMore),
+	)
+	if len(output.NextCursor) > 0 {
+		md.Set(api.SearchNextCursorHeader, output.NextCursor)
+	}
+	if len(output.DidYouMean) > 0 {
+		md.Set(api.SearchDidYouMeanHeader, output.DidYouMean...)
+	}
+	if err := grpc.SetHeader(ctx, md); err != nil {
+		s.Debugf("Failed to set search headers: %v", err)
+	}
+}
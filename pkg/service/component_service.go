@@ -74,9 +74,12 @@ func (d componentServer) SearchComponents(ctx context.Context, request *pb.CompS
 		status.Server = &common.StatusResponse_Server{Version: d.config.App.Version}
 		return &pb.CompSearchResponse{Status: status}, nil
 	}
+	// Options not in the request message are passed as metadata
+	setSearchOptions(ctx, &dtoRequest)
 
 	// Search the KB for information about the components
 	compUc := usecase.NewComponents(ctx, s, d.db, database.NewDBSelectContext(s, d.db, nil, d.config.Database.Trace), d.config.GetStatusMapper())
+	compUc.SetSearchOrder(d.config.GetSearchOrder())
 	dtoComponents, err := compUc.SearchComponents(dtoRequest)
 	if err != nil {
 		status := se.HandleServiceError(ctx, s, err)
@@ -85,6 +88,7 @@ func (d componentServer) SearchComponents(ctx context.Context, request *pb.CompS
 		return &pb.CompSearchResponse{Status: status}, nil
 	}
 	s.Debugf("Parsed Components: %+v", dtoComponents)
+	setSearchHeaders(ctx, s, dtoComponents)
 	componentsResponse, err := convertSearchComponentOutput(s, dtoComponents) // Convert the internal data into a response object
 	if err != nil {
 		s.Errorf("Failed to convert parsed components: %v", err)
@@ -124,6 +128,7 @@ func (d componentServer) GetComponentVersions(ctx context.Context, request *pb.C
 		status.Server = &common.StatusResponse_Server{Version: d.config.App.Version}
 		return &pb.CompVersionResponse{Status: status}, nil
 	}
+	setVersionsOptions(ctx, &dtoRequest)
 	// Creates the use case
 	compUc := usecase.NewComponents(ctx, s, d.db, database.NewDBSelectContext(s, d.db, nil, d.config.Database.Trace), d.config.GetStatusMapper())
 	dtoOutput, err := compUc.GetComponentVersions(dtoRequest)
@@ -144,6 +149,7 @@ func (d componentServer) GetComponentVersions(ctx context.Context, request *pb.C
 			Server:  &common.StatusResponse_Server{Version: d.config.App.Version},
 		}}, nil
 	}
+	setVersionsHeaders(ctx, s, dtoOutput)
 	telemetryCompVersionRequestTime(ctx, d.config, requestStartTime)
 	// Set the status and respond with the data
 	return &pb.CompVersionResponse{Component: reqResponse.Component, Status: &common.StatusResponse{
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"scanoss.com/components/pkg/api"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// setVersionsOptions sets the versions options that are not part of the request message (order, requirement and
+// exclusions) from the request metadata.
+func setVersionsOptions(ctx context.Context, request *dtos.ComponentVersionsInput) {
+	request.Order = incomingMetadata(ctx, api.VersionsOrderHeader)
+	request.Requirement = incomingMetadata(ctx, api.VersionsRequirementHeader)
+	request.ExcludePreReleases, _ = strconv.ParseBool(incomingMetadata(ctx, api.VersionsExcludePreReleasesHeader))
+	request.ExcludeYanked, _ = strconv.ParseBool(incomingMetadata(ctx, api.VersionsExcludeYankedHeader))
+}
+
+// setVersionsHeaders returns the classified status of each version as response header metadata (the
+// CompVersionResponse message has no field for it). The license expressions and the highest match of the requirement
+// are only returned by the ComponentsExtension service.
+func setVersionsHeaders(ctx context.Context, s *zap.SugaredLogger, output dtos.ComponentVersionsOutput) {
+	md := metadata.MD{}
+	for _, v := range output.Component.Versions {
+		if len(v.Status) > 0 {
+			md.Append(api.VersionsStatusHeader, url.QueryEscape(v.Version)+"="+url.QueryEscape(v.Status))
+		}
+	}
+	if md.Len() == 0 {
+		return
+	}
+	if err := grpc.SetHeader(ctx, md); err != nil {
+		s.Debugf("Failed to set versions headers: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"time"
+
+	myconfig "scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/usecase"
+)
+
+// applyWaivers applies the configured waivers (if any) to the removed/deprecated components in the batch, returning
+// the waived and expired entries (nil if no waivers have been configured).
+func applyWaivers(config *myconfig.ServerConfig, statuses dtos.ComponentsStatusOutput) *dtos.ComponentsWaiversOutput {
+	waivers := config.GetWaivers()
+	if waivers == nil {
+		return nil
+	}
+	output := usecase.AuditComponentsStatus(config.GetStatusMapper(), statuses)
+	usecase.ApplyWaivers(waivers, &output, time.Now())
+	result := &dtos.ComponentsWaiversOutput{Waived: output.Waived}
+	for _, f := range output.Findings {
+		if f.Waiver != nil && f.Waiver.Expired {
+			result.Expired = append(result.Expired, f)
+		}
+	}
+	return result
+}
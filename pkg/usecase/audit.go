@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"strings"
+
+	"scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// Audit finding scopes.
+const (
+	auditScopeComponent = "component"
+	auditScopeVersion   = "version"
+)
+
+// auditRank orders the audit classifications from least to most severe.
+var auditRank = map[string]int{
+	"":                   0,
+	dtos.AuditUnknown:    1,
+	dtos.AuditDeprecated: 2,
+	dtos.AuditRemoved:    3,
+}
+
+// AuditComponentsStatus classifies the supplied component statuses into removed, deprecated and unknown findings.
+// The registry status is classified using the supplied StatusMapper, so the same rules apply whether the
+// statuses came from the local database or a remote service. Components that need no attention are not reported.
+func AuditComponentsStatus(statusMapper *config.StatusMapper, statuses dtos.ComponentsStatusOutput) dtos.ComponentsAuditOutput {
+	output := dtos.ComponentsAuditOutput{
+		Summary:  dtos.ComponentsAuditSummary{Total: len(statuses.Components)},
+		Findings: []dtos.ComponentAuditFinding{},
+	}
+	for _, component := range statuses.Components {
+		finding, ok := auditComponentStatus(statusMapper, component)
+		if !ok {
+			continue
+		}
+		switch finding.Classification {
+		case dtos.AuditRemoved:
+			output.Summary.Removed++
+		case dtos.AuditDeprecated:
+			output.Summary.Deprecated++
+		case dtos.AuditUnknown:
+			output.Summary.Unknown++
+		}
+		output.Findings = append(output.Findings, finding)
+	}
+	return output
+}
+
+// auditComponentStatus returns the most severe finding for the given component (if any).
+// Component level findings take precedence over version level findings of the same severity.
+func auditComponentStatus(statusMapper *config.StatusMapper, component dtos.ComponentStatusOutput) (dtos.ComponentAuditFinding, bool) {
+	finding := dtos.ComponentAuditFinding{
+		Purl:        component.Purl,
+		Name:        component.Name,
+		Requirement: component.Requirement,
+	}
+	if vs := component.VersionStatus; vs != nil {
+		finding.Version = vs.Version
+	}
+	var componentClass, versionClass string
+	if isUnknownComponent(component) {
+		componentClass = dtos.AuditUnknown
+	} else if cs := component.ComponentStatus; cs != nil {
+		componentClass = classifyStatus(statusMapper, cs.RepositoryStatus, cs.Status)
+	}
+	if vs := component.VersionStatus; vs != nil {
+		if isUnknownVersion(vs) {
+			versionClass = dtos.AuditUnknown
+		} else {
+			versionClass = classifyStatus(statusMapper, vs.RepositoryStatus, vs.Status)
+		}
+	}
+	switch {
+	case len(componentClass) > 0 && auditRank[componentClass] >= auditRank[versionClass]:
+		cs := component.ComponentStatus
+		finding.Classification = componentClass
+		finding.Scope = auditScopeComponent
+		if cs != nil {
+			finding.Status = cs.Status
+			finding.RepositoryStatus = cs.RepositoryStatus
+			finding.StatusChangeDate = cs.StatusChangeDate
+			finding.Message = stringValue(cs.ErrorMessage)
+		}
+	case len(versionClass) > 0:
+		vs := component.VersionStatus
+		finding.Classification = versionClass
+		finding.Scope = auditScopeVersion
+		finding.Status = vs.Status
+		finding.RepositoryStatus = vs.RepositoryStatus
+		finding.StatusChangeDate = vs.StatusChangeDate
+		finding.Message = stringValue(vs.ErrorMessage)
+	default:
+		return dtos.ComponentAuditFinding{}, false
+	}
+	return finding, true
+}
+
+// classifyStatus maps a registry status to an audit classification (empty if the status needs no attention).
+// The raw repository status is preferred, falling back to the already mapped status.
+func classifyStatus(statusMapper *config.StatusMapper, repositoryStatus, status string) string {
+	switch strings.ToLower(strings.TrimSpace(mapStatus(statusMapper, repositoryStatus, status))) {
+	case "removed", "deleted":
+		return dtos.AuditRemoved
+	case "deprecated":
+		return dtos.AuditDeprecated
+	default:
+		return ""
+	}
+}
+
+// stringValue returns the value of an optional string, or empty if not set.
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
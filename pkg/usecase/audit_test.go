@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"testing"
+
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	myconfig "scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+)
+
+func TestAuditComponentsStatus(t *testing.T) {
+	notFound := domain.ComponentNotFound
+	versionNotFound := domain.VersionNotFound
+	statuses := dtos.ComponentsStatusOutput{Components: []dtos.ComponentStatusOutput{
+		{ // Healthy component - no finding
+			Purl:            "pkg:npm/react",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "active", RepositoryStatus: "active"},
+			VersionStatus:   &dtos.VersionStatusOutput{Version: "18.0.0", Status: "active", RepositoryStatus: "active"},
+		},
+		{ // Yanked version of an active component
+			Purl:            "pkg:gem/tablestyle",
+			Requirement:     "0.1.0",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "active", RepositoryStatus: "active"},
+			VersionStatus:   &dtos.VersionStatusOutput{Version: "0.1.0", Status: "removed", RepositoryStatus: "yanked"},
+		},
+		{ // Archived component with a missing version - deprecated wins over unknown
+			Purl:            "pkg:github/scanoss/old",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "deprecated", RepositoryStatus: "archived"},
+			VersionStatus:   &dtos.VersionStatusOutput{Version: "9.9.9", ErrorCode: &versionNotFound},
+		},
+		{ // Unknown component
+			Purl:            "pkg:npm/does-not-exist",
+			ComponentStatus: &dtos.ComponentStatusInfo{ErrorMessage: dtos.StringPtr("Component not found"), ErrorCode: &notFound},
+		},
+		{ // Custom mapped status
+			Purl:            "pkg:pypi/custom",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "quarantined", RepositoryStatus: "quarantined"},
+		},
+	}}
+	mapper := myconfig.NewStatusMapper(nil, map[string]string{"quarantined": "removed"})
+	output := AuditComponentsStatus(mapper, statuses)
+
+	wantSummary := dtos.ComponentsAuditSummary{Total: 5, Removed: 2, Deprecated: 1, Unknown: 1}
+	if output.Summary != wantSummary {
+		t.Errorf("AuditComponentsStatus() summary = %+v, want %+v", output.Summary, wantSummary)
+	}
+	want := map[string][2]string{
+		"pkg:gem/tablestyle":     {dtos.AuditRemoved, auditScopeVersion},
+		"pkg:github/scanoss/old": {dtos.AuditDeprecated, auditScopeComponent},
+		"pkg:npm/does-not-exist": {dtos.AuditUnknown, auditScopeComponent},
+		"pkg:pypi/custom":        {dtos.AuditRemoved, auditScopeComponent},
+	}
+	if len(output.Findings) != len(want) {
+		t.Fatalf("AuditComponentsStatus() returned %v findings, want %v: %+v", len(output.Findings), len(want), output.Findings)
+	}
+	for _, f := range output.Findings {
+		expected, ok := want[f.Purl]
+		if !ok {
+			t.Errorf("AuditComponentsStatus() unexpected finding: %+v", f)
+			continue
+		}
+		if f.Classification != expected[0] || f.Scope != expected[1] {
+			t.Errorf("AuditComponentsStatus() finding for %v = %v/%v, want %v/%v", f.Purl, f.Classification, f.Scope, expected[0], expected[1])
+		}
+	}
+	if empty := AuditComponentsStatus(mapper, dtos.ComponentsStatusOutput{}); empty.Findings == nil || empty.Summary.Total != 0 {
+		t.Errorf("AuditComponentsStatus() unexpected output for empty input: %+v", empty)
+	}
+}
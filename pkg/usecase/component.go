@@ -21,6 +21,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	cmpHelper "github.com/scanoss/go-component-helper/componenthelper"
@@ -34,6 +36,9 @@ import (
 	"scanoss.com/components/pkg/models"
 )
 
+// maxDidYouMean is the maximum number of "did you mean" suggestions returned by a fuzzy search.
+const maxDidYouMean = 5
+
 type ComponentUseCase struct {
 	ctx             context.Context
 	s               *zap.SugaredLogger
@@ -46,8 +51,10 @@ type ComponentUseCase struct {
 }
 
 func NewComponents(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, q *database.DBQueryContext, statusMapper *config.StatusMapper) *ComponentUseCase {
+	components := models.NewComponentModel(ctx, s, q, database.GetLikeOperator(db))
+	components.SetDB(db)
 	return &ComponentUseCase{ctx: ctx, s: s, q: q,
-		components:      models.NewComponentModel(ctx, s, q, database.GetLikeOperator(db)),
+		components:      components,
 		allURL:          models.NewAllURLModel(ctx, s, q),
 		componentStatus: models.NewComponentStatusModel(ctx, s, q),
 		db:              db,
@@ -55,39 +62,159 @@ func NewComponents(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, q *da
 	}
 }
 
+// SetSearchOrder sets the configured ORDER BY clause of each purl type (see config.ServerConfig.GetSearchOrder)
+// used to order the search results.
+func (c ComponentUseCase) SetSearchOrder(clauses map[string]string) {
+	c.components.SetSearchOrder(clauses)
+}
+
 func (c ComponentUseCase) SearchComponents(request dtos.ComponentSearchInput) (dtos.ComponentsSearchOutput, error) {
-	var err error
-	var searchResults []models.Component
+	filter, err := convertSearchFilter(request.ComponentSearchFilter)
+	if err != nil {
+		return dtos.ComponentsSearchOutput{}, se.NewBadRequestError("Invalid search filter supplied", err)
+	}
+	c.components.SetFilter(filter)
+	c.components.SetFacets(request.Facets)
+	sort, err := models.ParseComponentSort(request.Sort, request.Order)
+	if err != nil {
+		return dtos.ComponentsSearchOutput{}, se.NewBadRequestError("Invalid search sort supplied", err)
+	}
+	c.components.SetSort(sort)
+	var page models.ComponentPage
+	fuzzyTerm := firstNonEmpty(request.Search, request.Component)
 	switch {
+	case len(request.Namespace) > 0:
+		// Everything in a namespace (the purl type is inferred from the namespace if not supplied)
+		page, err = c.components.GetComponentsByNamespace(request.Namespace, request.Package, fuzzyTerm,
+			request.Limit, request.Offset, request.Cursor)
+	case request.Fuzzy && len(fuzzyTerm) > 0:
+		// Typo tolerant search by name similarity (across all types if requested)
+		page, err = c.components.GetComponentsFuzzy(fuzzyTerm, searchPurlType(request), request.Limit, request.Offset, request.Cursor)
+	case len(request.Package) == 0 && request.AllTypes:
+		// No package type supplied, and searching across every ecosystem was requested
+		page, err = c.components.GetComponentsAllTypes(request.Search, request.Component, request.Vendor,
+			request.Limit, request.Offset, request.PerTypeLimit, request.Cursor)
 	case len(request.Search) != 0:
-		searchResults, err = c.components.GetComponents(request.Search, request.Package, request.Limit, request.Offset)
+		page, err = c.components.GetComponents(request.Search, request.Package, request.Limit, request.Offset, request.Cursor)
 	case len(request.Component) != 0 && len(request.Vendor) == 0:
-		searchResults, err = c.components.GetComponentsByNameType(request.Component, request.Package, request.Limit, request.Offset)
+		page, err = c.components.GetComponentsByNameType(request.Component, request.Package, request.Limit, request.Offset, request.Cursor)
 	case len(request.Component) == 0 && len(request.Vendor) != 0:
-		searchResults, err = c.components.GetComponentsByVendorType(request.Vendor, request.Package, request.Limit, request.Offset)
+		page, err = c.components.GetComponentsByVendorType(request.Vendor, request.Package, request.Limit, request.Offset, request.Cursor)
 	case len(request.Component) != 0 && len(request.Vendor) != 0:
-		searchResults, err = c.components.GetComponentsByNameVendorType(request.Component, request.Vendor, request.Package, request.Limit, request.Offset)
+		page, err = c.components.GetComponentsByNameVendorType(request.Component, request.Vendor, request.Package, request.Limit, request.Offset, request.Cursor)
 	}
 	if err != nil {
-		c.s.Errorf("Problem encountered searching for components: %v - %v.", request.Component, request.Package)
+		if errors.Is(err, models.ErrInvalidCursor) {
+			return dtos.ComponentsSearchOutput{}, se.NewBadRequestError("Invalid search cursor supplied", err)
+		}
+		if errors.Is(err, models.ErrUnknownNamespaceType) {
+			return dtos.ComponentsSearchOutput{}, se.NewBadRequestError("Please specify the package type of the namespace", err)
+		}
+		c.s.Errorf("Problem encountered searching for components: %v - %v - %v.", request.Component, request.Package, err)
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	searchResults := page.Components
+	if page.Total == 0 {
+		return dtos.ComponentsSearchOutput{}, se.NewNotFoundError("No components found matching the search criteria")
+	}
+	output := dtos.ComponentsSearchOutput{
+		Components: convertSearchResults(searchResults),
+		Total:      page.Total,
+		HasMore:    page.HasMore,
+		NextCursor: page.NextCursor,
 	}
-	for i := range searchResults {
-		searchResults[i].URL, _ = purlhelper.ProjectUrl(searchResults[i].PurlName, searchResults[i].PurlType)
+	if request.Fuzzy {
+		output.DidYouMean = didYouMean(searchResults, fuzzyTerm)
 	}
-	var componentsSearchResults []dtos.ComponentSearchOutput
+	if request.Facets {
+		output.Facets = buildFacets(page.Facets, c.statusMapper)
+	}
+	if request.Enrich {
+		c.enrichResults(output.Components, searchResults)
+	}
+	if len(request.Namespace) > 0 {
+		// After the details are added, as they are keyed by the unencoded purl
+		encodeNamespacePurls(output.Components, searchResults)
+	}
+	return output, nil
+}
 
-	for _, component := range searchResults {
-		var componentSearchResult dtos.ComponentSearchOutput
-		componentSearchResult.Name = component.Component
-		componentSearchResult.Component = component.Component // Deprecated. Remove in future versions
-		componentSearchResult.Purl = "pkg:" + component.PurlType + "/" + component.PurlName
-		componentSearchResult.URL = component.URL
-		componentsSearchResults = append(componentsSearchResults, componentSearchResult)
-	}
-	if len(componentsSearchResults) == 0 {
-		return dtos.ComponentsSearchOutput{}, se.NewNotFoundError("No components found matching the search criteria")
+// encodeNamespacePurls replaces the purls of the namespace search results with their encoded form (see
+// models.NamespacePurl).
+func encodeNamespacePurls(results []dtos.ComponentSearchOutput, components []models.Component) {
+	for i := range results {
+		results[i].Purl = models.NamespacePurl(components[i].PurlType, components[i].PurlName)
+	}
+}
+
+// SuggestComponents returns the most popular components whose name or purl name starts with the supplied prefix,
+// for autocompletion. It skips the ranking, filters and pagination of a search.
+func (c ComponentUseCase) SuggestComponents(request dtos.ComponentSuggestInput) (dtos.ComponentsSearchOutput, error) {
+	prefix := request.Prefix
+	if len(strings.TrimSpace(prefix)) == 0 {
+		return dtos.ComponentsSearchOutput{}, se.NewBadRequestError("No prefix supplied", errors.New("no prefix supplied"))
+	}
+	suggestions, err := c.components.GetComponentSuggestions(prefix, purlTypeOrDefault(request.Package, request.AllTypes), request.Limit)
+	if err != nil {
+		c.s.Errorf("Problem encountered suggesting components for: %v - %v - %v.", prefix, request.Package, err)
+		return dtos.ComponentsSearchOutput{}, err
+	}
+	if len(suggestions) == 0 {
+		return dtos.ComponentsSearchOutput{}, se.NewNotFoundError("No components found matching the prefix")
 	}
-	return dtos.ComponentsSearchOutput{Components: componentsSearchResults}, nil
+	output := dtos.ComponentsSearchOutput{Components: convertSearchResults(suggestions), Total: len(suggestions)}
+	if request.Enrich {
+		c.enrichResults(output.Components, suggestions)
+	}
+	return output, nil
+}
+
+// searchPurlType returns the purl type to search: the requested package type, or if none was supplied,
+// either every type (empty) when all types were requested or the default type.
+func searchPurlType(request dtos.ComponentSearchInput) string {
+	return purlTypeOrDefault(request.Package, request.AllTypes)
+}
+
+// purlTypeOrDefault returns the supplied package type, or if none was supplied, either every type (empty)
+// when all types were requested or the default type.
+func purlTypeOrDefault(packageType string, allTypes bool) string {
+	if len(packageType) > 0 || allTypes {
+		return packageType
+	}
+	return models.DefaultPurlType
+}
+
+// convertSearchResults converts the components into search results, including their project URLs.
+func convertSearchResults(components []models.Component) []dtos.ComponentSearchOutput {
+	results := make([]dtos.ComponentSearchOutput, 0, len(components))
+	for _, component := range components {
+		url, _ := purlhelper.ProjectUrl(component.PurlName, component.PurlType)
+		results = append(results, dtos.ComponentSearchOutput{
+			Name:       component.Component,
+			Component:  component.Component, // Deprecated. Remove in future versions
+			Purl:       models.ComponentPurl(component.PurlType, component.PurlName),
+			URL:        url,
+			PurlType:   component.PurlType,
+			Score:      component.Score,
+			Similarity: component.Similarity,
+		})
+	}
+	return results
+}
+
+// didYouMean returns the distinct names of the best fuzzy matches that differ from the search term.
+func didYouMean(components []models.Component, term string) []string {
+	var names []string
+	for _, c := range components {
+		if len(names) >= maxDidYouMean {
+			break
+		}
+		if strings.EqualFold(c.Component, term) || slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, c.Component) }) {
+			continue
+		}
+		names = append(names, c.Component)
+	}
+	return names
 }
 
 func (c ComponentUseCase) GetComponentVersions(request dtos.ComponentVersionsInput) (dtos.ComponentVersionsOutput, error) {
@@ -95,7 +222,17 @@ func (c ComponentUseCase) GetComponentVersions(request dtos.ComponentVersionsInp
 		c.s.Errorf("The request does not contains purl to retrieve component versions")
 		return dtos.ComponentVersionsOutput{}, errors.New("the request does not contains purl to retrieve component versions")
 	}
-	allUrls, err := c.allURL.GetUrlsByPurlString(request.Purl, request.Limit)
+	order := strings.ToLower(strings.TrimSpace(request.Order))
+	if len(order) == 0 {
+		order = VersionOrderDate
+	}
+	if order != VersionOrderVersion && order != VersionOrderDate {
+		c.s.Errorf("Invalid version order supplied: %v", request.Order)
+		return dtos.ComponentVersionsOutput{}, se.NewBadRequestError("Invalid version order supplied",
+			fmt.Errorf("unknown version order %q (expected version or date)", request.Order))
+	}
+	// The limit applies to the versions, so fetch every row to sort and group them first
+	allUrls, err := c.allURL.GetUrlsByPurlString(request.Purl, maxVersionRows)
 	if err != nil {
 		c.s.Errorf("Problem encountered gettings URLs versions for: %v - %v.", request.Purl, err)
 		return dtos.ComponentVersionsOutput{}, err
@@ -104,6 +241,16 @@ func (c ComponentUseCase) GetComponentVersions(request dtos.ComponentVersionsInp
 	if err != nil {
 		c.s.Warnf("Problem encountered generating output component versions for: %v - %v.", request.Purl, err)
 	}
+	var requirement versionRequirement
+	if len(strings.TrimSpace(request.Requirement)) > 0 {
+		if requirement, err = parseVersionRequirement(purl.Type, request.Requirement); err != nil {
+			c.s.Errorf("Invalid version requirement supplied: %v - %v", request.Requirement, err)
+			return dtos.ComponentVersionsOutput{}, se.NewBadRequestError("Invalid version requirement supplied", err)
+		}
+	}
+	if order == VersionOrderVersion {
+		sortVersionsByPrecedence(purl.Type, allUrls)
+	}
 	purlName := purl.Name
 	if purl.Type == "github" {
 		purlName = fmt.Sprintf("%s/%s", purl.Namespace, purl.Name)
@@ -118,27 +265,17 @@ func (c ComponentUseCase) GetComponentVersions(request dtos.ComponentVersionsInp
 		output.Name = allUrls[0].Component
 		output.URL = projectURL
 		output.Component = allUrls[0].Component
-		output.Versions = []dtos.ComponentVersion{}
-		for _, u := range allUrls {
-			var version dtos.ComponentVersion
-			var license dtos.ComponentLicense
-			if len(u.Version) == 0 {
-				c.s.Infof("Empty version string supplied for: %+v. Skipping", u)
-				continue
-			}
-			version.Version = u.Version
-			version.Date = u.Date.String
-			if len(u.License) == 0 {
-				c.s.Infof("Empty license string supplied for: %+v. Skipping", u)
-				version.Licenses = []dtos.ComponentLicense{}
-				output.Versions = append(output.Versions, version)
-				continue
-			}
-			license.Name = u.License
-			license.SpdxID = u.LicenseID
-			license.IsSpdx = u.IsSpdx
-			version.Licenses = append(version.Licenses, license)
-			output.Versions = append(output.Versions, version)
+		output.Versions = c.groupVersions(allUrls)
+		output.Versions = filterVersions(purl.Type, output.Versions, request.ExcludePreReleases, request.ExcludeYanked)
+		if requirement != nil {
+			output.Versions = matchingVersions(purl.Type, output.Versions, requirement, c.statusVersion(request.Purl, request.Requirement))
+		}
+		limit := request.Limit
+		if limit <= 0 {
+			limit = defaultVersionsLimit
+		}
+		if len(output.Versions) > limit {
+			output.Versions = output.Versions[:limit]
 		}
 	}
 	if output.Name == "" || output.Purl == "" {
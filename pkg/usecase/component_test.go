@@ -18,7 +18,10 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"testing"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
@@ -28,6 +31,7 @@ import (
 	_ "modernc.org/sqlite"
 	myconfig "scanoss.com/components/pkg/config"
 	"scanoss.com/components/pkg/dtos"
+	se "scanoss.com/components/pkg/errors"
 	"scanoss.com/components/pkg/models"
 )
 
@@ -68,7 +72,7 @@ func TestComponentUseCase_SearchComponents(t *testing.T) {
 
 	for i, dtoCompSearchInput := range goodTable {
 		searchOut, err := compUc.SearchComponents(dtoCompSearchInput)
-		if err == nil {
+		if err != nil {
 			t.Fatalf("test case %d: an error '%s' was not expected when getting components with input %+v", i, err, dtoCompSearchInput)
 		}
 		fmt.Printf("Search response: %+v\n", searchOut)
@@ -86,6 +90,131 @@ func TestComponentUseCase_SearchComponents(t *testing.T) {
 		fmt.Printf("Component-only search failed as expected: %v\n", err)
 		// This is fine - some component searches may not find exact matches
 	}
+	// Only an empty result is reported as not found
+	_, err = compUc.SearchComponents(dtos.ComponentSearchInput{Component: "no-such-component-xyz", Package: "npm"})
+	var svcErr *se.ServiceError
+	if !errors.As(err, &svcErr) || svcErr.HTTPCode != http.StatusNotFound {
+		t.Errorf("expected a not found error for an empty result, got %v", err)
+	}
+	// Database errors are propagated (as internal errors), rather than reported as not found
+	emptyDB, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(emptyDB)
+	emptyUc := NewComponents(ctx, s, emptyDB, database.NewDBSelectContext(s, emptyDB, nil, false), myConfig.GetStatusMapper())
+	if _, err = emptyUc.SearchComponents(dtos.ComponentSearchInput{Search: "angular"}); err == nil || se.IsServiceError(err) {
+		t.Errorf("expected the database error to be propagated, got %v", err)
+	}
+	if _, err = emptyUc.SuggestComponents(dtos.ComponentSuggestInput{Prefix: "reac"}); err == nil || se.IsServiceError(err) {
+		t.Errorf("expected the database error to be propagated, got %v", err)
+	}
+}
+
+func TestComponentUseCase_SuggestComponents(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), nil)
+	if _, err = compUc.SuggestComponents(dtos.ComponentSuggestInput{Prefix: " ", Package: "npm"}); err == nil {
+		t.Errorf("SuggestComponents() expected an error without a prefix")
+	}
+	out, err := compUc.SuggestComponents(dtos.ComponentSuggestInput{Prefix: "reac", Package: "npm", Limit: 2})
+	if err != nil {
+		t.Fatalf("SuggestComponents() error = %v", err)
+	}
+	if len(out.Components) != 2 || out.Components[0].Purl != "pkg:npm/react" || len(out.Components[0].URL) == 0 {
+		t.Errorf("SuggestComponents() unexpected suggestions: %+v", out.Components)
+	}
+}
+
+func TestComponentUseCase_SearchComponentsSorted(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), nil)
+	if _, err = compUc.SearchComponents(dtos.ComponentSearchInput{Component: "react", Package: "npm", Sort: "downloads"}); err == nil {
+		t.Errorf("SearchComponents() expected an error for an unknown sort")
+	}
+	out, err := compUc.SearchComponents(dtos.ComponentSearchInput{Component: "react", Package: "npm", Sort: "stars", Order: "asc", Limit: 2})
+	if err != nil {
+		t.Fatalf("SearchComponents() error = %v", err)
+	}
+	if len(out.Components) != 2 || out.Components[0].Purl != "pkg:npm/react-checkbox-tree" || !out.HasMore {
+		t.Errorf("SearchComponents() unexpected sorted results: %+v", out)
+	}
+}
+
+func TestComponentUseCase_SearchComponentsNamespace(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	if err = models.LoadTestNamespaceSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading namespace test data", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), nil)
+	if _, err = compUc.SearchComponents(dtos.ComponentSearchInput{Namespace: "angular"}); err == nil {
+		t.Errorf("SearchComponents() expected an error for a namespace without a package type")
+	}
+	out, err := compUc.SearchComponents(dtos.ComponentSearchInput{Namespace: "@babel", Component: "core"})
+	if err != nil {
+		t.Fatalf("SearchComponents() error = %v", err)
+	}
+	if len(out.Components) != 1 || out.Components[0].Purl != "pkg:npm/%40babel/core" || out.Components[0].PurlType != "npm" {
+		t.Errorf("SearchComponents() unexpected namespace results: %+v", out.Components)
+	}
+	// The details of the encoded namespace results are still found
+	out, err = compUc.SearchComponents(dtos.ComponentSearchInput{Namespace: "@babel", Component: "core", Enrich: true})
+	if err != nil {
+		t.Fatalf("SearchComponents() error = %v", err)
+	}
+	if len(out.Components) != 1 || out.Components[0].Details == nil || out.Components[0].Details.License != "MIT" {
+		t.Errorf("SearchComponents() unexpected enriched namespace results: %+v", out.Components)
+	}
+	// Other searches keep the unencoded purls
+	out, err = compUc.SearchComponents(dtos.ComponentSearchInput{Component: "@babel/core", Package: "npm"})
+	if err != nil {
+		t.Fatalf("SearchComponents() error = %v", err)
+	}
+	if len(out.Components) == 0 || out.Components[0].Purl != "pkg:npm/@babel/core" {
+		t.Errorf("SearchComponents() unexpected results: %+v", out.Components)
+	}
 }
 
 //goland:noinspection DuplicatedCode
@@ -152,6 +281,72 @@ func TestComponentUseCase_GetComponentVersions(t *testing.T) {
 	}
 }
 
+func TestComponentUseCase_GetComponentVersionsOrdered(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), nil)
+
+	tests := []struct {
+		name    string
+		request dtos.ComponentVersionsInput
+		want    []string
+	}{
+		{
+			name:    "release date by default",
+			request: dtos.ComponentVersionsInput{Purl: "pkg:gem/tablestyle", Limit: 1},
+			want:    []string{"0.0.10"},
+		},
+		{
+			name:    "release date",
+			request: dtos.ComponentVersionsInput{Purl: "pkg:gem/tablestyle", Limit: 1, Order: "date"},
+			want:    []string{"0.0.10"},
+		},
+		{
+			name:    "version precedence",
+			request: dtos.ComponentVersionsInput{Purl: "pkg:gem/tablestyle", Limit: 3, Order: "version"},
+			want:    []string{"0.99.0", "0.0.12", "0.0.11"},
+		},
+		{
+			name:    "pep 440 release after its candidates",
+			request: dtos.ComponentVersionsInput{Purl: "pkg:pypi/grpcio", Limit: 1, Order: "version"},
+			want:    []string{"1.42.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := compUc.GetComponentVersions(tt.request)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when getting component versions", err)
+			}
+			var got []string
+			for _, v := range output.Component.Versions {
+				got = append(got, v.Version)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("GetComponentVersions() versions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	_, err = compUc.GetComponentVersions(dtos.ComponentVersionsInput{Purl: "pkg:gem/tablestyle", Order: "stars"})
+	var svcErr *se.ServiceError
+	if !errors.As(err, &svcErr) || svcErr.HTTPCode != http.StatusBadRequest {
+		t.Errorf("expected a bad request error for an invalid order, got %v", err)
+	}
+}
+
 //goland:noinspection DuplicatedCode
 func TestComponentUseCase_GetComponentStatus(t *testing.T) {
 	err := zlog.NewSugaredDevLogger()
@@ -431,3 +626,49 @@ func TestComponentUseCase_GetComponentStatus_AllCases(t *testing.T) {
 		})
 	}
 }
+
+func TestDidYouMean(t *testing.T) {
+	components := []models.Component{
+		{Component: "requests"}, {Component: "Requests"}, {Component: "reqests"},
+		{Component: "request"}, {Component: "react"}, {Component: "pytest"}, {Component: "urllib3"}, {Component: "quests"},
+	}
+	got := didYouMean(components, "reqests")
+	want := []string{"requests", "request", "react", "pytest", "urllib3"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("didYouMean() = %v, want %v", got, want)
+	}
+	if got = didYouMean(nil, "reqests"); len(got) != 0 {
+		t.Errorf("didYouMean() expected no suggestions, got %v", got)
+	}
+}
+
+func TestComponentUseCase_SearchComponentsAllTypes(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), nil)
+	// Without a package type, only the default type (github) is searched, which has no react components
+	if out, err := compUc.SearchComponents(dtos.ComponentSearchInput{Component: "react"}); err == nil {
+		t.Errorf("SearchComponents() expected no github components, got %+v", out.Components)
+	}
+	// Searching across all types has to be requested explicitly
+	out, err := compUc.SearchComponents(dtos.ComponentSearchInput{Component: "react", AllTypes: true})
+	if err != nil {
+		t.Fatalf("SearchComponents() error = %v", err)
+	}
+	if !slices.ContainsFunc(out.Components, func(c dtos.ComponentSearchOutput) bool { return c.Purl == "pkg:npm/react" }) {
+		t.Errorf("SearchComponents() expected pkg:npm/react across all types, got %+v", out.Components)
+	}
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"slices"
+	"strings"
+
+	"scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/models"
+)
+
+// groupVersions merges the all_urls rows of each version (one per artifact and license) into a single entry, in the
+// order the versions are first seen, with the deduplicated licenses, their SPDX license expression and the classified
+// status of the version.
+func (c ComponentUseCase) groupVersions(urls []models.AllURL) []dtos.ComponentVersion {
+	versions := []dtos.ComponentVersion{}
+	index := make(map[string]int, len(urls))
+	for _, u := range urls {
+		if len(u.Version) == 0 {
+			c.s.Infof("Empty version string supplied for: %+v. Skipping", u)
+			continue
+		}
+		i, found := index[u.Version]
+		if !found {
+			i = len(versions)
+			index[u.Version] = i
+			versions = append(versions, dtos.ComponentVersion{Version: u.Version, Licenses: []dtos.ComponentLicense{}})
+		}
+		if len(versions[i].Date) == 0 {
+			versions[i].Date = u.Date.String
+		}
+		if len(versions[i].Status) == 0 {
+			versions[i].Status = c.versionStatus(u.VersionStatus)
+		}
+		if len(u.License) == 0 {
+			c.s.Infof("Empty license string supplied for: %+v. Skipping", u)
+			continue
+		}
+		license := dtos.ComponentLicense{Name: u.License, SpdxID: u.LicenseID, IsSpdx: u.IsSpdx}
+		if !slices.ContainsFunc(versions[i].Licenses, func(l dtos.ComponentLicense) bool { return sameLicense(l, license) }) {
+			versions[i].Licenses = append(versions[i].Licenses, license)
+		}
+	}
+	for i := range versions {
+		versions[i].LicenseExpression = licenseExpression(versions[i].Licenses)
+	}
+	return versions
+}
+
+// versionStatus classifies the registry status of a version (i.e. yanked is removed) using the configured status
+// mapping, or the default mapping if none is configured.
+func (c ComponentUseCase) versionStatus(status string) string {
+	if len(status) == 0 {
+		return ""
+	}
+	statusMapper := c.statusMapper
+	if statusMapper == nil {
+		statusMapper = config.NewStatusMapper(c.s, nil)
+	}
+	return statusMapper.MapStatus(status)
+}
+
+// isYanked reports whether the classified status of a version means it is no longer available from its registry.
+func isYanked(status string) bool {
+	return status == "removed" || status == "deleted"
+}
+
+// filterVersions removes the pre-releases and/or the yanked versions from the list, as requested.
+func filterVersions(purlType string, versions []dtos.ComponentVersion, excludePreReleases, excludeYanked bool) []dtos.ComponentVersion {
+	if !excludePreReleases && !excludeYanked {
+		return versions
+	}
+	return slices.DeleteFunc(versions, func(v dtos.ComponentVersion) bool {
+		return excludePreReleases && isPreRelease(purlType, v.Version) || excludeYanked && isYanked(v.Status)
+	})
+}
+
+// matchingVersions returns the versions satisfying the requirement, flagging the one it resolves to as the highest
+// match. This is the status version (the version a status lookup of the requirement reports) if it is one of them,
+// otherwise the version chosen by resolveRequirement.
+func matchingVersions(purlType string, versions []dtos.ComponentVersion, requirement versionRequirement, statusVersion string) []dtos.ComponentVersion {
+	matched := slices.DeleteFunc(versions, func(v dtos.ComponentVersion) bool { return !requirement.matches(purlType, v.Version) })
+	highest := -1
+	if len(statusVersion) > 0 {
+		highest = slices.IndexFunc(matched, func(v dtos.ComponentVersion) bool { return v.Version == statusVersion })
+	}
+	if highest < 0 {
+		highest, _ = resolveRequirement(purlType, matched, requirement)
+	}
+	if highest >= 0 {
+		matched[highest].HighestMatch = true
+	}
+	return matched
+}
+
+// sameLicense reports whether two licenses are the same (by SPDX identifier, or by name if either has none).
+func sameLicense(a, b dtos.ComponentLicense) bool {
+	if len(a.SpdxID) > 0 && len(b.SpdxID) > 0 {
+		return strings.EqualFold(a.SpdxID, b.SpdxID)
+	}
+	return strings.EqualFold(a.Name, b.Name)
+}
+
+// licenseExpression returns the SPDX license expression of the licenses declared for a version (all of which apply,
+// i.e. MIT AND Apache-2.0), or an empty string if any of the licenses has no SPDX identifier.
+func licenseExpression(licenses []dtos.ComponentLicense) string {
+	var ids []string
+	for _, l := range licenses {
+		id := strings.TrimSpace(l.SpdxID)
+		if len(id) == 0 {
+			return ""
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 {
+		return strings.Join(ids, "")
+	}
+	slices.Sort(ids)
+	for i, id := range ids {
+		if strings.Contains(id, " ") { // Already an expression (i.e. MIT OR GPL-2.0-only)
+			ids[i] = "(" + id + ")"
+		}
+	}
+	return strings.Join(ids, " AND ")
+}
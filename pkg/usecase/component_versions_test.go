@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/models"
+)
+
+func TestLicenseExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		licenses []dtos.ComponentLicense
+		want     string
+	}{
+		{name: "no licenses", want: ""},
+		{name: "single license", licenses: []dtos.ComponentLicense{{Name: "MIT", SpdxID: "MIT"}}, want: "MIT"},
+		{
+			name:     "multiple licenses",
+			licenses: []dtos.ComponentLicense{{Name: "MIT", SpdxID: "MIT"}, {Name: "Apache License 2.0", SpdxID: "Apache-2.0"}},
+			want:     "Apache-2.0 AND MIT",
+		},
+		{
+			name:     "compound license",
+			licenses: []dtos.ComponentLicense{{SpdxID: "MIT OR GPL-2.0-only"}, {SpdxID: "BSD-3-Clause"}},
+			want:     "BSD-3-Clause AND (MIT OR GPL-2.0-only)",
+		},
+		{
+			name:     "license without an spdx identifier",
+			licenses: []dtos.ComponentLicense{{Name: "MIT", SpdxID: "MIT"}, {Name: "Custom"}},
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := licenseExpression(tt.licenses); got != tt.want {
+				t.Errorf("licenseExpression() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponentUseCase_GetComponentVersionsGrouped(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), nil)
+
+	output, err := compUc.GetComponentVersions(dtos.ComponentVersionsInput{Purl: "pkg:pypi/grpcio", Limit: 500})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when getting component versions", err)
+	}
+	seen := make(map[string]bool)
+	var version dtos.ComponentVersion
+	for _, v := range output.Component.Versions {
+		if seen[v.Version] {
+			t.Errorf("version %v returned more than once", v.Version)
+		}
+		seen[v.Version] = true
+		if v.Version == "1.12.1" {
+			version = v
+		}
+	}
+	if len(version.Licenses) != 2 {
+		t.Fatalf("expected grpcio 1.12.1 to have 2 licenses, got %+v", version.Licenses)
+	}
+	if version.LicenseExpression != "Apache-2.0 AND MIT" {
+		t.Errorf("expected the grpcio 1.12.1 license expression to be 'Apache-2.0 AND MIT', got %q", version.LicenseExpression)
+	}
+	limited, err := compUc.GetComponentVersions(dtos.ComponentVersionsInput{Purl: "pkg:pypi/grpcio", Limit: 5})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when getting component versions", err)
+	}
+	if len(limited.Component.Versions) != 5 {
+		t.Errorf("expected the limit to apply to the versions, got %v", len(limited.Component.Versions))
+	}
+}
+
+func TestComponentUseCase_GetComponentVersionsRequirement(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), nil)
+
+	tests := []struct {
+		name        string
+		request     dtos.ComponentVersionsInput
+		wantCount   int
+		wantHighest string
+	}{
+		{
+			name:        "caret range",
+			request:     dtos.ComponentVersionsInput{Purl: "pkg:npm/react", Requirement: "^16.8"},
+			wantCount:   16,
+			wantHighest: "16.14.0",
+		},
+		{
+			name:        "tilde range ordered by date",
+			request:     dtos.ComponentVersionsInput{Purl: "pkg:npm/react", Requirement: "~0.14.2", Order: "date"},
+			wantCount:   9,
+			wantHighest: "0.14.10",
+		},
+		{
+			name:        "no matches",
+			request:     dtos.ComponentVersionsInput{Purl: "pkg:gem/tablestyle", Requirement: ">=1.0"},
+			wantCount:   0,
+			wantHighest: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := compUc.GetComponentVersions(tt.request)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when getting component versions", err)
+			}
+			var highest []string
+			for _, v := range output.Component.Versions {
+				if v.HighestMatch {
+					highest = append(highest, v.Version)
+				}
+			}
+			if len(output.Component.Versions) != tt.wantCount {
+				t.Errorf("GetComponentVersions() returned %v versions, want %v", len(output.Component.Versions), tt.wantCount)
+			}
+			if len(tt.wantHighest) > 0 && (len(highest) != 1 || highest[0] != tt.wantHighest) {
+				t.Errorf("GetComponentVersions() highest match = %v, want %v", highest, tt.wantHighest)
+			}
+		})
+	}
+	if _, err = compUc.GetComponentVersions(dtos.ComponentVersionsInput{Purl: "pkg:pypi/grpcio", Requirement: "~=1"}); err == nil {
+		t.Errorf("an error was expected for an invalid requirement")
+	}
+}
+
+func TestComponentUseCase_GetComponentVersionsExclusions(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	if _, err = db.Exec("UPDATE all_urls SET version_status = 'yanked' WHERE purl_name = 'react' AND version = '18.0.0'"); err != nil {
+		t.Fatalf("an error '%s' was not expected when yanking a version", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), nil)
+
+	tests := []struct {
+		name    string
+		request dtos.ComponentVersionsInput
+		want    []string // Highest versions expected, in order
+	}{
+		{
+			name:    "no exclusions",
+			request: dtos.ComponentVersionsInput{Purl: "pkg:npm/react", Order: "version", Limit: 2},
+			want:    []string{"18.0.0", "18.0.0-beta-fdc1d617a-20211118"},
+		},
+		{
+			name:    "exclude pre-releases",
+			request: dtos.ComponentVersionsInput{Purl: "pkg:npm/react", Order: "version", Limit: 2, ExcludePreReleases: true},
+			want:    []string{"18.0.0", "17.0.2"},
+		},
+		{
+			name:    "exclude pre-releases and yanked versions",
+			request: dtos.ComponentVersionsInput{Purl: "pkg:npm/react", Order: "version", Limit: 2, ExcludePreReleases: true, ExcludeYanked: true},
+			want:    []string{"17.0.2", "17.0.1"},
+		},
+		{
+			name:    "exclude yanked versions with a requirement",
+			request: dtos.ComponentVersionsInput{Purl: "pkg:npm/react", Order: "version", Limit: 2, Requirement: ">=17", ExcludeYanked: true},
+			want:    []string{"17.0.2", "17.0.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := compUc.GetComponentVersions(tt.request)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when getting component versions", err)
+			}
+			var got []string
+			for _, v := range output.Component.Versions {
+				got = append(got, v.Version)
+				want := "active"
+				if v.Version == "18.0.0" {
+					want = "removed"
+				}
+				if v.Status != want {
+					t.Errorf("expected version %v to have status %q, got %q", v.Version, want, v.Status)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("GetComponentVersions() versions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	output, err := compUc.GetComponentVersions(dtos.ComponentVersionsInput{Purl: "pkg:npm/react", Limit: 1000, ExcludePreReleases: true})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when getting component versions", err)
+	}
+	for _, v := range output.Component.Versions {
+		if strings.Contains(v.Version, "-") {
+			t.Errorf("expected pre-release %v to be excluded", v.Version)
+		}
+	}
+}
+
+func TestMatchingVersions(t *testing.T) {
+	requirement, err := parseVersionRequirement("npm", "^1.0")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing the requirement", err)
+	}
+	tests := []struct {
+		name          string
+		statusVersion string
+		want          string
+	}{
+		{name: "status version", statusVersion: "1.1.0", want: "1.1.0"},
+		{name: "no status version", statusVersion: "", want: "1.2.0"},
+		{name: "status version not matched", statusVersion: "2.0.0", want: "1.2.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := []dtos.ComponentVersion{{Version: "2.0.0"}, {Version: "1.2.0"}, {Version: "1.1.0"}, {Version: "0.9.0"}}
+			var highest []string
+			for _, v := range matchingVersions("npm", versions, requirement, tt.statusVersion) {
+				if v.HighestMatch {
+					highest = append(highest, v.Version)
+				}
+			}
+			if len(highest) != 1 || highest[0] != tt.want {
+				t.Errorf("matchingVersions() highest match = %v, want %v", highest, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,454 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// Supported lockfile types.
+const (
+	LockfileNpm          = "npm"          // package-lock.json / npm-shrinkwrap.json
+	LockfileYarn         = "yarn"         // yarn.lock (classic and berry)
+	LockfileRequirements = "requirements" // requirements.txt
+	LockfilePoetry       = "poetry"       // poetry.lock
+	LockfileGemfile      = "gemfile"      // Gemfile.lock
+	LockfileGoSum        = "gosum"        // go.sum
+	LockfileMaven        = "maven"        // pom.xml
+)
+
+// LockfileTypes lists the supported lockfile types.
+var LockfileTypes = []string{LockfileNpm, LockfileYarn, LockfileRequirements, LockfilePoetry, LockfileGemfile, LockfileGoSum, LockfileMaven}
+
+var (
+	pypiNameRegex        = regexp.MustCompile(`[-_.]+`)                                                          // PEP 503 name normalisation
+	requirementLineRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)         // name[extras] specifier
+	pomPropertyRegex     = regexp.MustCompile(`\$\{([^}]+)\}`)                                                   // ${property} references
+	gemSpecRegex         = regexp.MustCompile(`^    ([^\s(]+) \(([^)]+)\)$`)                                     // "    name (version)"
+	tomlStringRegex      = regexp.MustCompile(`^([A-Za-z0-9_-]+)\s*=\s*"((?:[^"\\]|\\.)*)"`)                     // key = "value"
+	yarnVersionRegex     = regexp.MustCompile(`^  version:?\s+"?([^"\s]+)"?`)                                    // version "x" / version: x
+	yarnIgnoredProtocols = []string{"workspace:", "link:", "portal:", "file:", "exec:", "patch:", "git", "http"} // non-registry descriptors
+)
+
+// DetectLockfileType works out the lockfile type from its file name.
+// It returns an empty string if the file is not a recognised lockfile.
+func DetectLockfileType(filename string) string {
+	base := strings.ToLower(filepath.Base(filename))
+	switch {
+	case base == "package-lock.json" || base == "npm-shrinkwrap.json":
+		return LockfileNpm
+	case base == "yarn.lock":
+		return LockfileYarn
+	case strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt"):
+		return LockfileRequirements
+	case base == "poetry.lock":
+		return LockfilePoetry
+	case base == "gemfile.lock":
+		return LockfileGemfile
+	case base == "go.sum":
+		return LockfileGoSum
+	case base == "pom.xml":
+		return LockfileMaven
+	default:
+		return ""
+	}
+}
+
+// ParseLockfile extracts the pinned dependencies from a lockfile of the given type.
+// Each dependency is returned as a purl (without version) with the pinned version as its requirement,
+// ready to be passed to GetComponentsStatus. Duplicate purl/version pairs are only returned once.
+func ParseLockfile(s *zap.SugaredLogger, lockfileType string, data []byte) (dtos.ComponentsStatusInput, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return dtos.ComponentsStatusInput{}, fmt.Errorf("no %v lockfile data supplied", lockfileType)
+	}
+	var components []dtos.ComponentStatusInput
+	var err error
+	switch lockfileType {
+	case LockfileNpm:
+		components, err = parsePackageLock(s, data)
+	case LockfileYarn:
+		components, err = parseYarnLock(data)
+	case LockfileRequirements:
+		components, err = parseRequirements(data)
+	case LockfilePoetry:
+		components, err = parsePoetryLock(data)
+	case LockfileGemfile:
+		components, err = parseGemfileLock(data)
+	case LockfileGoSum:
+		components, err = parseGoSum(data)
+	case LockfileMaven:
+		components, err = parsePom(s, data)
+	default:
+		return dtos.ComponentsStatusInput{}, fmt.Errorf("unsupported lockfile type: %v (supported: %v)", lockfileType, strings.Join(LockfileTypes, ", "))
+	}
+	if err != nil {
+		return dtos.ComponentsStatusInput{}, err
+	}
+	components = uniqueStatusInputs(components)
+	if len(components) == 0 {
+		return dtos.ComponentsStatusInput{}, fmt.Errorf("no dependencies found in %v lockfile", lockfileType)
+	}
+	return dtos.ComponentsStatusInput{Components: components}, nil
+}
+
+// newLockfileInput creates a status request for the given package type, name and pinned version.
+func newLockfileInput(purlType, name, version string) dtos.ComponentStatusInput {
+	return dtos.ComponentStatusInput{Purl: "pkg:" + purlType + "/" + name, Requirement: strings.TrimSpace(version)}
+}
+
+// npmLockDependency represents a lockfileVersion 1 dependency entry (which can contain nested dependencies).
+type npmLockDependency struct {
+	Version      string                       `json:"version"`
+	Dependencies map[string]npmLockDependency `json:"dependencies"`
+}
+
+// npmLock represents the parts of a package-lock.json we need (lockfileVersion 1, 2 and 3).
+type npmLock struct {
+	Packages map[string]struct {
+		Version string `json:"version"`
+		Link    bool   `json:"link"`
+	} `json:"packages"`
+	Dependencies map[string]npmLockDependency `json:"dependencies"`
+}
+
+// parsePackageLock extracts the installed packages from a package-lock.json (or npm-shrinkwrap.json).
+func parsePackageLock(s *zap.SugaredLogger, data []byte) ([]dtos.ComponentStatusInput, error) {
+	var lock npmLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		s.Errorf("Parse failure: %v", err)
+		return nil, fmt.Errorf("failed to parse package-lock.json: %v", err)
+	}
+	var components []dtos.ComponentStatusInput
+	if len(lock.Packages) > 0 {
+		// lockfileVersion 2+: keys are install paths such as node_modules/a/node_modules/@scope/b
+		for _, path := range sortedKeys(lock.Packages) {
+			pkg := lock.Packages[path]
+			idx := strings.LastIndex(path, "node_modules/")
+			if idx < 0 || pkg.Link || len(pkg.Version) == 0 {
+				continue // Skip the root project, workspaces and links
+			}
+			components = append(components, newLockfileInput("npm", path[idx+len("node_modules/"):], pkg.Version))
+		}
+		return components, nil
+	}
+	// lockfileVersion 1: nested dependency tree
+	var walk func(deps map[string]npmLockDependency)
+	walk = func(deps map[string]npmLockDependency) {
+		for _, name := range sortedKeys(deps) {
+			dep := deps[name]
+			if len(dep.Version) > 0 && !strings.Contains(dep.Version, ":") {
+				components = append(components, newLockfileInput("npm", name, dep.Version))
+			}
+			walk(dep.Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return components, nil
+}
+
+// parseYarnLock extracts the resolved packages from a yarn.lock file (classic v1 or berry format).
+func parseYarnLock(data []byte) ([]dtos.ComponentStatusInput, error) {
+	var components []dtos.ComponentStatusInput
+	var name string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		switch {
+		case len(line) == 0 || strings.HasPrefix(line, "#"):
+			continue
+		case !strings.HasPrefix(line, " ") && strings.HasSuffix(line, ":"):
+			// Entry header: "name@range", "name@range2": (or berry: "name@npm:range, name@npm:range2":)
+			name = yarnPackageName(strings.TrimSuffix(line, ":"))
+		case len(name) > 0:
+			if m := yarnVersionRegex.FindStringSubmatch(line); m != nil {
+				components = append(components, newLockfileInput("npm", name, m[1]))
+				name = ""
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read yarn.lock: %v", err)
+	}
+	return components, nil
+}
+
+// yarnPackageName returns the package name from a yarn.lock entry header (empty if it is not a registry package).
+func yarnPackageName(header string) string {
+	spec, _, _ := strings.Cut(header, ",")
+	spec = strings.Trim(strings.TrimSpace(spec), `"`)
+	if spec == "__metadata" || len(spec) < 2 {
+		return ""
+	}
+	idx := strings.Index(spec[1:], "@") // Skip any leading scope '@'
+	if idx < 0 {
+		return ""
+	}
+	name, descriptor := spec[:idx+1], spec[idx+2:]
+	descriptor = strings.TrimPrefix(descriptor, "npm:")
+	for _, protocol := range yarnIgnoredProtocols {
+		if strings.HasPrefix(descriptor, protocol) {
+			return ""
+		}
+	}
+	// Aliased packages (alias@npm:real-name@range) resolve to the real package
+	if len(descriptor) > 1 {
+		if aliasIdx := strings.Index(descriptor[1:], "@"); aliasIdx >= 0 {
+			return descriptor[:aliasIdx+1]
+		}
+	}
+	return name
+}
+
+// parseRequirements extracts the requirements from a pip requirements.txt file.
+// Pinned (==) versions are used as is, any other specifier is passed through as the requirement.
+func parseRequirements(data []byte) ([]dtos.ComponentStatusInput, error) {
+	var components []dtos.ComponentStatusInput
+	var previous string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := previous + strings.TrimSpace(scanner.Text())
+		previous = ""
+		if strings.HasSuffix(line, `\`) { // Line continuation
+			previous = strings.TrimSuffix(line, `\`) + " "
+			continue
+		}
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		line, _, _ = strings.Cut(line, ";")  // Environment markers
+		line, _, _ = strings.Cut(line, " -") // Per-requirement options (i.e. --hash)
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "-") {
+			continue // Blank lines and global options (-r, -e, --index-url, etc.)
+		}
+		m := requirementLineRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name := strings.ToLower(pypiNameRegex.ReplaceAllString(m[1], "-"))
+		specifier := strings.ReplaceAll(m[2], " ", "")
+		switch {
+		case strings.HasPrefix(specifier, "@"):
+			specifier = "" // Direct URL reference
+		case strings.HasPrefix(specifier, "==") && !strings.ContainsAny(specifier, ",*"):
+			specifier = strings.TrimLeft(specifier, "=")
+		}
+		components = append(components, newLockfileInput("pypi", name, specifier))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read requirements file: %v", err)
+	}
+	return components, nil
+}
+
+// parsePoetryLock extracts the locked packages from a poetry.lock file.
+func parsePoetryLock(data []byte) ([]dtos.ComponentStatusInput, error) {
+	var components []dtos.ComponentStatusInput
+	var name, version string
+	inPackage := false
+	flush := func() {
+		if len(name) > 0 && len(version) > 0 {
+			components = append(components, newLockfileInput("pypi", strings.ToLower(pypiNameRegex.ReplaceAllString(name, "-")), version))
+		}
+		name, version = "", ""
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			flush()
+			inPackage = line == "[[package]]" // Ignore sub-tables such as [package.dependencies]
+			continue
+		}
+		if !inPackage {
+			continue
+		}
+		if m := tomlStringRegex.FindStringSubmatch(line); m != nil {
+			switch m[1] {
+			case "name":
+				name = m[2]
+			case "version":
+				version = m[2]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read poetry.lock: %v", err)
+	}
+	flush()
+	return components, nil
+}
+
+// parseGemfileLock extracts the gems from the GEM section of a Gemfile.lock.
+func parseGemfileLock(data []byte) ([]dtos.ComponentStatusInput, error) {
+	var components []dtos.ComponentStatusInput
+	var section string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		if len(line) > 0 && !strings.HasPrefix(line, " ") {
+			section = line
+			continue
+		}
+		if section != "GEM" {
+			continue // Only gems from a rubygems remote (not GIT/PATH sources)
+		}
+		if m := gemSpecRegex.FindStringSubmatch(line); m != nil {
+			version, _, _ := strings.Cut(m[2], "-") // Strip any platform, i.e. 1.13.10-x86_64-linux
+			components = append(components, newLockfileInput("gem", m[1], version))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Gemfile.lock: %v", err)
+	}
+	return components, nil
+}
+
+// parseGoSum extracts the modules from a go.sum file. Only modules whose content is checksummed (not just their go.mod)
+// are included, and the highest listed version of each module is used, matching minimal version selection.
+func parseGoSum(data []byte) ([]dtos.ComponentStatusInput, error) {
+	versions := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		module, version := fields[0], fields[1]
+		if current, ok := versions[module]; !ok || compareVersions("golang", version, current) > 0 {
+			versions[module] = version
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read go.sum: %v", err)
+	}
+	components := make([]dtos.ComponentStatusInput, 0, len(versions))
+	for _, module := range sortedKeys(versions) {
+		components = append(components, newLockfileInput("golang", module, versions[module]))
+	}
+	return components, nil
+}
+
+// pomDependency represents a Maven dependency declaration.
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+}
+
+// pomProject represents the parts of a Maven pom.xml we need.
+type pomProject struct {
+	GroupID string `xml:"groupId"`
+	Version string `xml:"version"`
+	Parent  struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+	DependencyManagement []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+// parsePom extracts the declared dependencies from a Maven pom.xml, resolving ${property} versions
+// and versions inherited from the dependencyManagement section.
+func parsePom(s *zap.SugaredLogger, data []byte) ([]dtos.ComponentStatusInput, error) {
+	var project pomProject
+	if err := xml.Unmarshal(data, &project); err != nil {
+		s.Errorf("Parse failure: %v", err)
+		return nil, fmt.Errorf("failed to parse pom.xml: %v", err)
+	}
+	properties := map[string]string{
+		"project.groupId":        firstNonEmpty(project.GroupID, project.Parent.GroupID),
+		"project.version":        firstNonEmpty(project.Version, project.Parent.Version),
+		"project.parent.version": project.Parent.Version,
+	}
+	for _, p := range project.Properties.Entries {
+		properties[p.XMLName.Local] = strings.TrimSpace(p.Value)
+	}
+	resolve := func(value string) string {
+		for range 5 { // Allow for properties referencing other properties
+			if !strings.Contains(value, "${") {
+				break
+			}
+			value = pomPropertyRegex.ReplaceAllStringFunc(value, func(ref string) string {
+				if v, ok := properties[ref[2:len(ref)-1]]; ok {
+					return v
+				}
+				return ref
+			})
+		}
+		return strings.TrimSpace(value)
+	}
+	managed := make(map[string]string, len(project.DependencyManagement))
+	for _, d := range project.DependencyManagement {
+		managed[resolve(d.GroupID)+":"+resolve(d.ArtifactID)] = resolve(d.Version)
+	}
+	var components []dtos.ComponentStatusInput
+	for _, d := range project.Dependencies {
+		groupID, artifactID := resolve(d.GroupID), resolve(d.ArtifactID)
+		if len(groupID) == 0 || len(artifactID) == 0 || d.Scope == "import" {
+			continue
+		}
+		version := resolve(d.Version)
+		if len(version) == 0 {
+			version = managed[groupID+":"+artifactID]
+		}
+		if strings.Contains(version, "${") {
+			s.Warnf("Unable to resolve version %v for %v:%v", version, groupID, artifactID)
+			version = ""
+		}
+		components = append(components, newLockfileInput("maven", groupID+"/"+artifactID, version))
+	}
+	return components, nil
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if len(v) > 0 {
+			return v
+		}
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of the given map in sorted order (for deterministic output).
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
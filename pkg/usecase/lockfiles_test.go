@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/components/pkg/dtos"
+)
+
+func TestDetectLockfileType(t *testing.T) {
+	tests := map[string]string{
+		"/src/app/package-lock.json": LockfileNpm,
+		"npm-shrinkwrap.json":        LockfileNpm,
+		"yarn.lock":                  LockfileYarn,
+		"requirements-dev.txt":       LockfileRequirements,
+		"poetry.lock":                LockfilePoetry,
+		"Gemfile.lock":               LockfileGemfile,
+		"go.sum":                     LockfileGoSum,
+		"pom.xml":                    LockfileMaven,
+		"sbom.cdx.json":              "",
+	}
+	for filename, want := range tests {
+		if got := DetectLockfileType(filename); got != want {
+			t.Errorf("DetectLockfileType(%v) = %v, want %v", filename, got, want)
+		}
+	}
+}
+
+func TestParseLockfile(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name         string
+		lockfileType string
+		input        string
+		want         []dtos.ComponentStatusInput
+	}{
+		{
+			name:         "package-lock v3",
+			lockfileType: LockfileNpm,
+			input: `{"lockfileVersion": 3, "packages": {
+				"": {"name": "app", "version": "1.0.0"},
+				"node_modules/react": {"version": "18.0.0"},
+				"node_modules/@babel/core": {"version": "7.20.0"},
+				"node_modules/a/node_modules/react": {"version": "17.0.2"},
+				"node_modules/local": {"resolved": "packages/local", "link": true},
+				"packages/local": {"version": "0.0.1"}}}`,
+			want: []dtos.ComponentStatusInput{
+				{Purl: "pkg:npm/@babel/core", Requirement: "7.20.0"},
+				{Purl: "pkg:npm/react", Requirement: "17.0.2"},
+				{Purl: "pkg:npm/react", Requirement: "18.0.0"},
+			},
+		},
+		{
+			name:         "package-lock v1",
+			lockfileType: LockfileNpm,
+			input: `{"lockfileVersion": 1, "dependencies": {
+				"react": {"version": "18.0.0", "dependencies": {"loose-envify": {"version": "1.4.0"}}},
+				"local": {"version": "file:../local"}}}`,
+			want: []dtos.ComponentStatusInput{
+				{Purl: "pkg:npm/react", Requirement: "18.0.0"},
+				{Purl: "pkg:npm/loose-envify", Requirement: "1.4.0"},
+			},
+		},
+		{
+			name:         "yarn classic",
+			lockfileType: LockfileYarn,
+			input: `# yarn lockfile v1
+
+"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.10.4":
+  version "7.12.13"
+  resolved "https://registry.yarnpkg.com/@babel/code-frame/-/code-frame-7.12.13.tgz"
+  dependencies:
+    "@babel/highlight" "^7.12.13"
+
+lodash@^4.17.21:
+  version "4.17.21"
+
+"string-width-cjs@npm:string-width@^4.2.0":
+  version "4.2.3"
+`,
+			want: []dtos.ComponentStatusInput{
+				{Purl: "pkg:npm/@babel/code-frame", Requirement: "7.12.13"},
+				{Purl: "pkg:npm/lodash", Requirement: "4.17.21"},
+				{Purl: "pkg:npm/string-width", Requirement: "4.2.3"},
+			},
+		},
+		{
+			name:         "yarn berry",
+			lockfileType: LockfileYarn,
+			input: `__metadata:
+  version: 6
+
+"lodash@npm:^4.17.21":
+  version: 4.17.21
+  resolution: "lodash@npm:4.17.21"
+
+"my-app@workspace:.":
+  version: 0.0.0-use.local
+`,
+			want: []dtos.ComponentStatusInput{{Purl: "pkg:npm/lodash", Requirement: "4.17.21"}},
+		},
+		{
+			name:         "requirements",
+			lockfileType: LockfileRequirements,
+			input: `# Production requirements
+-r base.txt
+Django==3.2.4 ; python_version >= "3.6"
+requests[security] == 2.31.0 \
+    --hash=sha256:abc
+zope.interface>=5.0,<6
+-e git+https://github.com/org/repo.git#egg=repo
+pkg @ https://example.com/pkg-1.0.tar.gz
+`,
+			want: []dtos.ComponentStatusInput{
+				{Purl: "pkg:pypi/django", Requirement: "3.2.4"},
+				{Purl: "pkg:pypi/requests", Requirement: "2.31.0"},
+				{Purl: "pkg:pypi/zope-interface", Requirement: ">=5.0,<6"},
+				{Purl: "pkg:pypi/pkg"},
+			},
+		},
+		{
+			name:         "poetry",
+			lockfileType: LockfilePoetry,
+			input: `[[package]]
+name = "certifi"
+version = "2023.7.22"
+python-versions = ">=3.6"
+
+[[package]]
+name = "Typing_Extensions"
+version = "4.7.1"
+
+[package.dependencies]
+version = "ignored"
+
+[metadata]
+lock-version = "2.0"
+`,
+			want: []dtos.ComponentStatusInput{
+				{Purl: "pkg:pypi/certifi", Requirement: "2023.7.22"},
+				{Purl: "pkg:pypi/typing-extensions", Requirement: "4.7.1"},
+			},
+		},
+		{
+			name:         "gemfile",
+			lockfileType: LockfileGemfile,
+			input: `GIT
+  remote: https://github.com/org/private.git
+  specs:
+    private (1.0.0)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    actionpack (7.0.4)
+      rack (~> 2.0)
+    nokogiri (1.13.10-x86_64-linux)
+    tablestyle (0.1.0)
+
+PLATFORMS
+  x86_64-linux
+`,
+			want: []dtos.ComponentStatusInput{
+				{Purl: "pkg:gem/actionpack", Requirement: "7.0.4"},
+				{Purl: "pkg:gem/nokogiri", Requirement: "1.13.10"},
+				{Purl: "pkg:gem/tablestyle", Requirement: "0.1.0"},
+			},
+		},
+		{
+			name:         "go.sum",
+			lockfileType: LockfileGoSum,
+			input: `github.com/jmoiron/sqlx v1.3.5 h1:abc=
+github.com/jmoiron/sqlx v1.3.5/go.mod h1:def=
+github.com/jmoiron/sqlx v1.4.0 h1:ghi=
+github.com/jmoiron/sqlx v1.4.0/go.mod h1:jkl=
+golang.org/x/mod v0.20.0/go.mod h1:mno=
+google.golang.org/grpc v1.60.0-rc.1 h1:pqr=
+`,
+			want: []dtos.ComponentStatusInput{
+				{Purl: "pkg:golang/github.com/jmoiron/sqlx", Requirement: "v1.4.0"},
+				{Purl: "pkg:golang/google.golang.org/grpc", Requirement: "v1.60.0-rc.1"},
+			},
+		},
+		{
+			name:         "pom",
+			lockfileType: LockfileMaven,
+			input: `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <groupId>com.example</groupId>
+  <artifactId>app</artifactId>
+  <version>1.0.0</version>
+  <properties>
+    <jackson.version>2.15.2</jackson.version>
+    <jackson.databind.version>${jackson.version}</jackson.databind.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.slf4j</groupId>
+        <artifactId>slf4j-api</artifactId>
+        <version>2.0.9</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+      <version>${jackson.databind.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>${project.groupId}</groupId>
+      <artifactId>module</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <version>${junit.version}</version>
+      <scope>test</scope>
+    </dependency>
+  </dependencies>
+</project>`,
+			want: []dtos.ComponentStatusInput{
+				{Purl: "pkg:maven/com.fasterxml.jackson.core/jackson-databind", Requirement: "2.15.2"},
+				{Purl: "pkg:maven/org.slf4j/slf4j-api", Requirement: "2.0.9"},
+				{Purl: "pkg:maven/com.example/module", Requirement: "1.0.0"},
+				{Purl: "pkg:maven/junit/junit"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLockfile(zlog.S, tt.lockfileType, []byte(tt.input))
+			if err != nil {
+				t.Fatalf("ParseLockfile() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Components, tt.want) {
+				t.Errorf("ParseLockfile() = %+v, want %+v", got.Components, tt.want)
+			}
+		})
+	}
+
+	badTable := []struct {
+		lockfileType string
+		input        string
+	}{
+		{lockfileType: LockfileNpm, input: ""},
+		{lockfileType: LockfileNpm, input: "not json"},
+		{lockfileType: LockfileNpm, input: `{"packages": {"": {"version": "1.0.0"}}}`},
+		{lockfileType: LockfileMaven, input: "<project><dependencies>"},
+		{lockfileType: "cargo", input: "[[package]]"},
+	}
+	for _, tt := range badTable {
+		if _, err := ParseLockfile(zlog.S, tt.lockfileType, []byte(tt.input)); err == nil {
+			t.Errorf("ParseLockfile() expected an error for %v input: %q", tt.lockfileType, tt.input)
+		}
+	}
+}
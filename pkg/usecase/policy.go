@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// dateLayouts lists the date formats used for the indexed and status change dates.
+var dateLayouts = []string{time.DateOnly, time.DateTime, time.RFC3339, "2006-01-02T15:04:05"}
+
+// policyStatus is the status of either the component or version being evaluated.
+type policyStatus struct {
+	scope            string
+	known            bool
+	status           string // Mapped status
+	repositoryStatus string
+	indexedDate      string
+	statusChangeDate string
+}
+
+// EvaluatePolicy evaluates the supplied policy against each component status, returning an allow/warn/deny verdict
+// per component and overall. The most severe action of all matching rules wins, and components matching no rule get
+// the policy default. Statuses are classified with the StatusMapper so local and remote results are treated the same.
+func EvaluatePolicy(policy *config.Policy, statusMapper *config.StatusMapper, statuses dtos.ComponentsStatusOutput,
+	now time.Time) dtos.ComponentsPolicyOutput {
+	output := dtos.ComponentsPolicyOutput{
+		Policy:     policy.Name,
+		Verdict:    config.PolicyAllow,
+		Components: make([]dtos.ComponentPolicyResult, 0, len(statuses.Components)),
+	}
+	for _, component := range statuses.Components {
+		result := evaluateComponentPolicy(policy, statusMapper, component, now)
+		switch result.Verdict {
+		case config.PolicyDeny:
+			output.Summary.Deny++
+		case config.PolicyWarn:
+			output.Summary.Warn++
+		default:
+			output.Summary.Allow++
+		}
+		if config.PolicyActionRank(result.Verdict) > config.PolicyActionRank(output.Verdict) {
+			output.Verdict = result.Verdict
+		}
+		output.Components = append(output.Components, result)
+	}
+	return output
+}
+
+// evaluateComponentPolicy evaluates every policy rule against a single component status.
+func evaluateComponentPolicy(policy *config.Policy, statusMapper *config.StatusMapper, component dtos.ComponentStatusOutput,
+	now time.Time) dtos.ComponentPolicyResult {
+	result := dtos.ComponentPolicyResult{
+		Purl:        component.Purl,
+		Requirement: component.Requirement,
+	}
+	if vs := component.VersionStatus; vs != nil {
+		result.Version = vs.Version
+	}
+	candidates := policyStatuses(statusMapper, component)
+	matched := false
+	for _, rule := range policy.Rules {
+		reason, ok := matchPolicyRule(rule.When, component.Purl, candidates, now)
+		if !ok {
+			continue
+		}
+		if !matched || config.PolicyActionRank(rule.Action) > config.PolicyActionRank(result.Verdict) {
+			result.Verdict = rule.Action
+		}
+		matched = true
+		result.Rules = append(result.Rules, rule.Name)
+		if len(rule.Description) > 0 {
+			reason = rule.Description
+		}
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%s: %s", rule.Name, reason))
+	}
+	if !matched {
+		result.Verdict = policy.Default
+	}
+	return result
+}
+
+// policyStatuses extracts the component and version statuses the policy conditions are evaluated against.
+func policyStatuses(statusMapper *config.StatusMapper, component dtos.ComponentStatusOutput) []policyStatus {
+	statuses := make([]policyStatus, 0, 2)
+	cs := component.ComponentStatus
+	componentStatus := policyStatus{scope: config.PolicyScopeComponent, known: !isUnknownComponent(component)}
+	if componentStatus.known && cs != nil {
+		componentStatus.status = mapStatus(statusMapper, cs.RepositoryStatus, cs.Status)
+		componentStatus.repositoryStatus = cs.RepositoryStatus
+		componentStatus.indexedDate = cs.LastIndexedDate
+		componentStatus.statusChangeDate = cs.StatusChangeDate
+	}
+	statuses = append(statuses, componentStatus)
+	if vs := component.VersionStatus; vs != nil {
+		versionStatus := policyStatus{scope: config.PolicyScopeVersion, known: !isUnknownVersion(vs)}
+		if versionStatus.known {
+			versionStatus.status = mapStatus(statusMapper, vs.RepositoryStatus, vs.Status)
+			versionStatus.repositoryStatus = vs.RepositoryStatus
+			versionStatus.indexedDate = vs.IndexedDate
+			versionStatus.statusChangeDate = vs.StatusChangeDate
+		}
+		statuses = append(statuses, versionStatus)
+	}
+	return statuses
+}
+
+// matchPolicyRule checks whether a rule condition matches the component, returning a description of why it matched.
+// For scoped conditions, all the status conditions must be met by the same (component or version) status.
+func matchPolicyRule(cond config.PolicyCondition, purl string, candidates []policyStatus, now time.Time) (string, bool) {
+	if !cond.MatchesPurl(purl) {
+		return "", false
+	}
+	for _, candidate := range candidates {
+		if cond.Scope != config.PolicyScopeAny && cond.Scope != candidate.scope {
+			continue
+		}
+		if reason, ok := matchPolicyStatus(cond, candidate, now); ok {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
+// matchPolicyStatus checks the status conditions against a single component or version status.
+func matchPolicyStatus(cond config.PolicyCondition, candidate policyStatus, now time.Time) (string, bool) {
+	var reasons []string
+	if cond.Unknown != nil {
+		if *cond.Unknown == candidate.known {
+			return "", false
+		}
+		if !candidate.known {
+			reasons = append(reasons, candidate.scope+" is unknown")
+		}
+	}
+	if len(cond.Status) > 0 {
+		if !containsFold(cond.Status, candidate.status) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("%s status is %s", candidate.scope, candidate.status))
+	}
+	if len(cond.RepositoryStatus) > 0 {
+		if !containsFold(cond.RepositoryStatus, candidate.repositoryStatus) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("%s repository status is %s", candidate.scope, candidate.repositoryStatus))
+	}
+	if age := cond.LastIndexedAge(); age.IsSet() {
+		date, ok := parseDate(candidate.indexedDate)
+		if !ok || !date.Before(age.Cutoff(now)) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("%s last indexed on %s (older than %s)", candidate.scope, candidate.indexedDate, cond.LastIndexedOlderThan))
+	}
+	if age := cond.StatusChangedAge(); age.IsSet() {
+		date, ok := parseDate(candidate.statusChangeDate)
+		if !ok || !date.Before(age.Cutoff(now)) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("%s status changed on %s (older than %s)", candidate.scope, candidate.statusChangeDate, cond.StatusChangedOlderThan))
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, "matched")
+	}
+	return strings.Join(reasons, ", "), true
+}
+
+// isUnknownComponent reports whether the component is not known to the knowledge base.
+func isUnknownComponent(component dtos.ComponentStatusOutput) bool {
+	cs := component.ComponentStatus
+	if cs == nil {
+		return component.VersionStatus == nil
+	}
+	return len(cs.Status) == 0 && len(cs.RepositoryStatus) == 0 && (cs.ErrorCode != nil || cs.ErrorMessage != nil)
+}
+
+// isUnknownVersion reports whether the requested version is not known to the knowledge base.
+func isUnknownVersion(vs *dtos.VersionStatusOutput) bool {
+	return vs != nil && (vs.ErrorCode != nil || vs.ErrorMessage != nil)
+}
+
+// mapStatus classifies a registry status using the StatusMapper, falling back to the already mapped status.
+func mapStatus(statusMapper *config.StatusMapper, repositoryStatus, status string) string {
+	if len(repositoryStatus) > 0 && statusMapper != nil {
+		return statusMapper.MapStatus(repositoryStatus)
+	}
+	return status
+}
+
+// parseDate parses a date in any of the formats stored in the knowledge base.
+func parseDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// containsFold reports whether the list contains the value (case-insensitive).
+func containsFold(list []string, value string) bool {
+	for _, v := range list {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	myconfig "scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+)
+
+func TestEvaluatePolicy(t *testing.T) {
+	policy, err := myconfig.LoadPolicyFile("../config/tests/policy.yaml")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when loading the policy", err)
+	}
+	notFound := domain.ComponentNotFound
+	statuses := dtos.ComponentsStatusOutput{Components: []dtos.ComponentStatusOutput{
+		{ // Healthy, recently indexed component
+			Purl:            "pkg:npm/react",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "active", RepositoryStatus: "active", LastIndexedDate: "2026-05-01"},
+			VersionStatus:   &dtos.VersionStatusOutput{Version: "18.0.0", Status: "active", RepositoryStatus: "active"},
+		},
+		{ // Yanked version - mapped to removed by the StatusMapper
+			Purl:            "pkg:gem/tablestyle",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "active", RepositoryStatus: "active", LastIndexedDate: "2026-05-01"},
+			VersionStatus:   &dtos.VersionStatusOutput{Version: "0.1.0", Status: "yanked", RepositoryStatus: "yanked"},
+		},
+		{ // Archived component
+			Purl:            "pkg:github/scanoss/old",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "deprecated", RepositoryStatus: "archived", LastIndexedDate: "2026-01-10 10:00:00"},
+		},
+		{ // Archived and not indexed for over two years - deny wins over warn
+			Purl:            "pkg:github/scanoss/older",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "deprecated", RepositoryStatus: "archived", LastIndexedDate: "2023-01-10"},
+		},
+		{ // Unknown component
+			Purl:            "pkg:npm/does-not-exist",
+			ComponentStatus: &dtos.ComponentStatusInfo{ErrorMessage: dtos.StringPtr("Component not found"), ErrorCode: &notFound},
+		},
+		{ // Internal component - explicitly allowed, but the unknown rule is more severe
+			Purl:            "pkg:npm/@acme/widgets",
+			ComponentStatus: &dtos.ComponentStatusInfo{ErrorMessage: dtos.StringPtr("Component not found"), ErrorCode: &notFound},
+		},
+	}}
+	now := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+	output := EvaluatePolicy(policy, myconfig.NewStatusMapper(nil, nil), statuses, now)
+
+	if output.Policy != "ci-policy" || output.Verdict != myconfig.PolicyDeny {
+		t.Errorf("unexpected policy/verdict: %v/%v", output.Policy, output.Verdict)
+	}
+	wantSummary := dtos.ComponentsPolicySummary{Allow: 1, Warn: 3, Deny: 2}
+	if output.Summary != wantSummary {
+		t.Errorf("summary = %+v, want %+v", output.Summary, wantSummary)
+	}
+	want := []struct {
+		verdict string
+		rules   []string
+	}{
+		{verdict: myconfig.PolicyAllow},
+		{verdict: myconfig.PolicyDeny, rules: []string{"removed"}},
+		{verdict: myconfig.PolicyWarn, rules: []string{"archived"}},
+		{verdict: myconfig.PolicyDeny, rules: []string{"archived", "stale"}},
+		{verdict: myconfig.PolicyWarn, rules: []string{"unknown"}},
+		{verdict: myconfig.PolicyWarn, rules: []string{"unknown", "internal"}},
+	}
+	if len(output.Components) != len(want) {
+		t.Fatalf("got %d components, want %d", len(output.Components), len(want))
+	}
+	for i, w := range want {
+		got := output.Components[i]
+		if got.Verdict != w.verdict || !slices.Equal(got.Rules, w.rules) {
+			t.Errorf("%v: verdict/rules = %v/%v, want %v/%v", got.Purl, got.Verdict, got.Rules, w.verdict, w.rules)
+		}
+		if len(got.Reasons) != len(got.Rules) {
+			t.Errorf("%v: expected a reason per matched rule: %v", got.Purl, got.Reasons)
+		}
+	}
+	if r := output.Components[1].Reasons[0]; r != "removed: Component or version has been removed from its registry" {
+		t.Errorf("unexpected reason: %v", r)
+	}
+}
+
+func TestEvaluatePolicyScope(t *testing.T) {
+	policy, err := myconfig.ParsePolicy([]byte("default: warn\nrules:\n" +
+		"  - name: removed-version\n    action: deny\n    when:\n      scope: version\n      status: [removed]\n"))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing the policy", err)
+	}
+	statuses := dtos.ComponentsStatusOutput{Components: []dtos.ComponentStatusOutput{
+		{ // Removed component, but the rule only applies to versions
+			Purl:            "pkg:npm/left-pad",
+			ComponentStatus: &dtos.ComponentStatusInfo{Status: "removed", RepositoryStatus: "unpublished"},
+		},
+		{
+			Purl:          "pkg:npm/left-pad",
+			VersionStatus: &dtos.VersionStatusOutput{Version: "1.0.0", Status: "removed", RepositoryStatus: "unpublished"},
+		},
+	}}
+	output := EvaluatePolicy(policy, nil, statuses, time.Now())
+	if v := output.Components[0].Verdict; v != myconfig.PolicyWarn {
+		t.Errorf("expected the policy default for a component level status, got %v", v)
+	}
+	if v := output.Components[1].Verdict; v != myconfig.PolicyDeny {
+		t.Errorf("expected a deny for a version level status, got %v", v)
+	}
+	if output.Components[1].Version != "1.0.0" {
+		t.Errorf("expected the version to be reported, got %q", output.Components[1].Version)
+	}
+}
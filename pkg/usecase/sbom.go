@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	purlhelper "github.com/scanoss/go-purl-helper/pkg"
+	"go.uber.org/zap"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// Supported SBOM formats.
+const (
+	SBOMFormatCycloneDX = "cyclonedx"
+	SBOMFormatSPDXJSON  = "spdx-json"
+	SBOMFormatSPDXTag   = "spdx-tag"
+)
+
+// cycloneDXComponent represents the parts of a CycloneDX component we need (including nested components).
+type cycloneDXComponent struct {
+	Purl       string               `json:"purl"`
+	Version    string               `json:"version"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+// cycloneDXBOM represents the parts of a CycloneDX JSON document we need.
+type cycloneDXBOM struct {
+	Components []cycloneDXComponent `json:"components"`
+}
+
+// spdxDocument represents the parts of an SPDX JSON document we need.
+type spdxDocument struct {
+	Packages []struct {
+		VersionInfo  string `json:"versionInfo"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+// DetectSBOMFormat works out which SBOM format the supplied data is in.
+func DetectSBOMFormat(data []byte) (string, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return "", errors.New("no SBOM data supplied")
+	}
+	if trimmed[0] == '{' {
+		var probe struct {
+			BomFormat   string `json:"bomFormat"`
+			SpdxVersion string `json:"spdxVersion"`
+		}
+		if err := json.Unmarshal(trimmed, &probe); err != nil {
+			return "", fmt.Errorf("failed to parse SBOM JSON: %v", err)
+		}
+		switch {
+		case strings.EqualFold(probe.BomFormat, "CycloneDX"):
+			return SBOMFormatCycloneDX, nil
+		case len(probe.SpdxVersion) > 0:
+			return SBOMFormatSPDXJSON, nil
+		}
+		return "", errors.New("unrecognised JSON SBOM format (expected CycloneDX or SPDX)")
+	}
+	if bytes.HasPrefix(trimmed, []byte("SPDXVersion:")) || bytes.Contains(trimmed, []byte("\nSPDXVersion:")) {
+		return SBOMFormatSPDXTag, nil
+	}
+	return "", errors.New("unrecognised SBOM format (expected CycloneDX JSON, SPDX JSON or SPDX tag-value)")
+}
+
+// ParseSBOM extracts every purl (and its version) from a CycloneDX JSON, SPDX JSON or SPDX tag-value document.
+// Each purl is returned without its version, which is supplied as the requirement instead.
+// Duplicate purl/version pairs are only returned once.
+func ParseSBOM(s *zap.SugaredLogger, data []byte) (dtos.ComponentsStatusInput, error) {
+	format, err := DetectSBOMFormat(data)
+	if err != nil {
+		return dtos.ComponentsStatusInput{}, err
+	}
+	var components []dtos.ComponentStatusInput
+	switch format {
+	case SBOMFormatCycloneDX:
+		components, err = parseCycloneDX(s, data)
+	case SBOMFormatSPDXJSON:
+		components, err = parseSPDXJSON(s, data)
+	case SBOMFormatSPDXTag:
+		components, err = parseSPDXTagValue(s, data)
+	}
+	if err != nil {
+		return dtos.ComponentsStatusInput{}, err
+	}
+	components = uniqueStatusInputs(components)
+	if len(components) == 0 {
+		return dtos.ComponentsStatusInput{}, fmt.Errorf("no purls found in %v SBOM", format)
+	}
+	return dtos.ComponentsStatusInput{Components: components}, nil
+}
+
+// parseCycloneDX extracts the purls from a CycloneDX JSON document.
+func parseCycloneDX(s *zap.SugaredLogger, data []byte) ([]dtos.ComponentStatusInput, error) {
+	var bom cycloneDXBOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		s.Errorf("Parse failure: %v", err)
+		return nil, fmt.Errorf("failed to parse CycloneDX SBOM: %v", err)
+	}
+	var components []dtos.ComponentStatusInput
+	var walk func(list []cycloneDXComponent)
+	walk = func(list []cycloneDXComponent) {
+		for _, c := range list {
+			if input, ok := newStatusInputFromPurl(s, c.Purl, c.Version); ok {
+				components = append(components, input)
+			}
+			walk(c.Components)
+		}
+	}
+	walk(bom.Components)
+	return components, nil
+}
+
+// parseSPDXJSON extracts the purls from the package external references of an SPDX JSON document.
+func parseSPDXJSON(s *zap.SugaredLogger, data []byte) ([]dtos.ComponentStatusInput, error) {
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		s.Errorf("Parse failure: %v", err)
+		return nil, fmt.Errorf("failed to parse SPDX SBOM: %v", err)
+	}
+	var components []dtos.ComponentStatusInput
+	for _, p := range doc.Packages {
+		for _, ref := range p.ExternalRefs {
+			if !strings.EqualFold(ref.ReferenceType, "purl") {
+				continue
+			}
+			if input, ok := newStatusInputFromPurl(s, ref.ReferenceLocator, p.VersionInfo); ok {
+				components = append(components, input)
+			}
+		}
+	}
+	return components, nil
+}
+
+// parseSPDXTagValue extracts the purls from the package external references of an SPDX tag-value document.
+func parseSPDXTagValue(s *zap.SugaredLogger, data []byte) ([]dtos.ComponentStatusInput, error) {
+	var components []dtos.ComponentStatusInput
+	var version string
+	var purls []string
+	flush := func() {
+		for _, p := range purls {
+			if input, ok := newStatusInputFromPurl(s, p, version); ok {
+				components = append(components, input)
+			}
+		}
+		version, purls = "", nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		tag, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch tag {
+		case "PackageName":
+			flush() // A new package section has started
+		case "PackageVersion":
+			version = value
+		case "ExternalRef":
+			// ExternalRef: <category> <type> <locator>
+			fields := strings.Fields(value)
+			if len(fields) == 3 && strings.EqualFold(fields[1], "purl") {
+				purls = append(purls, fields[2])
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read SPDX tag-value SBOM: %v", err)
+	}
+	flush()
+	return components, nil
+}
+
+// newStatusInputFromPurl converts a purl into a status request, using the purl version as the requirement
+// (or the supplied fallback version if the purl has none).
+func newStatusInputFromPurl(s *zap.SugaredLogger, purlString, version string) (dtos.ComponentStatusInput, bool) {
+	purlString = strings.TrimSpace(purlString)
+	if len(purlString) == 0 {
+		return dtos.ComponentStatusInput{}, false
+	}
+	purl, err := purlhelper.PurlFromString(purlString)
+	if err != nil {
+		s.Warnf("Skipping invalid purl %v: %v", purlString, err)
+		return dtos.ComponentStatusInput{}, false
+	}
+	purlName, err := purlhelper.PurlNameFromString(purlString)
+	if err != nil {
+		s.Warnf("Skipping invalid purl %v: %v", purlString, err)
+		return dtos.ComponentStatusInput{}, false
+	}
+	requirement := purl.Version
+	if len(requirement) == 0 {
+		requirement = strings.TrimSpace(version)
+	}
+	return dtos.ComponentStatusInput{Purl: "pkg:" + purl.Type + "/" + purlName, Requirement: requirement}, true
+}
+
+// uniqueStatusInputs removes duplicate purl/requirement pairs, preserving the original order.
+func uniqueStatusInputs(components []dtos.ComponentStatusInput) []dtos.ComponentStatusInput {
+	seen := make(map[dtos.ComponentStatusInput]bool, len(components))
+	unique := make([]dtos.ComponentStatusInput, 0, len(components))
+	for _, c := range components {
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		unique = append(unique, c)
+	}
+	return unique
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/components/pkg/dtos"
+)
+
+const cycloneDXSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {"type": "library", "name": "react", "version": "18.0.0", "purl": "pkg:npm/react@18.0.0"},
+    {"type": "library", "name": "tablestyle", "version": "0.1.0", "purl": "pkg:gem/tablestyle",
+      "components": [{"type": "library", "name": "nested", "purl": "pkg:pypi/Nested@1.2.3"}]},
+    {"type": "library", "name": "no-purl", "version": "1.0.0"},
+    {"type": "library", "name": "react", "version": "18.0.0", "purl": "pkg:npm/react@18.0.0"}
+  ]
+}`
+
+const spdxJSONSBOM = `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {"name": "react", "versionInfo": "18.0.0", "externalRefs": [
+      {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/react"}
+    ]},
+    {"name": "engine", "versionInfo": "1.0", "externalRefs": [
+      {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:x:y:1.0"}
+    ]}
+  ]
+}`
+
+const spdxTagValueSBOM = `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+
+PackageName: react
+PackageVersion: 18.0.0
+ExternalRef: PACKAGE-MANAGER purl pkg:npm/react
+
+PackageName: tablestyle
+ExternalRef: PACKAGE-MANAGER purl pkg:gem/tablestyle@0.1.0
+
+PackageName: no-refs
+PackageVersion: 2.0.0
+`
+
+func TestParseSBOM(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name   string
+		input  string
+		format string
+		want   []dtos.ComponentStatusInput
+	}{
+		{
+			name:   "cyclonedx",
+			input:  cycloneDXSBOM,
+			format: SBOMFormatCycloneDX,
+			want: []dtos.ComponentStatusInput{
+				{Purl: "pkg:npm/react", Requirement: "18.0.0"},
+				{Purl: "pkg:gem/tablestyle", Requirement: "0.1.0"},
+				{Purl: "pkg:pypi/nested", Requirement: "1.2.3"},
+			},
+		},
+		{
+			name:   "spdx json",
+			input:  spdxJSONSBOM,
+			format: SBOMFormatSPDXJSON,
+			want:   []dtos.ComponentStatusInput{{Purl: "pkg:npm/react", Requirement: "18.0.0"}},
+		},
+		{
+			name:   "spdx tag-value",
+			input:  spdxTagValueSBOM,
+			format: SBOMFormatSPDXTag,
+			want: []dtos.ComponentStatusInput{
+				{Purl: "pkg:npm/react", Requirement: "18.0.0"},
+				{Purl: "pkg:gem/tablestyle", Requirement: "0.1.0"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := DetectSBOMFormat([]byte(tt.input))
+			if err != nil || format != tt.format {
+				t.Errorf("DetectSBOMFormat() = %v, %v, want %v", format, err, tt.format)
+			}
+			got, err := ParseSBOM(zlog.S, []byte(tt.input))
+			if err != nil {
+				t.Fatalf("ParseSBOM() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Components, tt.want) {
+				t.Errorf("ParseSBOM() = %+v, want %+v", got.Components, tt.want)
+			}
+		})
+	}
+
+	badTable := []string{
+		"",
+		"not an sbom",
+		`{"bomFormat": "Other"}`,
+		`{"bomFormat": "CycloneDX", "components": []}`,
+		`{"spdxVersion": "SPDX-2.3", "packages": "bad"}`,
+	}
+	for _, input := range badTable {
+		if _, err := ParseSBOM(zlog.S, []byte(input)); err == nil {
+			t.Errorf("ParseSBOM() expected an error for input: %q", input)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/models"
+)
+
+// enrichResults adds the latest version, license, status and popularity of each component to the search results.
+// Results are still returned (without details) if the details cannot be retrieved.
+func (c ComponentUseCase) enrichResults(results []dtos.ComponentSearchOutput, components []models.Component) {
+	details, err := c.components.GetComponentDetails(components)
+	if err != nil {
+		c.s.Warnf("Problem encountered retrieving the search result details: %v", err)
+		return
+	}
+	applyDetails(results, details, c.statusMapper)
+}
+
+// applyDetails adds the details of each component (if found) to its search result.
+func applyDetails(results []dtos.ComponentSearchOutput, details map[string]models.ComponentDetails, statusMapper *config.StatusMapper) {
+	for i := range results {
+		d, ok := details[results[i].Purl]
+		if !ok {
+			continue
+		}
+		status := d.Status.String
+		if statusMapper != nil {
+			status = statusMapper.MapStatus(status)
+		}
+		latestDate := d.LatestVersionDate.String
+		latest, ok := latestRelease(d.PurlType, d.Releases)
+		if ok {
+			latestDate = latest.Date.String
+		}
+		results[i].Details = &dtos.ComponentSearchDetails{
+			LatestVersion:     latest.Version,
+			LatestVersionDate: latestDate,
+			License:           d.License.String,
+			SpdxID:            d.SpdxID.String,
+			Status:            status,
+			RepositoryStatus:  d.Status.String,
+			Stars:             d.GitStars.Int64,
+			Forks:             d.GitForks.Int64,
+			Issues:            d.GitIssues.Int64,
+			Versions:          d.Versions.Int64,
+		}
+	}
+}
+
+// latestRelease returns the highest release of a component using the version ordering rules of its ecosystem
+// (see compareVersions), preferring the stable releases over the pre-releases.
+func latestRelease(purlType string, releases []models.ComponentRelease) (models.ComponentRelease, bool) {
+	var latest models.ComponentRelease
+	found, latestPre := false, false
+	for _, r := range releases {
+		pre := isPreRelease(purlType, r.Version)
+		switch {
+		case !found, latestPre && !pre:
+		case pre && !latestPre:
+			continue
+		default:
+			key := versionKey(purlType, models.AllURL{Version: r.Version, Semver: r.Semver})
+			latestKey := versionKey(purlType, models.AllURL{Version: latest.Version, Semver: latest.Semver})
+			if c := compareVersions(purlType, key, latestKey); c < 0 || (c == 0 && r.Version <= latest.Version) {
+				continue
+			}
+		}
+		latest, found, latestPre = r, true, pre
+	}
+	return latest, found
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"database/sql"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/models"
+)
+
+func TestApplyDetails(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	results := []dtos.ComponentSearchOutput{{Purl: "pkg:npm/left-pad"}, {Purl: "pkg:npm/unknown"}}
+	details := map[string]models.ComponentDetails{
+		"pkg:npm/left-pad": {
+			PurlType:          "npm",
+			LatestVersionDate: sql.NullString{String: "2020-01-01", Valid: true},
+			Releases: []models.ComponentRelease{
+				{Version: "1.2.0", Date: sql.NullString{String: "2019-06-01", Valid: true}}, // Released after 1.3.0 (a backport)
+				{Version: "1.3.0", Date: sql.NullString{String: "2018-04-09", Valid: true}},
+			},
+			Status:   sql.NullString{String: "unlisted", Valid: true},
+			GitStars: sql.NullInt64{Int64: 1200, Valid: true},
+		},
+	}
+	applyDetails(results, details, config.NewStatusMapper(zlog.S, nil))
+	d := results[0].Details
+	if d == nil || d.LatestVersion != "1.3.0" || d.LatestVersionDate != "2018-04-09" || d.Status != "removed" ||
+		d.RepositoryStatus != "unlisted" || d.Stars != 1200 {
+		t.Errorf("applyDetails() unexpected details: %+v", d)
+	}
+	if results[1].Details != nil {
+		t.Errorf("applyDetails() expected no details for an unknown component: %+v", results[1].Details)
+	}
+}
+
+func TestLatestRelease(t *testing.T) {
+	releases := func(versions ...string) []models.ComponentRelease {
+		var list []models.ComponentRelease
+		for _, v := range versions {
+			list = append(list, models.ComponentRelease{Version: v})
+		}
+		return list
+	}
+	tests := []struct {
+		purlType string
+		releases []models.ComponentRelease
+		want     string
+	}{
+		{purlType: "npm", releases: releases("1.9.0", "1.10.0", "2.0.0-beta.1", "1.10.0-rc.1"), want: "1.10.0"},
+		{purlType: "npm", releases: releases("2.0.0-alpha.1", "2.0.0-beta.1"), want: "2.0.0-beta.1"}, // Only pre-releases
+		{purlType: "pypi", releases: releases("1.0", "1.0.post1", "1.1rc1", "1.0.dev1"), want: "1.0.post1"},
+		{purlType: "maven", releases: releases("1.0", "1.0-SNAPSHOT", "1.0.1", "1.1-beta"), want: "1.0.1"},
+		{purlType: "deb", releases: releases("1:1.0-1", "2.0-1", "1:1.0~rc1-1"), want: "1:1.0-1"},
+		{purlType: "npm", releases: releases("v1.0.0", "1.0.0"), want: "v1.0.0"}, // Ties are broken deterministically
+	}
+	for _, tt := range tests {
+		if got, ok := latestRelease(tt.purlType, tt.releases); !ok || got.Version != tt.want {
+			t.Errorf("latestRelease(%v, %v) = %v, want %v", tt.purlType, tt.releases, got.Version, tt.want)
+		}
+	}
+	if _, ok := latestRelease("npm", nil); ok {
+		t.Errorf("latestRelease() expected no release")
+	}
+}
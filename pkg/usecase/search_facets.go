@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"sort"
+
+	"scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/models"
+)
+
+// buildFacets converts the facet counts of a search, merging the counts of the statuses mapping to the same value.
+func buildFacets(facets *models.ComponentFacets, statusMapper *config.StatusMapper) *dtos.ComponentSearchFacets {
+	if facets == nil {
+		return nil
+	}
+	return &dtos.ComponentSearchFacets{
+		PurlType: convertFacetCounts(facets.PurlType),
+		License:  convertFacetCounts(facets.License),
+		Status:   mapStatusFacet(facets.Status, statusMapper),
+		Vendor:   convertFacetCounts(facets.Vendor),
+	}
+}
+
+// convertFacetCounts converts the counts of a facet.
+func convertFacetCounts(counts []models.FacetCount) []dtos.FacetCount {
+	output := make([]dtos.FacetCount, 0, len(counts))
+	for _, c := range counts {
+		output = append(output, dtos.FacetCount{Value: c.Value, Count: c.Count})
+	}
+	return output
+}
+
+// mapStatusFacet maps the statuses of the status facet, returning the most common mapped statuses
+// (up to models.MaxFacetValues) sorted by descending count and then value.
+func mapStatusFacet(counts []models.FacetCount, statusMapper *config.StatusMapper) []dtos.FacetCount {
+	mapped := map[string]int{}
+	for _, c := range counts {
+		status := c.Value
+		if statusMapper != nil && status != models.UnknownFacetValue {
+			status = statusMapper.MapStatus(status)
+		}
+		mapped[status] += c.Count
+	}
+	output := make([]dtos.FacetCount, 0, len(mapped))
+	for value, count := range mapped {
+		output = append(output, dtos.FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Count != output[j].Count {
+			return output[i].Count > output[j].Count
+		}
+		return output[i].Value < output[j].Value
+	})
+	if len(output) > models.MaxFacetValues {
+		output = output[:models.MaxFacetValues]
+	}
+	return output
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"testing"
+
+	"scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/models"
+)
+
+func TestBuildFacets(t *testing.T) {
+	if buildFacets(nil, nil) != nil {
+		t.Errorf("buildFacets() expected no facets")
+	}
+	facets := buildFacets(&models.ComponentFacets{
+		PurlType: []models.FacetCount{{Value: "npm", Count: 2}, {Value: "github", Count: 1}},
+		License:  []models.FacetCount{{Value: "MIT", Count: 2}},
+		Status: []models.FacetCount{{Value: "active", Count: 2}, {Value: "unlisted", Count: 1},
+			{Value: "yanked", Count: 2}, {Value: models.UnknownFacetValue, Count: 1}},
+	}, config.NewStatusMapper(nil, nil))
+	if len(facets.PurlType) != 2 || facets.PurlType[0] != (dtos.FacetCount{Value: "npm", Count: 2}) {
+		t.Errorf("buildFacets() unexpected purl types: %+v", facets.PurlType)
+	}
+	if len(facets.License) != 1 || len(facets.Vendor) != 0 {
+		t.Errorf("buildFacets() unexpected licenses/vendors: %+v/%+v", facets.License, facets.Vendor)
+	}
+	// Statuses mapping to the same value are merged
+	want := []dtos.FacetCount{{Value: "removed", Count: 3}, {Value: "active", Count: 2}, {Value: models.UnknownFacetValue, Count: 1}}
+	if len(facets.Status) != len(want) {
+		t.Fatalf("buildFacets() unexpected statuses: %+v", facets.Status)
+	}
+	for i := range want {
+		if facets.Status[i] != want[i] {
+			t.Errorf("buildFacets() status %d = %+v, want %+v", i, facets.Status[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/models"
+)
+
+// convertSearchFilter validates the search filter and converts it into a model filter.
+func convertSearchFilter(filter dtos.ComponentSearchFilter) (models.ComponentFilter, error) {
+	if filter.MinStars < 0 {
+		return models.ComponentFilter{}, fmt.Errorf("invalid min_stars %d", filter.MinStars)
+	}
+	output := models.ComponentFilter{
+		Licenses:        trimValues(filter.Licenses),
+		LicenseIDs:      filter.LicenseIDs,
+		Statuses:        trimValues(filter.Statuses),
+		ExcludeStatuses: trimValues(filter.ExcludeStatuses),
+		Verified:        filter.Verified,
+		MinStars:        filter.MinStars,
+	}
+	dates := []struct {
+		name  string
+		value string
+		dest  *time.Time
+	}{
+		{"latest_version_from", filter.LatestVersionFrom, &output.LatestVersionFrom},
+		{"latest_version_to", filter.LatestVersionTo, &output.LatestVersionTo},
+		{"pushed_from", filter.PushedFrom, &output.PushedFrom},
+		{"pushed_to", filter.PushedTo, &output.PushedTo},
+	}
+	for _, d := range dates {
+		if len(strings.TrimSpace(d.value)) == 0 {
+			continue
+		}
+		day, err := time.Parse(time.DateOnly, strings.TrimSpace(d.value))
+		if err != nil {
+			return models.ComponentFilter{}, fmt.Errorf("invalid %s date %q (expected YYYY-MM-DD)", d.name, d.value)
+		}
+		*d.dest = day
+	}
+	return output, nil
+}
+
+// trimValues returns the non-empty values with any surrounding whitespace removed.
+func trimValues(values []string) []string {
+	var output []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			output = append(output, v)
+		}
+	}
+	return output
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"scanoss.com/components/pkg/dtos"
+)
+
+func TestConvertSearchFilter(t *testing.T) {
+	filter, err := convertSearchFilter(dtos.ComponentSearchFilter{
+		Licenses:          []string{" MIT ", ""},
+		ExcludeStatuses:   []string{"deleted", "unpublished"},
+		MinStars:          50,
+		LatestVersionFrom: "2024-01-01",
+		PushedTo:          " 2025-06-30",
+	})
+	if err != nil {
+		t.Fatalf("convertSearchFilter() error = %v", err)
+	}
+	if len(filter.Licenses) != 1 || filter.Licenses[0] != "MIT" || len(filter.ExcludeStatuses) != 2 || filter.MinStars != 50 {
+		t.Errorf("convertSearchFilter() unexpected filter: %+v", filter)
+	}
+	if !filter.LatestVersionFrom.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) || !filter.LatestVersionTo.IsZero() ||
+		!filter.PushedTo.Equal(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("convertSearchFilter() unexpected dates: %+v", filter)
+	}
+	invalid := []dtos.ComponentSearchFilter{
+		{MinStars: -1},
+		{LatestVersionTo: "2024-13-01"},
+		{PushedFrom: "last week"},
+	}
+	for _, f := range invalid {
+		if _, err = convertSearchFilter(f); err == nil {
+			t.Errorf("convertSearchFilter(%+v) expected an error", f)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	purlhelper "github.com/scanoss/go-purl-helper/pkg"
+	"scanoss.com/components/pkg/dtos"
+	se "scanoss.com/components/pkg/errors"
+	"scanoss.com/components/pkg/models"
+)
+
+// projectURL is a purl (and version, if known) parsed from a repository, registry or download URL.
+type projectURL struct {
+	purlType string
+	purlName string
+	version  string
+}
+
+// gitHosts maps the git hosting sites to their purl types (owner/repository purl names).
+var gitHosts = map[string]string{
+	"github.com":    "github",
+	"gitlab.com":    "gitlab",
+	"bitbucket.org": "bitbucket",
+}
+
+// Download file names that include the version (i.e. react-18.0.0.tgz or rails-7.0.1.gem).
+var (
+	gemFileRegex     = regexp.MustCompile(`^(.+)-(\d[^-]*)\.gem$`)
+	archiveFileRegex = regexp.MustCompile(`\.(tar\.gz|tgz|tar\.bz2|tar\.xz|zip)$`)
+)
+
+// LookupURL returns the purls (and versions) matching a URL: a download URL (as stored in all_urls), or a
+// repository, registry or project page URL (the inverse of purlhelper.ProjectUrl) of a known component.
+func (c ComponentUseCase) LookupURL(request dtos.ComponentURLInput) (dtos.ComponentsSearchOutput, error) {
+	rawURL := strings.TrimSpace(request.URL)
+	if len(rawURL) == 0 {
+		return dtos.ComponentsSearchOutput{}, se.NewBadRequestError("No URL supplied", errors.New("no URL supplied"))
+	}
+	purls, err := c.allURL.GetPurlsByURL(rawURL)
+	if err != nil {
+		c.s.Errorf("Problem encountered looking up the URL %v: %v", rawURL, err)
+		return dtos.ComponentsSearchOutput{}, se.NewBadRequestError("Problem encountered looking up the URL", err)
+	}
+	if len(purls) == 0 {
+		// Not a known download URL, so parse it and check the component exists
+		if p, ok := parseProjectURL(rawURL); ok {
+			component := models.Component{PurlType: p.purlType, PurlName: p.purlName}
+			details, err := c.components.GetComponentDetails([]models.Component{component})
+			if err != nil {
+				c.s.Errorf("Problem encountered looking up the URL %v: %v", rawURL, err)
+				return dtos.ComponentsSearchOutput{}, se.NewBadRequestError("Problem encountered looking up the URL", err)
+			}
+			if _, found := details[models.ComponentPurl(p.purlType, p.purlName)]; found {
+				purls = append(purls, models.URLPurl{PurlType: p.purlType, PurlName: p.purlName, Version: p.version})
+			}
+		}
+	}
+	if len(purls) == 0 {
+		return dtos.ComponentsSearchOutput{}, se.NewNotFoundError("No components found matching the URL")
+	}
+	results := make([]dtos.ComponentSearchOutput, 0, len(purls))
+	for _, p := range purls {
+		name := p.Component
+		if len(name) == 0 {
+			name = path.Base(p.PurlName)
+		}
+		projectURL, _ := purlhelper.ProjectUrl(p.PurlName, p.PurlType)
+		results = append(results, dtos.ComponentSearchOutput{
+			Name:      name,
+			Component: name, // Deprecated. Remove in future versions
+			Purl:      models.ComponentVersionPurl(p.PurlType, p.PurlName, p.Version),
+			PurlType:  p.PurlType,
+			URL:       projectURL,
+			Version:   p.Version,
+		})
+	}
+	return dtos.ComponentsSearchOutput{Components: results, Total: len(results)}, nil
+}
+
+// parseProjectURL parses a repository, registry or download URL into its purl type, purl name and version (if the
+// URL refers to a specific version). It reports false if the URL format is not recognised.
+func parseProjectURL(rawURL string) (projectURL, bool) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return projectURL{}, false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	for i, s := range segments {
+		if unescaped, err := url.PathUnescape(s); err == nil {
+			segments[i] = unescaped
+		}
+	}
+	var p projectURL
+	switch host {
+	case "github.com", "gitlab.com", "bitbucket.org":
+		p = parseGitURL(gitHosts[host], segments)
+	case "npmjs.com":
+		// /package/[@scope/]name[/v/version]
+		if len(segments) > 1 && segments[0] == "package" {
+			name, rest := scopedName(segments[1:])
+			p = projectURL{purlType: "npm", purlName: name}
+			if len(rest) > 1 && rest[0] == "v" {
+				p.version = rest[1]
+			}
+		}
+	case "registry.npmjs.org":
+		// /[@scope/]name[/-/name-version.tgz]
+		name, rest := scopedName(segments)
+		p = projectURL{purlType: "npm", purlName: name}
+		if len(rest) > 1 && rest[0] == "-" {
+			p.version = strings.TrimSuffix(strings.TrimPrefix(rest[1], path.Base(name)+"-"), ".tgz")
+		}
+	case "mvnrepository.com":
+		// /artifact/group/artifact[/version]
+		if len(segments) > 2 && segments[0] == "artifact" {
+			p = projectURL{purlType: "maven", purlName: segments[1] + "/" + segments[2]}
+			if len(segments) > 3 {
+				p.version = segments[3]
+			}
+		}
+	case "repo1.maven.org", "repo.maven.apache.org", "central.maven.org":
+		// /maven2/group/path/artifact/version/artifact-version.jar
+		if n := len(segments); n > 4 && segments[0] == "maven2" {
+			p = projectURL{purlType: "maven", purlName: strings.Join(segments[1:n-3], ".") + "/" + segments[n-3], version: segments[n-2]}
+		}
+	case "rubygems.org":
+		// /gems/name[/versions/version] or /downloads/name-version.gem
+		switch {
+		case len(segments) > 1 && segments[0] == "gems":
+			p = projectURL{purlType: "gem", purlName: segments[1]}
+			if len(segments) > 3 && segments[2] == "versions" {
+				p.version = segments[3]
+			}
+		case len(segments) == 2 && segments[0] == "downloads":
+			if m := gemFileRegex.FindStringSubmatch(segments[1]); m != nil {
+				p = projectURL{purlType: "gem", purlName: m[1], version: m[2]}
+			}
+		}
+	case "pypi.org":
+		// /project/name[/version]
+		if len(segments) > 1 && segments[0] == "project" {
+			p = projectURL{purlType: "pypi", purlName: segments[1]}
+			if len(segments) > 2 {
+				p.version = segments[2]
+			}
+		}
+	case "pkg.go.dev":
+		// /module/path[@version]
+		module, version, _ := strings.Cut(strings.Join(segments, "/"), "@")
+		p = projectURL{purlType: "golang", purlName: module, version: version}
+	case "nuget.org":
+		// /packages/name[/version]
+		if len(segments) > 1 && segments[0] == "packages" {
+			p = projectURL{purlType: "nuget", purlName: segments[1]}
+			if len(segments) > 2 {
+				p.version = segments[2]
+			}
+		}
+	case "conan.io":
+		// /center/recipes/name
+		if len(segments) > 2 && segments[0] == "center" && segments[1] == "recipes" {
+			p = projectURL{purlType: "conan", purlName: segments[2]}
+		}
+	}
+	if len(p.purlType) == 0 || len(p.purlName) == 0 {
+		return projectURL{}, false
+	}
+	// Purl names are lowercase, except for the types that preserve case
+	if p.purlType != "npm" && p.purlType != "nuget" {
+		p.purlName = strings.ToLower(p.purlName)
+	}
+	return p, true
+}
+
+// parseGitURL parses the path of a git hosting site URL (owner/repository), including the version (tag) of
+// archive, release and tree URLs.
+func parseGitURL(purlType string, segments []string) projectURL {
+	if len(segments) < 2 {
+		return projectURL{}
+	}
+	p := projectURL{purlType: purlType, purlName: segments[0] + "/" + strings.TrimSuffix(segments[1], ".git")}
+	rest := segments[2:]
+	if len(rest) > 0 && rest[0] == "-" { // GitLab
+		rest = rest[1:]
+	}
+	switch {
+	case len(rest) > 3 && rest[0] == "archive" && rest[1] == "refs" && rest[2] == "tags":
+		p.version = archiveFileRegex.ReplaceAllString(rest[3], "")
+	case len(rest) > 1 && rest[0] == "archive":
+		p.version = archiveFileRegex.ReplaceAllString(rest[1], "")
+	case len(rest) > 2 && rest[0] == "releases" && (rest[1] == "tag" || rest[1] == "download"):
+		p.version = rest[2]
+	case len(rest) > 1 && (rest[0] == "tree" || rest[0] == "tags"):
+		p.version = rest[1]
+	}
+	return p
+}
+
+// scopedName returns the (optionally scoped) npm package name at the start of the path segments, and the rest.
+func scopedName(segments []string) (string, []string) {
+	if len(segments) == 0 {
+		return "", nil
+	}
+	if strings.HasPrefix(segments[0], "@") && len(segments) > 1 {
+		return segments[0] + "/" + segments[1], segments[2:]
+	}
+	return segments[0], segments[1:]
+}
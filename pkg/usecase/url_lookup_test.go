@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/components/pkg/dtos"
+	"scanoss.com/components/pkg/models"
+)
+
+func TestParseProjectURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want projectURL
+		ok   bool
+	}{
+		{url: "https://github.com/Angular/Angular", want: projectURL{"github", "angular/angular", ""}, ok: true},
+		{url: "git@github.com:angular/angular.git"},
+		{url: "https://github.com/angular/angular.git", want: projectURL{"github", "angular/angular", ""}, ok: true},
+		{url: "https://github.com/angular/angular/archive/refs/tags/v17.0.0.tar.gz", want: projectURL{"github", "angular/angular", "v17.0.0"}, ok: true},
+		{url: "https://github.com/angular/angular/releases/download/17.0.0/angular.zip", want: projectURL{"github", "angular/angular", "17.0.0"}, ok: true},
+		{url: "https://gitlab.com/gitlab-org/gitlab/-/tree/v16.0.0-ee", want: projectURL{"gitlab", "gitlab-org/gitlab", "v16.0.0-ee"}, ok: true},
+		{url: "github.com/angular", ok: false},
+		{url: "https://www.npmjs.com/package/@babel/core/v/7.23.0", want: projectURL{"npm", "@babel/core", "7.23.0"}, ok: true},
+		{url: "https://registry.npmjs.org/@babel/core/-/core-7.23.0.tgz", want: projectURL{"npm", "@babel/core", "7.23.0"}, ok: true},
+		{url: "https://registry.npmjs.org/react/-/react-18.2.0.tgz", want: projectURL{"npm", "react", "18.2.0"}, ok: true},
+		{url: "https://mvnrepository.com/artifact/org.apache.commons/commons-lang3/3.14.0",
+			want: projectURL{"maven", "org.apache.commons/commons-lang3", "3.14.0"}, ok: true},
+		{url: "https://repo1.maven.org/maven2/org/apache/commons/commons-lang3/3.14.0/commons-lang3-3.14.0.jar",
+			want: projectURL{"maven", "org.apache.commons/commons-lang3", "3.14.0"}, ok: true},
+		{url: "https://rubygems.org/downloads/active-record-7.0.1.gem", want: projectURL{"gem", "active-record", "7.0.1"}, ok: true},
+		{url: "https://rubygems.org/gems/rails/versions/7.0.1", want: projectURL{"gem", "rails", "7.0.1"}, ok: true},
+		{url: "https://pypi.org/project/Requests/", want: projectURL{"pypi", "requests", ""}, ok: true},
+		{url: "https://pkg.go.dev/golang.org/x/text@v0.14.0", want: projectURL{"golang", "golang.org/x/text", "v0.14.0"}, ok: true},
+		{url: "https://www.nuget.org/packages/Newtonsoft.Json/13.0.3", want: projectURL{"nuget", "Newtonsoft.Json", "13.0.3"}, ok: true},
+		{url: "https://conan.io/center/recipes/zlib", want: projectURL{"conan", "zlib", ""}, ok: true},
+		{url: "https://example.com/react", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := parseProjectURL(tt.url)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseProjectURL(%q) = %+v, %v, want %+v, %v", tt.url, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestComponentUseCase_LookupURL(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	if err = models.LoadTestNamespaceSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading namespace test data", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), nil)
+	tests := []struct {
+		url, purl, version string
+	}{
+		{url: "https://registry.npmjs.org/react/-/react-18.0.0.tgz", purl: "pkg:npm/react@18.0.0", version: "18.0.0"},
+		{url: "https://github.com/angular/angular", purl: "pkg:github/angular/angular"},
+		{url: "https://www.npmjs.com/package/@babel/core/v/7.16.5", purl: "pkg:npm/@babel/core@7.16.5", version: "7.16.5"},
+	}
+	for _, tt := range tests {
+		out, err := compUc.LookupURL(dtos.ComponentURLInput{URL: tt.url})
+		if err != nil {
+			t.Errorf("LookupURL(%v) error = %v", tt.url, err)
+			continue
+		}
+		if len(out.Components) != 1 || out.Components[0].Purl != tt.purl || out.Components[0].Version != tt.version {
+			t.Errorf("LookupURL(%v) = %+v, want %v", tt.url, out.Components, tt.purl)
+		}
+	}
+	for _, url := range []string{"https://github.com/unknown/project", "https://example.com/react", " "} {
+		if _, err = compUc.LookupURL(dtos.ComponentURLInput{URL: url}); err == nil {
+			t.Errorf("LookupURL(%q) expected an error", url)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"scanoss.com/components/pkg/config"
+)
+
+// compareVersions compares two versions of a package using the ordering rules of its purl type: PEP 440 for PyPI,
+// ComparableVersion for Maven, EVR (epoch:version-release) for Debian and RPM, and semver precedence for the rest
+// (including an empty purl type). It returns -1 if a < b, 0 if they are equal and 1 if a > b.
+// Every version comparison (ordering, requirements, resolutions and waiver ranges) goes through this function.
+func compareVersions(purlType, a, b string) int {
+	switch purlType {
+	case "pypi":
+		return comparePEP440(a, b)
+	case "maven":
+		return compareMaven(a, b)
+	case "deb", "rpm":
+		return compareEVR(a, b)
+	}
+	return compareSemver(a, b)
+}
+
+// compareSemver compares two semver-like version strings (an optional leading 'v' and any build metadata are ignored).
+// Release identifiers are compared numerically where possible, and a pre-release sorts before its release.
+// It returns -1 if a < b, 0 if they are equal and 1 if a > b.
+func compareSemver(a, b string) int {
+	relA, preA := splitVersion(a)
+	relB, preB := splitVersion(b)
+	if c := compareIdentifiers(strings.Split(relA, "."), strings.Split(relB, ".")); c != 0 {
+		return c
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case len(preA) == 0:
+		return 1
+	case len(preB) == 0:
+		return -1
+	}
+	return compareIdentifiers(strings.Split(preA, "."), strings.Split(preB, "."))
+}
+
+// splitVersion splits a version string into its release and pre-release parts.
+func splitVersion(version string) (string, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+")
+	release, pre, _ := strings.Cut(version, "-")
+	return release, pre
+}
+
+// compareIdentifiers compares two lists of dot separated version identifiers.
+// Numeric identifiers are compared numerically and sort before alphanumeric ones; missing identifiers sort first.
+func compareIdentifiers(a, b []string) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		switch {
+		case i >= len(a):
+			return -1
+		case i >= len(b):
+			return 1
+		}
+		numA, errA := strconv.ParseUint(a[i], 10, 64)
+		numB, errB := strconv.ParseUint(b[i], 10, 64)
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// matchesVersionConstraints reports whether the version satisfies every one of the supplied constraints, using the
+// ordering rules of the purl type.
+func matchesVersionConstraints(purlType, version string, constraints []config.VersionConstraint) bool {
+	return matchesConstraints(version, constraints, func(a, b string) int { return compareVersions(purlType, a, b) })
+}
+
+// matchesConstraints reports whether the version satisfies every one of the supplied constraints, comparing
+// versions with the supplied function.
+func matchesConstraints(version string, constraints []config.VersionConstraint, compare func(a, b string) int) bool {
+	for _, c := range constraints {
+		cmp := compare(version, c.Version)
+		var ok bool
+		switch c.Op {
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "!=":
+			ok = cmp != 0
+		default:
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// pep440Regex matches a PEP 440 version: [N!]N(.N)*[{a|b|rc}N][.postN][.devN][+local], including the alternative
+// spellings allowed by the normalisation rules.
+var pep440Regex = regexp.MustCompile(`^v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d+)?)?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// pep440Version is a parsed PEP 440 version. The pre-release phase is -1 for developmental releases of the final
+// release (1.0.dev1), 0-2 for alpha, beta and release candidates, and 3 for no pre-release.
+type pep440Version struct {
+	epoch    uint64
+	release  []uint64
+	phase    int
+	pre      uint64
+	post     int64 // -1 for no post-release
+	dev      uint64
+	hasDev   bool
+	localKey string
+}
+
+// parsePEP440 parses a PEP 440 version, reporting false if it does not follow the specification.
+func parsePEP440(version string) (pep440Version, bool) {
+	m := pep440Regex.FindStringSubmatch(strings.ToLower(strings.TrimSpace(version)))
+	if m == nil {
+		return pep440Version{}, false
+	}
+	number := func(s string) uint64 {
+		n, _ := strconv.ParseUint(s, 10, 64)
+		return n
+	}
+	v := pep440Version{epoch: number(m[1]), phase: 3, post: -1, localKey: m[10]}
+	for _, part := range strings.Split(m[2], ".") {
+		v.release = append(v.release, number(part))
+	}
+	for len(v.release) > 1 && v.release[len(v.release)-1] == 0 { // 1.0 == 1.0.0
+		v.release = v.release[:len(v.release)-1]
+	}
+	switch m[3] {
+	case "a", "alpha":
+		v.phase = 0
+	case "b", "beta":
+		v.phase = 1
+	case "c", "rc", "pre", "preview":
+		v.phase = 2
+	}
+	v.pre = number(m[4])
+	switch {
+	case len(m[5]) > 0:
+		v.post = int64(number(m[5]))
+	case len(m[6]) > 0:
+		v.post = int64(number(m[7]))
+	}
+	if len(m[8]) > 0 {
+		v.hasDev = true
+		v.dev = number(m[9])
+		if v.phase == 3 && v.post < 0 {
+			v.phase = -1 // 1.0.dev1 sorts before 1.0a1
+		}
+	}
+	return v, true
+}
+
+// comparePEP440 compares two PyPI versions following PEP 440 (falling back to semver precedence if either version
+// does not follow the specification).
+func comparePEP440(a, b string) int {
+	va, okA := parsePEP440(a)
+	vb, okB := parsePEP440(b)
+	if !okA || !okB {
+		return compareSemver(a, b)
+	}
+	if c := cmp.Compare(va.epoch, vb.epoch); c != 0 {
+		return c
+	}
+	if c := slices.Compare(va.release, vb.release); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(va.phase, vb.phase); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(va.pre, vb.pre); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(va.post, vb.post); c != 0 {
+		return c
+	}
+	if va.hasDev != vb.hasDev { // A developmental release sorts before its release
+		if va.hasDev {
+			return -1
+		}
+		return 1
+	}
+	if c := cmp.Compare(va.dev, vb.dev); c != 0 {
+		return c
+	}
+	return compareIdentifiers(strings.FieldsFunc(va.localKey, isPEP440Separator), strings.FieldsFunc(vb.localKey, isPEP440Separator))
+}
+
+// isPEP440Separator reports whether the rune separates the segments of a PEP 440 local version label.
+func isPEP440Separator(r rune) bool {
+	return r == '.' || r == '-' || r == '_'
+}
+
+// mavenQualifiers ranks the well known Maven qualifiers. Unknown qualifiers sort after these, alphabetically.
+var mavenQualifiers = map[string]int{
+	"alpha": 0, "beta": 1, "milestone": 2, "rc": 3, "cr": 3, "snapshot": 4, "": 5, "ga": 5, "final": 5, "release": 5, "sp": 6,
+}
+
+// mavenItem is a numeric or qualifier item of a Maven version.
+type mavenItem struct {
+	number    uint64
+	qualifier string
+	isNumber  bool
+}
+
+// parseMaven splits a Maven version into its items (on '.', '-' and the transitions between digits and letters),
+// expanding the a, b and m shorthands and removing the trailing items that equal a release (1.0.0 == 1.0 == 1-ga).
+func parseMaven(version string) []mavenItem {
+	var tokens []string
+	var current strings.Builder
+	var lastDigit bool
+	for _, r := range strings.ToLower(strings.TrimSpace(version)) {
+		if r == '.' || r == '-' || r == '_' {
+			tokens = append(tokens, current.String())
+			current.Reset()
+			continue
+		}
+		digit := unicode.IsDigit(r)
+		if current.Len() > 0 && digit != lastDigit {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+		current.WriteRune(r)
+		lastDigit = digit
+	}
+	tokens = append(tokens, current.String())
+	items := make([]mavenItem, 0, len(tokens))
+	for i, token := range tokens {
+		if n, err := strconv.ParseUint(token, 10, 64); err == nil {
+			items = append(items, mavenItem{number: n, isNumber: true})
+			continue
+		}
+		if i+1 < len(tokens) && len(tokens[i+1]) > 0 && unicode.IsDigit(rune(tokens[i+1][0])) {
+			switch token {
+			case "a":
+				token = "alpha"
+			case "b":
+				token = "beta"
+			case "m":
+				token = "milestone"
+			}
+		}
+		items = append(items, mavenItem{qualifier: token})
+	}
+	for len(items) > 0 && isMavenRelease(items[len(items)-1]) {
+		items = items[:len(items)-1]
+	}
+	return items
+}
+
+// isMavenRelease reports whether the item is equivalent to a missing item (zero or a release qualifier).
+func isMavenRelease(item mavenItem) bool {
+	if item.isNumber {
+		return item.number == 0
+	}
+	rank, known := mavenQualifiers[item.qualifier]
+	return known && rank == mavenQualifiers[""]
+}
+
+// compareMavenQualifiers compares two Maven qualifiers by rank, then alphabetically for unknown qualifiers.
+func compareMavenQualifiers(a, b string) int {
+	rankA, knownA := mavenQualifiers[a]
+	rankB, knownB := mavenQualifiers[b]
+	if !knownA {
+		rankA = len(mavenQualifiers)
+	}
+	if !knownB {
+		rankB = len(mavenQualifiers)
+	}
+	if c := cmp.Compare(rankA, rankB); c != 0 || knownA {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// compareMaven compares two Maven versions following the ComparableVersion rules: numbers are compared numerically
+// and sort after qualifiers, and qualifiers are ordered alpha < beta < milestone < rc < snapshot < release < sp.
+func compareMaven(a, b string) int {
+	itemsA, itemsB := parseMaven(a), parseMaven(b)
+	for i := 0; i < len(itemsA) || i < len(itemsB); i++ {
+		var x, y mavenItem // A missing item is the same as 0 (or a release)
+		x.isNumber, y.isNumber = true, true
+		if i < len(itemsA) {
+			x = itemsA[i]
+		}
+		if i < len(itemsB) {
+			y = itemsB[i]
+		}
+		var c int
+		switch {
+		case x.isNumber && y.isNumber:
+			c = cmp.Compare(x.number, y.number)
+		case x.isNumber:
+			c = 1
+			if x.number == 0 && i >= len(itemsA) { // Missing item, so compare as a release
+				c = compareMavenQualifiers("", y.qualifier)
+			}
+		case y.isNumber:
+			c = -1
+			if y.number == 0 && i >= len(itemsB) {
+				c = compareMavenQualifiers(x.qualifier, "")
+			}
+		default:
+			c = compareMavenQualifiers(x.qualifier, y.qualifier)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareEVR compares two Debian or RPM versions ([epoch:]upstream[-revision]): the epochs numerically, then the
+// upstream versions and revisions using the dpkg comparison rules (where '~' sorts before anything, even the end).
+func compareEVR(a, b string) int {
+	epochA, upstreamA, revisionA := splitEVR(a)
+	epochB, upstreamB, revisionB := splitEVR(b)
+	if c := cmp.Compare(epochA, epochB); c != 0 {
+		return c
+	}
+	if c := compareDebianPart(upstreamA, upstreamB); c != 0 {
+		return c
+	}
+	return compareDebianPart(revisionA, revisionB)
+}
+
+// splitEVR splits a version into its epoch, upstream version and revision.
+func splitEVR(version string) (uint64, string, string) {
+	version = strings.TrimSpace(version)
+	var epoch uint64
+	if e, rest, found := strings.Cut(version, ":"); found {
+		if n, err := strconv.ParseUint(e, 10, 64); err == nil {
+			epoch, version = n, rest
+		}
+	}
+	if i := strings.LastIndex(version, "-"); i >= 0 {
+		return epoch, version[:i], version[i+1:]
+	}
+	return epoch, version, ""
+}
+
+// debianCharOrder returns the sort weight of a non-digit character: '~' first, then the end of the string,
+// then letters, then everything else.
+func debianCharOrder(s string, i int) int {
+	switch {
+	case i >= len(s):
+		return 0
+	case s[i] == '~':
+		return -1
+	case unicode.IsLetter(rune(s[i])):
+		return int(s[i])
+	}
+	return int(s[i]) + 256
+}
+
+// compareDebianPart compares two upstream versions (or revisions) using the dpkg algorithm: alternating runs of
+// non-digits (compared character by character) and digits (compared numerically).
+func compareDebianPart(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			orderA, orderB := 0, 0
+			if i < len(a) && !isDigit(a[i]) {
+				orderA = debianCharOrder(a, i)
+			}
+			if j < len(b) && !isDigit(b[j]) {
+				orderB = debianCharOrder(b, j)
+			}
+			if c := cmp.Compare(orderA, orderB); c != 0 {
+				return c
+			}
+			i++
+			j++
+		}
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = cmp.Compare(a[i], b[j])
+			}
+			i++
+			j++
+		}
+		switch {
+		case i < len(a) && isDigit(a[i]):
+			return 1
+		case j < len(b) && isDigit(b[j]):
+			return -1
+		case firstDiff != 0:
+			return firstDiff
+		}
+	}
+	return 0
+}
+
+// isDigit reports whether the byte is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"testing"
+
+	myconfig "scanoss.com/components/pkg/config"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0.0", b: "1.0.0", want: 0},
+		{a: "v1.2.3", b: "1.2.3", want: 0},
+		{a: "1.10.0", b: "1.9.0", want: 1},
+		{a: "1.0", b: "1.0.1", want: -1},
+		{a: "1.0.0-rc.1", b: "1.0.0", want: -1},
+		{a: "1.0.0-rc.2", b: "1.0.0-rc.10", want: -1},
+		{a: "1.0.0-alpha", b: "1.0.0-1", want: 1},
+		{a: "1.0.0+build.5", b: "1.0.0", want: 0},
+		{a: "v0.0.0-20190513183733-4bf6d317e70e", b: "v0.1.0", want: -1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions("", tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := compareVersions("", tt.b, tt.a); got != -tt.want {
+			t.Errorf("compareVersions(%v, %v) = %v, want %v", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestMatchesVersionConstraints(t *testing.T) {
+	constraints, err := myconfig.ParseVersionConstraints(">=1.0.0, <2.0.0, !=1.4.0")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing the constraints", err)
+	}
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "1.0.0", want: true},
+		{version: "v1.9.9", want: true},
+		{version: "1.4.0", want: false},
+		{version: "1.0.0-rc.1", want: false},
+		{version: "2.0.0", want: false},
+		{version: "0.9", want: false},
+	}
+	for _, tt := range tests {
+		if got := matchesVersionConstraints("", tt.version, constraints); got != tt.want {
+			t.Errorf("matchesVersionConstraints(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+	if !matchesVersionConstraints("", "1.2.3", nil) {
+		t.Errorf("expected no constraints to match every version")
+	}
+	// Ranges follow the ordering rules of the ecosystem
+	ecosystems := []struct {
+		purlType, version, constraints string
+		want                           bool
+	}{
+		{purlType: "pypi", version: "1.0rc1", constraints: ">=1.0", want: false},
+		{purlType: "pypi", version: "1.0.post1", constraints: ">1.0, <1.1", want: true},
+		{purlType: "maven", version: "1.0-SNAPSHOT", constraints: ">=1.0", want: false},
+		{purlType: "maven", version: "1.0-sp1", constraints: ">1.0", want: true},
+		{purlType: "deb", version: "1:0.9", constraints: ">=1.0", want: true},
+	}
+	for _, tt := range ecosystems {
+		c, err := myconfig.ParseVersionConstraints(tt.constraints)
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when parsing the constraints", err)
+		}
+		if got := matchesVersionConstraints(tt.purlType, tt.version, c); got != tt.want {
+			t.Errorf("matchesVersionConstraints(%v, %q, %q) = %v, want %v", tt.purlType, tt.version, tt.constraints, got, tt.want)
+		}
+	}
+}
+
+func TestCompareEcosystemVersions(t *testing.T) {
+	tests := []struct {
+		purlType, a, b string
+		want           int
+	}{
+		{purlType: "npm", a: "1.10.0", b: "1.9.0", want: 1},
+		{purlType: "npm", a: "2.0.0-beta.1", b: "2.0.0", want: -1},
+		{purlType: "pypi", a: "1.42.0rc1", b: "1.42.0", want: -1},
+		{purlType: "pypi", a: "1.0.dev1", b: "1.0a1", want: -1},
+		{purlType: "pypi", a: "1.0a2", b: "1.0b1", want: -1},
+		{purlType: "pypi", a: "1.0.post1", b: "1.0", want: 1},
+		{purlType: "pypi", a: "1.0", b: "1.0.0", want: 0},
+		{purlType: "pypi", a: "1!0.1", b: "2.0", want: 1},
+		{purlType: "pypi", a: "1.0+local.1", b: "1.0", want: 1},
+		{purlType: "maven", a: "1.0-alpha-1", b: "1.0-beta-1", want: -1},
+		{purlType: "maven", a: "1.0-M1", b: "1.0-RC1", want: -1},
+		{purlType: "maven", a: "1.0-SNAPSHOT", b: "1.0", want: -1},
+		{purlType: "maven", a: "1.0", b: "1.0.0.GA", want: 0},
+		{purlType: "maven", a: "1.0-sp1", b: "1.0", want: 1},
+		{purlType: "maven", a: "1.0.1", b: "1.0-RC1", want: 1},
+		{purlType: "maven", a: "2.10", b: "2.9", want: 1},
+		{purlType: "deb", a: "1.0~rc1-1", b: "1.0-1", want: -1},
+		{purlType: "deb", a: "1:0.9-1", b: "2.0-1", want: 1},
+		{purlType: "deb", a: "1.0-10", b: "1.0-9", want: 1},
+		{purlType: "rpm", a: "1.2.10-1.el8", b: "1.2.9-3.el8", want: 1},
+		{purlType: "rpm", a: "1.0a", b: "1.0", want: 1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.purlType, tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%v, %v, %v) = %v, want %v", tt.purlType, tt.a, tt.b, got, tt.want)
+		}
+		if got := compareVersions(tt.purlType, tt.b, tt.a); got != -tt.want {
+			t.Errorf("compareVersions(%v, %v, %v) = %v, want %v", tt.purlType, tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
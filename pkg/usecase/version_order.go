@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"regexp"
+	"slices"
+
+	"scanoss.com/components/pkg/models"
+)
+
+// Component version orderings.
+const (
+	VersionOrderDate    = "date"    // Release date, newest first (default)
+	VersionOrderVersion = "version" // Version precedence, highest first
+)
+
+// maxVersionRows is the maximum number of all_urls rows fetched for a component (the limit can only be applied to the
+// versions once they are sorted and grouped).
+const maxVersionRows = 10000
+
+// defaultVersionsLimit is the number of versions returned when no limit is supplied (as per the all_urls model).
+const defaultVersionsLimit = 50
+
+// sortVersionsByPrecedence sorts the versions of a component from the highest to the lowest version, using the
+// version ordering rules of its ecosystem. Versions of equal precedence keep their (release date) order.
+func sortVersionsByPrecedence(purlType string, urls []models.AllURL) {
+	slices.SortStableFunc(urls, func(a, b models.AllURL) int {
+		return compareVersions(purlType, versionKey(purlType, b), versionKey(purlType, a))
+	})
+}
+
+// Versions that start with a release number, and well-formed semvers (with an optional leading 'v').
+var (
+	releaseRegex = regexp.MustCompile(`^v?\d+(?:\.\d+)*`)
+	semverRegex  = regexp.MustCompile(`^v?\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?$`)
+)
+
+// versionKey returns the version string to compare. This is the version as published, unless it does not start with
+// a release number (i.e. a tag such as release-1.2) and the normalised semver of the version is known.
+func versionKey(purlType string, u models.AllURL) string {
+	switch purlType {
+	case "pypi", "maven", "deb", "rpm":
+		return u.Version
+	}
+	if !releaseRegex.MatchString(u.Version) && semverRegex.MatchString(u.Semver) {
+		return u.Semver
+	}
+	return u.Version
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"database/sql"
+	"slices"
+	"testing"
+
+	"scanoss.com/components/pkg/models"
+)
+
+func TestSortVersionsByPrecedence(t *testing.T) {
+	urls := []models.AllURL{
+		{Version: "1.2.0", Date: sql.NullString{String: "2024-03-01", Valid: true}},
+		{Version: "2.0.0-rc.1", Date: sql.NullString{String: "2024-02-01", Valid: true}},
+		{Version: "1.10.0", Date: sql.NullString{String: "2024-01-01", Valid: true}},
+		{Version: "release-1.10", Semver: "v1.10.0", Date: sql.NullString{String: "2023-12-01", Valid: true}},
+		{Version: "1.9.9", Date: sql.NullString{String: "2024-04-01", Valid: true}},
+	}
+	sortVersionsByPrecedence("npm", urls)
+	var got []string
+	for _, u := range urls {
+		got = append(got, u.Version)
+	}
+	want := []string{"2.0.0-rc.1", "1.10.0", "release-1.10", "1.9.9", "1.2.0"}
+	if !slices.Equal(got, want) {
+		t.Errorf("sortVersionsByPrecedence() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,307 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"scanoss.com/components/pkg/config"
+)
+
+// versionRequirement is a parsed version requirement: a version satisfies it if it meets every constraint of any of
+// its ranges (an empty range matches every version).
+type versionRequirement [][]config.VersionConstraint
+
+var (
+	// requirementOperatorRegex matches an operator separated from its version by whitespace (i.e. "~> 1.2" or ">= 1.0").
+	requirementOperatorRegex = regexp.MustCompile(`(\^|~>|~=|~|===|>=|<=|!=|==|=|>|<)\s+`)
+	// hyphenRangeRegex matches an npm hyphen range (i.e. "1.2 - 2.3").
+	hyphenRangeRegex = regexp.MustCompile(`^(\S+)\s+-\s+(\S+)$`)
+	// mavenRangeRegex matches a Maven version range (i.e. "[1.0,2.0)", "(,1.0]" or "[1.5]").
+	mavenRangeRegex = regexp.MustCompile(`[\[(]([^\[\]()]*)[\])]`)
+	// preReleaseMarkerRegex matches a pre-release marker word in a version tag (i.e. "2.0.0.beta1" or "release-2.0-rc1").
+	preReleaseMarkerRegex = regexp.MustCompile(`(?i)(^|[^a-z])(alpha|beta|rc|pre|dev|snapshot)([^a-z]|$)`)
+)
+
+// parseVersionRequirement parses a version requirement using the range syntax of the package ecosystem: npm/semver
+// ranges (^1.2, ~1.2.3, 1.x, 1.2 - 2.3, >=2 <3 and || alternatives), PEP 440 specifiers (~=3.4, ==3.*, !=3.4.1),
+// RubyGems pessimistic constraints (~> 1.2) and Maven version ranges ([1.0,2.0), (,1.0], [1.5,)).
+func parseVersionRequirement(purlType, requirement string) (versionRequirement, error) {
+	requirement = strings.TrimSpace(requirement)
+	if len(requirement) == 0 {
+		return nil, errors.New("no version requirement supplied")
+	}
+	if purlType == "maven" && strings.ContainsAny(requirement, "[(") {
+		return parseMavenRanges(requirement)
+	}
+	var req versionRequirement
+	for _, part := range strings.Split(requirement, "||") {
+		constraints, err := parseRequirementRange(purlType, strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		req = append(req, constraints)
+	}
+	return req, nil
+}
+
+// parseRequirementRange parses a set of constraints that must all be met (separated by commas or whitespace).
+func parseRequirementRange(purlType, value string) ([]config.VersionConstraint, error) {
+	if m := hyphenRangeRegex.FindStringSubmatch(value); m != nil {
+		constraints := []config.VersionConstraint{{Op: ">=", Version: m[1]}}
+		if parts := releaseParts(m[2]); len(parts) < 3 && purlType != "pypi" { // 1.2 - 2.3 is <2.4
+			return append(constraints, config.VersionConstraint{Op: "<", Version: bumpRelease(purlType, parts)}), nil
+		}
+		return append(constraints, config.VersionConstraint{Op: "<=", Version: m[2]}), nil
+	}
+	value = requirementOperatorRegex.ReplaceAllString(value, "$1")
+	constraints := []config.VersionConstraint{}
+	for _, f := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		var op, version string
+		for _, prefix := range []string{"^", "~>", "~=", "~", "===", "=="} {
+			if strings.HasPrefix(f, prefix) {
+				op, version = prefix, strings.TrimPrefix(f, prefix)
+				break
+			}
+		}
+		if len(op) == 0 && !strings.ContainsAny(f[:1], "<>!=") {
+			version = strings.TrimPrefix(f, "=")
+		}
+		switch {
+		case op == "===":
+			constraints = append(constraints, config.VersionConstraint{Op: "=", Version: version})
+		case op == "^":
+			constraints = append(constraints, caretRange(purlType, version)...)
+		case op == "~":
+			constraints = append(constraints, prefixRange(purlType, version, 1)...)
+		case op == "~>" || op == "~=":
+			parts := releaseParts(version)
+			if len(parts) < 2 && op == "~=" {
+				return nil, fmt.Errorf("invalid version constraint %q (~= requires at least two release segments)", f)
+			}
+			constraints = append(constraints, prefixRange(purlType, version, max(len(parts)-2, 0))...)
+		case (op == "==" || len(op) == 0 && len(version) > 0) && isWildcard(version):
+			parts := releaseParts(version)
+			if len(parts) > 0 {
+				constraints = append(constraints, config.VersionConstraint{Op: ">=", Version: strings.Join(parts, ".")},
+					config.VersionConstraint{Op: "<", Version: bumpRelease(purlType, parts)})
+			}
+		default:
+			parsed, err := config.ParseVersionConstraints(f)
+			if err != nil {
+				return nil, err
+			}
+			constraints = append(constraints, parsed...)
+		}
+	}
+	return constraints, nil
+}
+
+// caretRange returns the constraints of an npm caret range, which allows changes that do not modify the left-most
+// non-zero release segment (^1.2.3 is >=1.2.3 <2.0.0, ^0.2.3 is >=0.2.3 <0.3.0).
+func caretRange(purlType, version string) []config.VersionConstraint {
+	parts := releaseParts(version)
+	if len(parts) == 0 {
+		return nil
+	}
+	i := len(parts) - 1
+	for j, p := range parts {
+		if p != "0" {
+			i = j
+			break
+		}
+	}
+	return []config.VersionConstraint{
+		{Op: ">=", Version: lowerBound(version, parts)},
+		{Op: "<", Version: bumpRelease(purlType, parts[:i+1])},
+	}
+}
+
+// prefixRange returns the constraints of a range allowing changes after the given release segment: npm tilde ranges
+// (~1.2.3 is >=1.2.3 <1.3.0), and PEP 440 compatible releases and RubyGems pessimistic constraints (~=3.4 is
+// >=3.4 <4, ~>1.2.3 is >=1.2.3 <1.3).
+func prefixRange(purlType, version string, segment int) []config.VersionConstraint {
+	parts := releaseParts(version)
+	if len(parts) == 0 {
+		return nil
+	}
+	segment = min(segment, len(parts)-1)
+	return []config.VersionConstraint{
+		{Op: ">=", Version: lowerBound(version, parts)},
+		{Op: "<", Version: bumpRelease(purlType, parts[:segment+1])},
+	}
+}
+
+// releaseParts returns the numeric release segments of a version, up to any wildcard or pre-release.
+func releaseParts(version string) []string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	var parts []string
+	for _, p := range strings.Split(version, ".") {
+		digits := p
+		if i := strings.IndexFunc(p, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+			digits = p[:i]
+		}
+		if len(digits) == 0 {
+			break
+		}
+		parts = append(parts, digits)
+		if len(digits) < len(p) {
+			break
+		}
+	}
+	return parts
+}
+
+// lowerBound returns the version itself as the lower bound of a range, without any wildcard segments.
+func lowerBound(version string, parts []string) string {
+	if isWildcard(version) {
+		return strings.Join(parts, ".")
+	}
+	return version
+}
+
+// isWildcard reports whether a version has a wildcard segment (i.e. 1.x, 1.2.* or *).
+func isWildcard(version string) bool {
+	for _, p := range strings.Split(version, ".") {
+		if p == "x" || p == "X" || p == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// bumpRelease increments the last of the release segments, returning the exclusive upper bound of a range. The bound
+// sorts before the pre-releases of that version (i.e. 2.0.0-0 or 2.dev0) so they are not included in the range.
+func bumpRelease(purlType string, parts []string) string {
+	if len(parts) == 0 {
+		return ""
+	}
+	bumped := append([]string{}, parts...)
+	last, _ := strconv.ParseUint(bumped[len(bumped)-1], 10, 64)
+	bumped[len(bumped)-1] = strconv.FormatUint(last+1, 10)
+	switch purlType {
+	case "pypi":
+		return strings.Join(bumped, ".") + ".dev0"
+	case "maven", "deb", "rpm", "gem":
+		return strings.Join(bumped, ".")
+	}
+	for len(bumped) < 3 {
+		bumped = append(bumped, "0")
+	}
+	return strings.Join(bumped, ".") + "-0"
+}
+
+// parseMavenRanges parses one or more (comma separated) Maven version ranges, any of which can be met.
+func parseMavenRanges(requirement string) (versionRequirement, error) {
+	var req versionRequirement
+	rest := requirement
+	for _, loc := range mavenRangeRegex.FindAllStringSubmatchIndex(requirement, -1) {
+		if strings.Trim(requirement[len(requirement)-len(rest):loc[0]], ", ") != "" {
+			return nil, fmt.Errorf("invalid version range %q", requirement)
+		}
+		rest = requirement[loc[1]:]
+		open, closing := requirement[loc[0]], requirement[loc[1]-1]
+		lower, upper, isRange := strings.Cut(requirement[loc[2]:loc[3]], ",")
+		lower, upper = strings.TrimSpace(lower), strings.TrimSpace(upper)
+		if !isRange {
+			if open != '[' || closing != ']' || len(lower) == 0 {
+				return nil, fmt.Errorf("invalid version range %q", requirement)
+			}
+			req = append(req, []config.VersionConstraint{{Op: "=", Version: lower}})
+			continue
+		}
+		constraints := []config.VersionConstraint{}
+		if len(lower) > 0 {
+			op := ">"
+			if open == '[' {
+				op = ">="
+			}
+			constraints = append(constraints, config.VersionConstraint{Op: op, Version: lower})
+		}
+		if len(upper) > 0 {
+			op := "<"
+			if closing == ']' {
+				op = "<="
+			}
+			constraints = append(constraints, config.VersionConstraint{Op: op, Version: upper})
+		}
+		req = append(req, constraints)
+	}
+	if len(req) == 0 || strings.Trim(rest, ", ") != "" {
+		return nil, fmt.Errorf("invalid version range %q", requirement)
+	}
+	return req, nil
+}
+
+// matches reports whether the version satisfies the requirement, comparing versions using the ecosystem rules.
+// As with npm and pip, a pre-release only satisfies a range that explicitly includes a pre-release of the same release.
+func (r versionRequirement) matches(purlType, version string) bool {
+	compare := func(a, b string) int { return compareVersions(purlType, a, b) }
+	preRelease := isPreRelease(purlType, version)
+	for _, constraints := range r {
+		if !matchesConstraints(version, constraints, compare) {
+			continue
+		}
+		if !preRelease || slices.ContainsFunc(constraints, func(c config.VersionConstraint) bool {
+			return isPreRelease(purlType, c.Version) && slices.Equal(releaseParts(c.Version), releaseParts(version))
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// inRange reports whether the version is within any of the ranges of the requirement, regardless of whether it is
+// a pre-release.
+func (r versionRequirement) inRange(purlType, version string) bool {
+	compare := func(a, b string) int { return compareVersions(purlType, a, b) }
+	return slices.ContainsFunc(r, func(constraints []config.VersionConstraint) bool {
+		return matchesConstraints(version, constraints, compare)
+	})
+}
+
+// isPreRelease reports whether a version is a pre-release, using the version scheme of its ecosystem: a PEP 440
+// pre or developmental release, a Maven version with an alpha, beta, milestone, rc or snapshot qualifier, a Debian/RPM
+// version with a '~', or a semver version with a pre-release part (i.e. 1.0.0-rc.1). Other tags are only pre-releases
+// with an alpha, beta, rc, pre, dev or snapshot marker (i.e. 2.0.0.beta1, but not release-1.0 or v1.0.final).
+func isPreRelease(purlType, version string) bool {
+	switch purlType {
+	case "pypi":
+		if v, ok := parsePEP440(version); ok {
+			return v.phase < 3 || v.hasDev
+		}
+	case "maven":
+		for _, item := range parseMaven(version) {
+			if rank, known := mavenQualifiers[item.qualifier]; !item.isNumber && known && rank < mavenQualifiers[""] {
+				return true
+			}
+		}
+		return false
+	case "deb", "rpm":
+		return strings.Contains(version, "~")
+	}
+	release, pre := splitVersion(version)
+	if len(releaseParts(release)) == len(strings.Split(release, ".")) {
+		return len(pre) > 0
+	}
+	return preReleaseMarkerRegex.MatchString(version)
+}
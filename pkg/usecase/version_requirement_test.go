@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package usecase
+
+import "testing"
+
+func TestVersionRequirementMatches(t *testing.T) {
+	tests := []struct {
+		purlType    string
+		requirement string
+		version     string
+		want        bool
+	}{
+		{purlType: "npm", requirement: "^1.2", version: "1.9.0", want: true},
+		{purlType: "npm", requirement: "^1.2", version: "2.0.0", want: false},
+		{purlType: "npm", requirement: "^1.2", version: "2.0.0-rc.1", want: false},
+		{purlType: "npm", requirement: "^1.2", version: "1.1.9", want: false},
+		{purlType: "npm", requirement: "^0.2.3", version: "0.2.9", want: true},
+		{purlType: "npm", requirement: "^0.2.3", version: "0.3.0", want: false},
+		{purlType: "npm", requirement: "~1.2.3", version: "1.2.9", want: true},
+		{purlType: "npm", requirement: "~1.2.3", version: "1.3.0", want: false},
+		{purlType: "npm", requirement: "1.x", version: "1.5.1", want: true},
+		{purlType: "npm", requirement: "1.2.*", version: "1.3.0", want: false},
+		{purlType: "npm", requirement: "*", version: "0.0.1", want: true},
+		{purlType: "npm", requirement: ">=2 <3", version: "2.5.0", want: true},
+		{purlType: "npm", requirement: ">=2 <3", version: "3.0.0", want: false},
+		{purlType: "npm", requirement: "1.2 - 2.3", version: "2.3.5", want: true},
+		{purlType: "npm", requirement: "1.2 - 2.3", version: "2.4.0", want: false},
+		{purlType: "npm", requirement: "^1.0 || ^3.0", version: "3.1.0", want: true},
+		{purlType: "npm", requirement: "^1.0 || ^3.0", version: "2.1.0", want: false},
+		{purlType: "npm", requirement: "^1.2", version: "1.5.0-beta.1", want: false},
+		{purlType: "npm", requirement: ">=1.0.0-rc.1", version: "1.0.0-rc.2", want: true},
+		{purlType: "npm", requirement: ">=1.0.0-rc.1", version: "1.1.0-rc.1", want: false},
+		{purlType: "npm", requirement: "*", version: "0.0.0-experimental-1a2d79250", want: false},
+		{purlType: "pypi", requirement: "~=3.4", version: "3.9.1", want: true},
+		{purlType: "pypi", requirement: "~=3.4", version: "4.0", want: false},
+		{purlType: "pypi", requirement: "~=3.4", version: "4.0rc1", want: false},
+		{purlType: "pypi", requirement: "~=3.4.1", version: "3.5.0", want: false},
+		{purlType: "pypi", requirement: "==1.*", version: "1.42.0", want: true},
+		{purlType: "pypi", requirement: "==1.*", version: "1.42.0rc1", want: false},
+		{purlType: "pypi", requirement: ">=1.42.0rc1", version: "1.42.0rc2", want: true},
+		{purlType: "pypi", requirement: ">=1.40, !=1.41.0", version: "1.41.0", want: false},
+		{purlType: "pypi", requirement: ">=1.40, !=1.41.0", version: "1.41.1", want: true},
+		{purlType: "gem", requirement: "~> 1.2", version: "1.9", want: true},
+		{purlType: "gem", requirement: "~> 1.2", version: "2.0", want: false},
+		{purlType: "maven", requirement: "[1.0,2.0)", version: "1.5", want: true},
+		{purlType: "maven", requirement: "[1.0,2.0)", version: "2.0", want: false},
+		{purlType: "maven", requirement: "(,1.0]", version: "1.0", want: true},
+		{purlType: "maven", requirement: "[1.5,)", version: "1.5-SNAPSHOT", want: false},
+		{purlType: "maven", requirement: "[1.2]", version: "1.2.0", want: true},
+		{purlType: "maven", requirement: "(,1.0],[1.2,)", version: "1.1", want: false},
+		{purlType: "maven", requirement: "(,1.0],[1.2,)", version: "1.3", want: true},
+	}
+	for _, tt := range tests {
+		req, err := parseVersionRequirement(tt.purlType, tt.requirement)
+		if err != nil {
+			t.Errorf("parseVersionRequirement(%v, %q) error = %v", tt.purlType, tt.requirement, err)
+			continue
+		}
+		if got := req.matches(tt.purlType, tt.version); got != tt.want {
+			t.Errorf("%v requirement %q matches %v = %v, want %v (%v)", tt.purlType, tt.requirement, tt.version, got, tt.want, req)
+		}
+	}
+}
+
+func TestParseVersionRequirementInvalid(t *testing.T) {
+	tests := []struct {
+		purlType    string
+		requirement string
+	}{
+		{purlType: "npm", requirement: ""},
+		{purlType: "npm", requirement: ">=?"},
+		{purlType: "pypi", requirement: "~=3"},
+		{purlType: "maven", requirement: "[1.0,2.0) foo"},
+		{purlType: "maven", requirement: "(1.0)"},
+	}
+	for _, tt := range tests {
+		if _, err := parseVersionRequirement(tt.purlType, tt.requirement); err == nil {
+			t.Errorf("parseVersionRequirement(%v, %q) expected an error", tt.purlType, tt.requirement)
+		}
+	}
+}
+
+func TestIsPreRelease(t *testing.T) {
+	tests := []struct {
+		purlType string
+		version  string
+		want     bool
+	}{
+		{purlType: "npm", version: "1.0.0", want: false},
+		{purlType: "npm", version: "1.0.0-rc.1", want: true},
+		{purlType: "npm", version: "16.9.0-alpha.0", want: true},
+		{purlType: "gem", version: "2.0.0.beta1", want: true},
+		{purlType: "golang", version: "v1.2.0-pre", want: true},
+		{purlType: "npm", version: "1.0.0-dev.20200101", want: true},
+		{purlType: "github", version: "1.0-SNAPSHOT", want: true},
+		{purlType: "github", version: "release-1.0", want: false},
+		{purlType: "github", version: "v1.0.final", want: false},
+		{purlType: "github", version: "v2.3.1-hotfix", want: true}, // Semver pre-release part
+		{purlType: "github", version: "release-2.0-rc1", want: true},
+		{purlType: "github", version: "1.0.0+build.5", want: false},
+		{purlType: "pypi", version: "1.0rc1", want: true},
+		{purlType: "pypi", version: "1.0.post1", want: false},
+		{purlType: "maven", version: "1.0-beta-2", want: true},
+		{purlType: "maven", version: "1.0.Final", want: false},
+		{purlType: "deb", version: "1.0~rc1-1", want: true},
+	}
+	for _, tt := range tests {
+		if got := isPreRelease(tt.purlType, tt.version); got != tt.want {
+			t.Errorf("isPreRelease(%v, %q) = %v, want %v", tt.purlType, tt.version, got, tt.want)
+		}
+	}
+}
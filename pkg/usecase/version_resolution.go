@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cmpHelper "github.com/scanoss/go-component-helper/componenthelper"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	purlhelper "github.com/scanoss/go-purl-helper/pkg"
+	"scanoss.com/components/pkg/dtos"
+	se "scanoss.com/components/pkg/errors"
+)
+
+// maxResolutionCandidates is the maximum number of candidate versions returned by a version resolution.
+const maxResolutionCandidates = 25
+
+// ResolveVersion resolves a version requirement (or the version of the purl) to a concrete version of the component,
+// explaining the choice: the candidate versions, the ecosystem rule applied and why each other version was rejected.
+// See resolveRequirement for how the version is chosen. With no requirement or version, the latest version is chosen.
+func (c ComponentUseCase) ResolveVersion(request dtos.ComponentStatusInput) (dtos.ComponentResolutionOutput, error) {
+	if len(request.Purl) == 0 {
+		c.s.Errorf("The request does not contain purl to resolve the version")
+		return dtos.ComponentResolutionOutput{}, se.NewBadRequestError("purl is required", errors.New("purl is required"))
+	}
+	purl, err := purlhelper.PurlFromString(request.Purl)
+	if err != nil {
+		c.s.Errorf("Invalid purl supplied: %v - %v", request.Purl, err)
+		return dtos.ComponentResolutionOutput{}, se.NewBadRequestError("Invalid purl supplied", err)
+	}
+	requirement := strings.TrimSpace(request.Requirement)
+	if len(requirement) == 0 {
+		requirement = purl.Version
+	}
+	if len(requirement) == 0 {
+		requirement = "*"
+	}
+	req, err := parseVersionRequirement(purl.Type, requirement)
+	if err != nil {
+		c.s.Errorf("Invalid version requirement supplied: %v - %v", requirement, err)
+		return dtos.ComponentResolutionOutput{}, se.NewBadRequestError("Invalid version requirement supplied", err)
+	}
+	return c.resolveVersion(request.Purl, purl.Type, requirement, req)
+}
+
+// resolveVersion resolves the parsed requirement against every known version of the purl, returning the candidates
+// highest first (see resolutionCandidates).
+func (c ComponentUseCase) resolveVersion(purlString, purlType, requirement string, req versionRequirement) (dtos.ComponentResolutionOutput, error) {
+	urls, err := c.allURL.GetVersionsByPurlString(purlString)
+	if err != nil {
+		c.s.Errorf("Problem encountered getting the versions for: %v - %v.", purlString, err)
+		return dtos.ComponentResolutionOutput{}, err
+	}
+	if len(urls) == 0 {
+		return dtos.ComponentResolutionOutput{}, se.NewNotFoundError(fmt.Sprintf("purl: '%v' not found", purlString))
+	}
+	sortVersionsByPrecedence(purlType, urls)
+	versions := make([]dtos.ComponentVersion, 0, len(urls))
+	for _, u := range urls {
+		versions = append(versions, dtos.ComponentVersion{Version: u.Version, Date: u.Date.String, Status: c.versionStatus(u.VersionStatus)})
+	}
+	selected, rejected := resolveRequirement(purlType, versions, req)
+	start, end := resolutionCandidates(len(versions), selected)
+	output := dtos.ComponentResolutionOutput{
+		Purl:              purlString,
+		Requirement:       requirement,
+		Rule:              resolutionRule(purlType),
+		Candidates:        make([]dtos.VersionCandidate, 0, end-start),
+		OmittedCandidates: len(versions) - (end - start),
+	}
+	for i := start; i < end; i++ {
+		v := versions[i]
+		output.Candidates = append(output.Candidates, dtos.VersionCandidate{Version: v.Version, Date: v.Date,
+			Status: v.Status, Selected: i == selected, Rejected: rejected[i]})
+	}
+	if selected >= 0 {
+		output.Version = versions[selected].Version
+	}
+	return output, nil
+}
+
+// resolutionCandidates returns the range of the (highest first) versions to list as candidates: the selected version
+// and the versions nearest to it, up to maxResolutionCandidates. With no selected version, the highest versions are
+// listed.
+func resolutionCandidates(count, selected int) (int, int) {
+	start := max(0, selected-maxResolutionCandidates/2)
+	end := min(count, start+maxResolutionCandidates)
+	return max(0, end-maxResolutionCandidates), end
+}
+
+// resolveRequirement chooses the version a requirement resolves to. This is the highest version satisfying the requirement by
+// the ecosystem ordering, skipping pre-releases (unless the requirement asks for one) and yanked versions (unless no
+// other version satisfies it, i.e. a pinned yanked version). It returns the index of the chosen version (-1 if none)
+// and why each of the other versions was rejected.
+func resolveRequirement(purlType string, versions []dtos.ComponentVersion, req versionRequirement) (int, []string) {
+	rejected := make([]string, len(versions))
+	selected, yanked := -1, -1
+	for i, v := range versions {
+		switch {
+		case !req.inRange(purlType, v.Version):
+			rejected[i] = dtos.RejectedOutOfRange
+		case !req.matches(purlType, v.Version):
+			rejected[i] = dtos.RejectedPreRelease
+		case isYanked(v.Status):
+			rejected[i] = dtos.RejectedYanked
+			if yanked < 0 || compareVersions(purlType, v.Version, versions[yanked].Version) > 0 {
+				yanked = i
+			}
+		default:
+			rejected[i] = dtos.RejectedSuperseded
+			if selected < 0 || compareVersions(purlType, v.Version, versions[selected].Version) > 0 {
+				selected = i
+			}
+		}
+	}
+	if selected < 0 {
+		selected = yanked
+	}
+	if selected >= 0 {
+		rejected[selected] = ""
+	}
+	return selected, rejected
+}
+
+// statusVersion returns the version a status lookup of the requirement resolves to, as reported by the component
+// helper, or an empty string if it resolves to none.
+func (c ComponentUseCase) statusVersion(purlString, requirement string) string {
+	results := cmpHelper.GetComponentsVersion(cmpHelper.ComponentVersionCfg{
+		MaxWorkers: 1,
+		Ctx:        c.ctx,
+		S:          c.s,
+		DB:         c.db,
+		Input: []cmpHelper.ComponentDTO{
+			{Purl: purlString, Requirement: requirement},
+		},
+	})
+	if len(results) == 0 || results[0].Status.StatusCode != domain.Success {
+		return ""
+	}
+	return results[0].Version
+}
+
+// resolutionRule describes the rule used to resolve a version requirement for the purl type.
+func resolutionRule(purlType string) string {
+	scheme := "semver precedence"
+	switch purlType {
+	case "pypi":
+		scheme = "PEP 440 ordering"
+	case "maven":
+		scheme = "Maven ComparableVersion ordering"
+	case "deb":
+		scheme = "Debian epoch:version-revision ordering"
+	case "rpm":
+		scheme = "RPM epoch:version-release ordering"
+	}
+	return "highest version satisfying the requirement by " + scheme +
+		", excluding pre-releases (unless the requirement includes one) and yanked versions (unless no other version satisfies it)"
+}
@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	cmpHelper "github.com/scanoss/go-component-helper/componenthelper"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+	se "scanoss.com/components/pkg/errors"
+	"scanoss.com/components/pkg/models"
+)
+
+func TestComponentUseCase_ResolveVersion(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	if _, err = db.Exec("UPDATE all_urls SET version_status = 'yanked' WHERE purl_name = 'react' AND version = '16.14.0'"); err != nil {
+		t.Fatalf("an error '%s' was not expected when yanking a version", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), nil)
+
+	tests := []struct {
+		name     string
+		request  dtos.ComponentStatusInput
+		want     string
+		rejected map[string]string // Expected rejection reason of some of the candidates
+	}{
+		{
+			name:    "caret range skipping a yanked version",
+			request: dtos.ComponentStatusInput{Purl: "pkg:npm/react", Requirement: "^16.8"},
+			want:    "16.13.1",
+			rejected: map[string]string{"18.0.0": dtos.RejectedOutOfRange, "16.14.0": dtos.RejectedYanked,
+				"16.13.0": dtos.RejectedSuperseded, "16.9.0-rc.0": dtos.RejectedPreRelease, "16.4.0": dtos.RejectedOutOfRange},
+		},
+		{
+			name:     "purl version",
+			request:  dtos.ComponentStatusInput{Purl: "pkg:npm/react@16.4.0"},
+			want:     "16.4.0",
+			rejected: map[string]string{"16.4.1": dtos.RejectedOutOfRange},
+		},
+		{
+			name:     "latest version",
+			request:  dtos.ComponentStatusInput{Purl: "pkg:gem/tablestyle"},
+			want:     "0.99.0",
+			rejected: map[string]string{"0.0.12": dtos.RejectedSuperseded},
+		},
+		{
+			name:    "no matching version",
+			request: dtos.ComponentStatusInput{Purl: "pkg:gem/tablestyle", Requirement: ">=1.0"},
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compUc.ResolveVersion(tt.request)
+			if err != nil {
+				t.Fatalf("ResolveVersion() error = %v", err)
+			}
+			if got.Version != tt.want || len(got.Rule) == 0 || len(got.Candidates) == 0 {
+				t.Errorf("ResolveVersion() version = %q, want %q (rule %q, %v candidates)", got.Version, tt.want, got.Rule, len(got.Candidates))
+			}
+			selected := 0
+			for _, c := range got.Candidates {
+				if c.Selected {
+					selected++
+					if c.Version != tt.want || len(c.Rejected) > 0 {
+						t.Errorf("ResolveVersion() unexpected selected candidate: %+v", c)
+					}
+				}
+				if reason, ok := tt.rejected[c.Version]; ok && c.Rejected != reason {
+					t.Errorf("ResolveVersion() candidate %v rejected = %q, want %q", c.Version, c.Rejected, reason)
+				}
+				if c.Version == "16.14.0" && c.Status != "removed" {
+					t.Errorf("ResolveVersion() candidate %v status = %q, want removed", c.Version, c.Status)
+				}
+			}
+			if selected != min(len(tt.want), 1) {
+				t.Errorf("ResolveVersion() selected %v candidates", selected)
+			}
+			if len(got.Candidates) > maxResolutionCandidates || got.OmittedCandidates < 0 {
+				t.Errorf("ResolveVersion() returned %v candidates, omitting %v", len(got.Candidates), got.OmittedCandidates)
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		request dtos.ComponentStatusInput
+		code    int
+	}{
+		{request: dtos.ComponentStatusInput{}, code: http.StatusBadRequest},
+		{request: dtos.ComponentStatusInput{Purl: "pkg:npm/react", Requirement: "~=1"}, code: http.StatusBadRequest},
+		{request: dtos.ComponentStatusInput{Purl: "pkg:npm/nonexistent"}, code: http.StatusNotFound},
+	} {
+		_, err = compUc.ResolveVersion(tt.request)
+		var svcErr *se.ServiceError
+		if !errors.As(err, &svcErr) || svcErr.HTTPCode != tt.code {
+			t.Errorf("ResolveVersion(%+v) error = %v, want HTTP code %v", tt.request, err, tt.code)
+		}
+	}
+}
+
+func TestResolutionCandidates(t *testing.T) {
+	tests := []struct {
+		count, selected int
+		start, end      int
+	}{
+		{count: 10, selected: 4, start: 0, end: 10},
+		{count: 10, selected: -1, start: 0, end: 10},
+		{count: 100, selected: -1, start: 0, end: maxResolutionCandidates},
+		{count: 100, selected: 5, start: 0, end: maxResolutionCandidates},
+		{count: 100, selected: 50, start: 50 - maxResolutionCandidates/2, end: 50 - maxResolutionCandidates/2 + maxResolutionCandidates},
+		{count: 100, selected: 98, start: 100 - maxResolutionCandidates, end: 100},
+	}
+	for _, tt := range tests {
+		start, end := resolutionCandidates(tt.count, tt.selected)
+		if start != tt.start || end != tt.end {
+			t.Errorf("resolutionCandidates(%v, %v) = %v, %v, want %v, %v", tt.count, tt.selected, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+// TestComponentUseCase_StatusRequirement checks that a status lookup reports the version the component helper
+// resolves the requirement to, unchanged, and that the highest match of the component versions agrees with it.
+func TestComponentUseCase_StatusRequirement(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	compUc := NewComponents(ctx, s, db, database.NewDBSelectContext(s, db, nil, false), myConfig.GetStatusMapper())
+
+	for _, tt := range []dtos.ComponentStatusInput{
+		{Purl: "pkg:npm/react", Requirement: "1.99.0"},
+		{Purl: "pkg:npm/react", Requirement: "^18.0.0"},
+		{Purl: "pkg:npm/react", Requirement: "999.0.0"},
+		{Purl: "pkg:gem/tablestyle", Requirement: "0.99.0"},
+	} {
+		t.Run(tt.Purl+"@"+tt.Requirement, func(t *testing.T) {
+			results := cmpHelper.GetComponentsVersion(cmpHelper.ComponentVersionCfg{MaxWorkers: 1, Ctx: ctx, S: s, DB: db,
+				Input: []cmpHelper.ComponentDTO{{Purl: tt.Purl, Requirement: tt.Requirement}}})
+			if len(results) != 1 {
+				t.Fatalf("GetComponentsVersion() returned %v results, want 1", len(results))
+			}
+			result := results[0]
+			status, err := compUc.GetComponentStatus(tt)
+			if err != nil {
+				t.Fatalf("GetComponentStatus() error = %v", err)
+			}
+			if status.Requirement != tt.Requirement || status.VersionStatus == nil {
+				t.Fatalf("GetComponentStatus() unexpected output: %+v", status)
+			}
+			//nolint:exhaustive
+			switch result.Status.StatusCode {
+			case domain.Success:
+				if status.VersionStatus.Version != result.Version {
+					t.Errorf("GetComponentStatus() version = %q, want %q", status.VersionStatus.Version, result.Version)
+				}
+			case domain.VersionNotFound:
+				if status.VersionStatus.Version != tt.Requirement || status.VersionStatus.ErrorMessage == nil ||
+					*status.VersionStatus.ErrorMessage != result.Status.Message {
+					t.Errorf("GetComponentStatus() unexpected version not found output: %+v", status.VersionStatus)
+				}
+			}
+			versions, err := compUc.GetComponentVersions(dtos.ComponentVersionsInput{Purl: tt.Purl, Requirement: tt.Requirement, Limit: 1000})
+			if err != nil {
+				t.Fatalf("GetComponentVersions() error = %v", err)
+			}
+			for _, v := range versions.Component.Versions {
+				if v.HighestMatch && result.Status.StatusCode == domain.Success && v.Version != result.Version {
+					t.Errorf("GetComponentVersions() highest match = %q, want %q", v.Version, result.Version)
+				}
+			}
+		})
+	}
+}
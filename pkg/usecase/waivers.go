@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	purlhelper "github.com/scanoss/go-purl-helper/pkg"
+	"scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+)
+
+// ApplyWaivers applies the supplied waivers to the removed and deprecated audit findings.
+// Findings covered by an active waiver are moved from the findings to the waived list, while findings whose
+// only matching waiver has expired are kept (resurfaced) with details of the expired waiver.
+func ApplyWaivers(waivers *config.Waivers, output *dtos.ComponentsAuditOutput, now time.Time) {
+	if waivers == nil || output == nil {
+		return
+	}
+	findings := make([]dtos.ComponentAuditFinding, 0, len(output.Findings))
+	for _, finding := range output.Findings {
+		waiver := findWaiver(waivers, finding, now)
+		if waiver == nil {
+			findings = append(findings, finding)
+			continue
+		}
+		finding.Waiver = waiver
+		if waiver.Expired {
+			output.Summary.ExpiredWaivers++
+			msg := fmt.Sprintf("waiver expired on %s (owner: %s)", waiver.Expires, waiver.Owner)
+			finding.Message = joinNonEmpty("; ", msg, finding.Message)
+			findings = append(findings, finding)
+			continue
+		}
+		switch finding.Classification {
+		case dtos.AuditRemoved:
+			output.Summary.Removed--
+		case dtos.AuditDeprecated:
+			output.Summary.Deprecated--
+		}
+		output.Summary.Waived++
+		output.Waived = append(output.Waived, finding)
+	}
+	output.Findings = findings
+}
+
+// findWaiver returns the waiver covering the finding, preferring active waivers over expired ones (nil if none match).
+// Only removed and deprecated findings can be waived, and waivers restricted to a version range only apply to findings
+// with a known (resolved) version, never to a bare requirement.
+func findWaiver(waivers *config.Waivers, finding dtos.ComponentAuditFinding, now time.Time) *dtos.ComponentWaiver {
+	if finding.Classification != dtos.AuditRemoved && finding.Classification != dtos.AuditDeprecated {
+		return nil
+	}
+	var purlType string
+	if purl, err := purlhelper.PurlFromString(finding.Purl); err == nil {
+		purlType = purl.Type
+	}
+	var expired *dtos.ComponentWaiver
+	for i := range waivers.Waivers {
+		w := &waivers.Waivers[i]
+		if !w.MatchesPurl(finding.Purl) {
+			continue
+		}
+		if len(w.Status) > 0 && !slices.Contains(w.Status, finding.Classification) {
+			continue
+		}
+		if constraints := w.Constraints(); len(constraints) > 0 && (len(finding.Version) == 0 || !matchesVersionConstraints(purlType, finding.Version, constraints)) {
+			continue
+		}
+		waiver := &dtos.ComponentWaiver{
+			Purl:          w.Purl,
+			Vendor:        w.Vendor,
+			Versions:      w.Versions,
+			Expires:       w.Expires,
+			Justification: w.Justification,
+			Owner:         w.Owner,
+			Expired:       w.IsExpired(now),
+		}
+		if !waiver.Expired {
+			return waiver
+		}
+		if expired == nil {
+			expired = waiver
+		}
+	}
+	return expired
+}
+
+// joinNonEmpty joins the non-empty values with the given separator.
+func joinNonEmpty(sep string, values ...string) string {
+	var result string
+	for _, v := range values {
+		if len(v) == 0 {
+			continue
+		}
+		if len(result) > 0 {
+			result += sep
+		}
+		result += v
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	myconfig "scanoss.com/components/pkg/config"
+	"scanoss.com/components/pkg/dtos"
+)
+
+func TestApplyWaivers(t *testing.T) {
+	waivers, err := myconfig.LoadWaiversFile("../config/tests/waivers.yaml")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when loading the waivers", err)
+	}
+	output := dtos.ComponentsAuditOutput{
+		Summary: dtos.ComponentsAuditSummary{Total: 6, Removed: 3, Deprecated: 2, Unknown: 1},
+		Findings: []dtos.ComponentAuditFinding{
+			{Purl: "pkg:npm/left-pad", Version: "1.3.0", Classification: dtos.AuditRemoved},           // Waived
+			{Purl: "pkg:npm/left-pad", Version: "2.0.0", Classification: dtos.AuditRemoved},           // Outside the version range
+			{Purl: "pkg:github/scanoss/old", Classification: dtos.AuditDeprecated, Message: "old"},    // Expired vendor waiver
+			{Purl: "pkg:gem/tablestyle", Version: "0.1.0", Classification: dtos.AuditRemoved},         // Waived
+			{Purl: "pkg:npm/request", Version: "2.88.2", Classification: dtos.AuditDeprecated},        // No waiver
+			{Purl: "pkg:github/scanoss/missing", Version: "1.0.0", Classification: dtos.AuditUnknown}, // Unknown cannot be waived
+		},
+	}
+	ApplyWaivers(waivers, &output, time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC))
+
+	wantSummary := dtos.ComponentsAuditSummary{Total: 6, Removed: 1, Deprecated: 2, Unknown: 1, Waived: 2, ExpiredWaivers: 1}
+	if output.Summary != wantSummary {
+		t.Errorf("summary = %+v, want %+v", output.Summary, wantSummary)
+	}
+	if len(output.Waived) != 2 || output.Waived[0].Version != "1.3.0" || output.Waived[1].Purl != "pkg:gem/tablestyle" {
+		t.Errorf("unexpected waived findings: %+v", output.Waived)
+	}
+	if w := output.Waived[0].Waiver; w == nil || w.Owner != "web-team" || w.Expired {
+		t.Errorf("unexpected waiver details: %+v", w)
+	}
+	if len(output.Findings) != 4 {
+		t.Fatalf("expected 4 remaining findings, got %d", len(output.Findings))
+	}
+	expired := output.Findings[1]
+	if expired.Waiver == nil || !expired.Waiver.Expired {
+		t.Errorf("expected the expired waiver to be reported: %+v", expired)
+	}
+	if !strings.HasPrefix(expired.Message, "waiver expired on 2026-01-31 (owner: platform-team)") || !strings.HasSuffix(expired.Message, "; old") {
+		t.Errorf("unexpected expired waiver message: %q", expired.Message)
+	}
+	if output.Findings[0].Waiver != nil || output.Findings[2].Waiver != nil || output.Findings[3].Waiver != nil {
+		t.Errorf("did not expect waivers on the remaining findings")
+	}
+	// Versioned waivers are not applied when only the requirement is known
+	requirement := dtos.ComponentsAuditOutput{
+		Summary:  dtos.ComponentsAuditSummary{Total: 1, Removed: 1},
+		Findings: []dtos.ComponentAuditFinding{{Purl: "pkg:npm/left-pad", Requirement: "^1.3.0", Classification: dtos.AuditRemoved}},
+	}
+	ApplyWaivers(waivers, &requirement, time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC))
+	if requirement.Summary.Waived != 0 || len(requirement.Findings) != 1 || requirement.Findings[0].Waiver != nil {
+		t.Errorf("did not expect a versioned waiver to apply to a requirement: %+v", requirement)
+	}
+	// No waivers configured should leave the output untouched
+	ApplyWaivers(nil, &output, time.Now())
+	if output.Summary != wantSummary {
+		t.Errorf("expected a nil waivers list to be ignored")
+	}
+}